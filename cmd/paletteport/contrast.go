@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/contrast"
+)
+
+func runContrast(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("contrast", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: paletteport contrast [--from <format>] [--standard wcag|apca] [--level AA|AAA] [input]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Checks the contrast of the scheme's text colors against their backgrounds.")
+		fmt.Fprintln(stderr, "Exits with status 1 if any pair is below the level.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+
+	var from, standardName, levelName string
+	var asJSON bool
+	fs.StringVar(&from, "from", "", "input format (adapter name, detected if omitted)")
+	fs.StringVar(&standardName, "standard", "wcag", "contrast standard: wcag or apca")
+	fs.StringVar(&levelName, "level", "AA", "level every pair must reach: AA or AAA")
+	fs.BoolVar(&asJSON, "json", false, "print the report as JSON")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 1 {
+		fmt.Fprintf(stderr, "paletteport contrast: expected at most one input, got %d\n", len(positional))
+		return exitUsage
+	}
+	standard, err := contrast.ParseStandard(standardName)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport contrast: %v\n", err)
+		return exitUsage
+	}
+	level, err := contrast.ParseLevel(levelName)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport contrast: %v\n", err)
+		return exitUsage
+	}
+
+	inputPath := ""
+	if len(positional) == 1 {
+		inputPath = positional[0]
+	}
+
+	input, err := readInput(inputPath, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport contrast: %v\n", err)
+		return exitError
+	}
+
+	reader, code := resolveReader(from, inputPath, input, stderr, "contrast")
+	if reader == nil {
+		return code
+	}
+
+	scheme, _, err := adapter.ParseAbstract(input, reader)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport contrast: %v\n", err)
+		return exitError
+	}
+
+	report, err := contrast.Audit(scheme)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport contrast: %v\n", err)
+		return exitError
+	}
+
+	out := report.String()
+	if asJSON {
+		if out, err = report.JSON(); err != nil {
+			fmt.Fprintf(stderr, "paletteport contrast: %v\n", err)
+			return exitError
+		}
+	}
+	if _, err := io.WriteString(stdout, out); err != nil {
+		fmt.Fprintf(stderr, "paletteport contrast: %v\n", err)
+		return exitError
+	}
+
+	if failing := report.Failing(standard, level); len(failing) > 0 {
+		fmt.Fprintf(stderr, "paletteport contrast: %d of %d pairs are below %s %s\n",
+			len(failing), len(report.Results), standard, level)
+		return exitError
+	}
+	return exitOK
+}
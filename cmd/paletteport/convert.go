@@ -0,0 +1,274 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/templates"
+)
+
+func runConvert(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: paletteport convert [--from <format>] (--to <format> | --template <file>) [--scheme <name|index> | --all] [-o output] [input]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Reads input from the given file, or stdin when omitted or \"-\".")
+		fmt.Fprintln(stderr, "The input format is detected when --from is omitted.")
+		fmt.Fprintln(stderr, "Inputs holding several schemes convert their first or default one, unless")
+		fmt.Fprintln(stderr, "--scheme (or --profile) picks another or --all converts them all into a single output.")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Templates are looked up in --template-dir, then")
+		fmt.Fprintln(stderr, "$XDG_CONFIG_HOME/paletteport/templates, then the built-in ones, so a file")
+		fmt.Fprintln(stderr, "there named like a built-in template, e.g. alacritty.toml.tmpl, replaces it.")
+		fmt.Fprintln(stderr, "--template renders the abstract scheme with any template file instead.")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Colors the scheme has no value for are derived by the fallback rules, and left")
+		fmt.Fprintln(stderr, "out where the format allows; the conversion fails listing those that can't be.")
+		fmt.Fprintln(stderr, "--missing fail fails on any missing color, omit skips the fallbacks, and")
+		fmt.Fprintln(stderr, "placeholder writes --placeholder in their place.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+
+	var from, to, scheme, output, reportFormat, fallbacksPath, templateDir, templateFile, missing, placeholder string
+	var maxLoss int
+	var all bool
+	fs.StringVar(&from, "from", "", "input format (adapter name, detected if omitted)")
+	fs.StringVar(&to, "to", "", "output format (adapter name)")
+	fs.StringVar(&scheme, "scheme", "", "name or index of the scheme to convert from an input holding several")
+	fs.StringVar(&scheme, "profile", "", "same as --scheme, e.g. for the profiles of a Terminator config")
+	fs.BoolVar(&all, "all", false, "convert every scheme of the input into a single output")
+	fs.StringVar(&templateDir, "template-dir", "", "directory searched for templates before the user's and built-in ones")
+	fs.StringVar(&templateFile, "template", "", "template file to render the abstract scheme with, instead of --to")
+	fs.StringVar(&output, "o", "", "output file (default stdout)")
+	fs.StringVar(&output, "output", "", "output file (default stdout)")
+	fs.StringVar(&reportFormat, "report", "", "print a conversion report to stderr: text or json")
+	fs.StringVar(&fallbacksPath, "fallbacks", "", "YAML file of fallback rules for missing colors, on top of the defaults")
+	fs.StringVar(&missing, "missing", "fallback", "what to do with colors the scheme has no value for: fallback, fail, omit or placeholder")
+	fs.StringVar(&placeholder, "placeholder", "", "color written for missing colors with --missing placeholder (default #ff00ff)")
+	fs.IntVar(&maxLoss, "max-loss", -1, "fail if more fields than this are dropped, unused or left empty (-1 disables)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 1 {
+		fmt.Fprintf(stderr, "paletteport convert: expected at most one input, got %d\n", len(positional))
+		return exitUsage
+	}
+	if (to == "") == (templateFile == "") {
+		fmt.Fprintln(stderr, "paletteport convert: exactly one of --to and --template is required")
+		return exitUsage
+	}
+	if templateFile != "" && all {
+		fmt.Fprintln(stderr, "paletteport convert: --template renders a single scheme and can't be used with --all")
+		return exitUsage
+	}
+	if scheme != "" && all {
+		fmt.Fprintln(stderr, "paletteport convert: --scheme and --all are mutually exclusive")
+		return exitUsage
+	}
+	if reportFormat != "" && reportFormat != "text" && reportFormat != "json" {
+		fmt.Fprintf(stderr, "paletteport convert: unknown report format %q, expected text or json\n", reportFormat)
+		return exitUsage
+	}
+
+	missingPolicy, err := templates.ParseMissingPolicy(missing)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport convert: %v\n", err)
+		return exitUsage
+	}
+	opts := adapter.ConvertOptions{Missing: templates.Missing{Policy: missingPolicy}}
+	if placeholder != "" {
+		if missingPolicy != templates.MissingPlaceholder {
+			fmt.Fprintln(stderr, "paletteport convert: --placeholder requires --missing placeholder")
+			return exitUsage
+		}
+		c, err := color.FromHex(placeholder)
+		if err != nil {
+			fmt.Fprintf(stderr, "paletteport convert: invalid placeholder: %v\n", err)
+			return exitUsage
+		}
+		opts.Missing.Placeholder = &c
+	}
+
+	inputPath := ""
+	if len(positional) == 1 {
+		inputPath = positional[0]
+	}
+
+	var writer adapter.Adapter
+	if to != "" {
+		if writer, err = adapter.GetAdapter(to); err != nil {
+			fmt.Fprintf(stderr, "paletteport convert: %v\n", err)
+			return exitUsage
+		}
+	}
+
+	input, err := readInput(inputPath, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport convert: %v\n", err)
+		return exitError
+	}
+
+	reader, code := resolveReader(from, inputPath, input, stderr, "convert")
+	if reader == nil {
+		return code
+	}
+
+	if scheme != "" {
+		if input, err = extractScheme(reader, input, scheme); err != nil {
+			fmt.Fprintf(stderr, "paletteport convert: %v\n", err)
+			return exitError
+		}
+	}
+
+	fallbacks, err := loadFallbacks(fallbacksPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport convert: %v\n", err)
+		return exitError
+	}
+
+	opts.Fallbacks = fallbacks
+	var templateDirs []string
+	if templateDir != "" {
+		templateDirs = append(templateDirs, templateDir)
+	}
+	opts.Templates = templates.DefaultSearchPath(templateDirs...)
+	var result string
+	var reports []*adapter.ConversionReport
+	switch {
+	case all:
+		result, reports, err = convertAll(input, reader, writer, opts)
+	case templateFile != "":
+		var report *adapter.ConversionReport
+		result, report, err = adapter.ConvertToTemplateWith(input, reader, templateFile, opts)
+		reports = []*adapter.ConversionReport{report}
+	default:
+		var report *adapter.ConversionReport
+		result, report, err = adapter.ConvertThemeWith(input, reader, writer, opts)
+		reports = []*adapter.ConversionReport{report}
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport convert: %v\n", err)
+		return exitError
+	}
+
+	for _, report := range reports {
+		if err := printReport(stderr, report, reportFormat); err != nil {
+			fmt.Fprintf(stderr, "paletteport convert: %v\n", err)
+			return exitError
+		}
+	}
+	for _, report := range reports {
+		if maxLoss >= 0 && report.Loss() > maxLoss {
+			fmt.Fprintf(stderr, "paletteport convert: conversion lost %d fields, more than the allowed %d\n", report.Loss(), maxLoss)
+			return exitError
+		}
+	}
+
+	if err := writeOutput(output, stdout, result); err != nil {
+		fmt.Fprintf(stderr, "paletteport convert: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// printReport writes the report in the given format, or nothing if format is empty
+func printReport(w io.Writer, report *adapter.ConversionReport, format string) error {
+	switch format {
+	case "":
+		return nil
+	case "json":
+		out, err := report.JSON()
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, out)
+		return err
+	default:
+		_, err := io.WriteString(w, report.String())
+		return err
+	}
+}
+
+// resolveReader looks up the adapter named by --from, or detects it from the
+// input when no name was given. On failure it reports the error and returns a
+// nil adapter along with the exit code to use.
+func resolveReader(from, inputPath, input string, stderr io.Writer, cmd string) (adapter.Adapter, int) {
+	if from != "" {
+		reader, err := adapter.GetAdapter(from)
+		if err != nil {
+			fmt.Fprintf(stderr, "paletteport %s: %v\n", cmd, err)
+			return nil, exitUsage
+		}
+		return reader, exitOK
+	}
+
+	reader, err := adapter.DetectAdapter(inputPath, input)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport %s: %v; pass --from to choose one\n", cmd, err)
+		return nil, exitError
+	}
+	return reader, exitOK
+}
+
+// extractScheme returns the named or numbered scheme of an input holding
+// several
+func extractScheme(reader adapter.Adapter, input, ref string) (string, error) {
+	multi, err := adapter.GetMultiAdapter(reader)
+	if err != nil {
+		return "", err
+	}
+	return adapter.ExtractScheme(input, multi, ref)
+}
+
+// convertAll converts every scheme of the input into a bundle
+func convertAll(input string, reader, writer adapter.Adapter, opts adapter.ConvertOptions) (string, []*adapter.ConversionReport, error) {
+	multiReader, err := adapter.GetMultiAdapter(reader)
+	if err != nil {
+		return "", nil, err
+	}
+	multiWriter, err := adapter.GetMultiAdapter(writer)
+	if err != nil {
+		return "", nil, err
+	}
+	return adapter.ConvertBundleWith(input, multiReader, multiWriter, opts)
+}
+
+// loadFallbacks loads the user's fallback rules, or returns nil for the
+// defaults when no file was given
+func loadFallbacks(path string) (*adapter.FallbackRules, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return adapter.LoadFallbackRules(path)
+}
+
+// parseArgs parses flags that may be interspersed with positional arguments,
+// e.g. "convert theme.toml --to iterm", which the flag package alone rejects.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+)
+
+func runDetect(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("detect", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: paletteport detect [input]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Lists the formats the input may be in, most likely first.")
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 1 {
+		fmt.Fprintf(stderr, "paletteport detect: expected at most one input, got %d\n", len(positional))
+		return exitUsage
+	}
+
+	inputPath := ""
+	if len(positional) == 1 {
+		inputPath = positional[0]
+	}
+
+	input, err := readInput(inputPath, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport detect: %v\n", err)
+		return exitError
+	}
+
+	candidates := adapter.Detect(inputPath, input)
+	if len(candidates) == 0 {
+		fmt.Fprintln(stderr, "paletteport detect: no matching format")
+		return exitError
+	}
+	for _, c := range candidates {
+		fmt.Fprintf(stdout, "%-12s %.2f\n", c.Name, c.Confidence)
+	}
+	return exitOK
+}
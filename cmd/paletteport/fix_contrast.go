@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/contrast"
+	"github.com/da-luce/paletteport/templates"
+)
+
+func runFixContrast(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("fix-contrast", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: paletteport fix-contrast [--from <format>] [--to <format>] [--standard wcag|apca] [--level AA|AAA] [-o output] [input]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Adjusts the lightness of text colors that don't reach the contrast level")
+		fmt.Fprintln(stderr, "against their background, and prints the changes to stderr.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+
+	var from, to, output, standardName, levelName, skip, reportFormat, fallbacksPath string
+	var target float64
+	fs.StringVar(&from, "from", "", "input format (adapter name, detected if omitted)")
+	fs.StringVar(&to, "to", "", "output format (adapter name, defaults to the input format)")
+	fs.StringVar(&output, "o", "", "output file (default stdout)")
+	fs.StringVar(&output, "output", "", "output file (default stdout)")
+	fs.StringVar(&standardName, "standard", "wcag", "contrast standard: wcag or apca")
+	fs.StringVar(&levelName, "level", "AA", "level every pair must reach: AA or AAA")
+	fs.Float64Var(&target, "target", 0, "contrast every pair must reach, overriding --level (ratio for wcag, absolute Lc for apca)")
+	fs.StringVar(&skip, "skip", "", "comma separated foreground fields to leave alone, e.g. AnsiColors.Black")
+	fs.StringVar(&reportFormat, "report", "text", "format of the report printed to stderr: text or json")
+	fs.StringVar(&fallbacksPath, "fallbacks", "", "YAML file of fallback rules for missing colors, on top of the defaults")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 1 {
+		fmt.Fprintf(stderr, "paletteport fix-contrast: expected at most one input, got %d\n", len(positional))
+		return exitUsage
+	}
+	if reportFormat != "text" && reportFormat != "json" {
+		fmt.Fprintf(stderr, "paletteport fix-contrast: unknown report format %q, expected text or json\n", reportFormat)
+		return exitUsage
+	}
+	standard, err := contrast.ParseStandard(standardName)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport fix-contrast: %v\n", err)
+		return exitUsage
+	}
+	if target <= 0 {
+		level, err := contrast.ParseLevel(levelName)
+		if err != nil {
+			fmt.Fprintf(stderr, "paletteport fix-contrast: %v\n", err)
+			return exitUsage
+		}
+		target = contrast.Threshold(standard, level)
+	}
+
+	inputPath := ""
+	if len(positional) == 1 {
+		inputPath = positional[0]
+	}
+
+	input, err := readInput(inputPath, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport fix-contrast: %v\n", err)
+		return exitError
+	}
+
+	reader, code := resolveReader(from, inputPath, input, stderr, "fix-contrast")
+	if reader == nil {
+		return code
+	}
+	if to == "" {
+		to = reader.Name()
+	}
+	writer, err := adapter.GetAdapter(to)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport fix-contrast: %v\n", err)
+		return exitUsage
+	}
+
+	fallbacks, err := loadFallbacks(fallbacksPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport fix-contrast: %v\n", err)
+		return exitError
+	}
+
+	pairs := fixablePairs(skip)
+	var fixReport *contrast.FixReport
+	result, _, err := adapter.ConvertThemeWith(input, reader, writer, adapter.ConvertOptions{
+		Fallbacks: fallbacks,
+		Templates: templates.DefaultSearchPath(),
+		Transform: func(s *adapter.AbstractScheme) error {
+			fixReport, err = contrast.FixPairs(s, pairs, standard, target)
+			return err
+		},
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport fix-contrast: %v\n", err)
+		return exitError
+	}
+
+	report := fixReport.String()
+	if reportFormat == "json" {
+		if report, err = fixReport.JSON(); err != nil {
+			fmt.Fprintf(stderr, "paletteport fix-contrast: %v\n", err)
+			return exitError
+		}
+	}
+	io.WriteString(stderr, report)
+
+	if err := writeOutput(output, stdout, result); err != nil {
+		fmt.Fprintf(stderr, "paletteport fix-contrast: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// fixablePairs returns the audited pairs, without those whose foreground is
+// in the comma separated skip list
+func fixablePairs(skip string) []contrast.Pair {
+	skipped := make(map[string]bool)
+	for _, field := range strings.Split(skip, ",") {
+		skipped[strings.TrimSpace(field)] = true
+	}
+
+	var pairs []contrast.Pair
+	for _, pair := range contrast.SchemePairs() {
+		if !skipped[pair.Foreground] {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/image"
+	"github.com/da-luce/paletteport/templates"
+)
+
+func runFromImage(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("from-image", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: paletteport from-image --to <format> [--method kmeans|median-cut] [--colors N] [--light] [-o output] [image]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Makes a scheme from the dominant colors of a PNG, JPEG or GIF image: the")
+		fmt.Fprintln(stderr, "darkest and lightest become the background and foreground, and the ANSI")
+		fmt.Fprintln(stderr, "colors take the colors closest in hue to red, green, yellow, blue, magenta")
+		fmt.Fprintln(stderr, "and cyan, adjusted to reach --min-contrast against the background.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+
+	var to, output, methodName, name, fallbacksPath string
+	var colors int
+	var light bool
+	var minContrast float64
+	fs.StringVar(&to, "to", "", "output format (adapter name)")
+	fs.StringVar(&output, "o", "", "output file (default stdout)")
+	fs.StringVar(&output, "output", "", "output file (default stdout)")
+	fs.StringVar(&methodName, "method", "kmeans", "how dominant colors are extracted: kmeans or median-cut")
+	fs.IntVar(&colors, "colors", 16, "number of dominant colors to extract")
+	fs.BoolVar(&light, "light", false, "make a light scheme rather than a dark one")
+	fs.Float64Var(&minContrast, "min-contrast", 4.5, "WCAG contrast ratio text colors reach against the background")
+	fs.StringVar(&name, "name", "", "name of the scheme (default the image's file name)")
+	fs.StringVar(&fallbacksPath, "fallbacks", "", "YAML file of fallback rules for missing colors, on top of the defaults")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 1 {
+		fmt.Fprintf(stderr, "paletteport from-image: expected at most one image, got %d\n", len(positional))
+		return exitUsage
+	}
+	if to == "" {
+		fmt.Fprintln(stderr, "paletteport from-image: --to is required")
+		return exitUsage
+	}
+	method, err := image.ParseMethod(methodName)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport from-image: %v\n", err)
+		return exitUsage
+	}
+	if colors < 1 || minContrast < 1 || minContrast > 21 {
+		fmt.Fprintln(stderr, "paletteport from-image: --colors must be at least 1 and --min-contrast between 1 and 21")
+		return exitUsage
+	}
+	writer, err := adapter.GetAdapter(to)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport from-image: %v\n", err)
+		return exitUsage
+	}
+
+	inputPath := ""
+	if len(positional) == 1 {
+		inputPath = positional[0]
+	}
+	if name == "" && inputPath != "" && inputPath != "-" {
+		base := filepath.Base(inputPath)
+		name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+
+	in := stdin
+	if inputPath != "" && inputPath != "-" {
+		f, err := os.Open(inputPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "paletteport from-image: failed to read input: %v\n", err)
+			return exitError
+		}
+		defer f.Close()
+		in = f
+	}
+	img, err := image.Decode(in)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport from-image: %v\n", err)
+		return exitError
+	}
+
+	fallbacks, err := loadFallbacks(fallbacksPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport from-image: %v\n", err)
+		return exitError
+	}
+
+	scheme, err := image.FromImage(img, image.Options{
+		Method:      method,
+		Colors:      colors,
+		Light:       light,
+		MinContrast: minContrast,
+		Name:        name,
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport from-image: %v\n", err)
+		return exitError
+	}
+
+	result, _, err := adapter.RenderSchemeWith(scheme, writer, adapter.ConvertOptions{
+		Fallbacks: fallbacks,
+		Templates: templates.DefaultSearchPath(),
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport from-image: %v\n", err)
+		return exitError
+	}
+	if err := writeOutput(output, stdout, result); err != nil {
+		fmt.Fprintf(stderr, "paletteport from-image: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
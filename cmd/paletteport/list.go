@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+)
+
+func runList(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: paletteport list [--from <format>] [--json] [input]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Lists the schemes of an input holding several, by index and name.")
+		fmt.Fprintln(stderr, "Either can be passed to convert --scheme.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+
+	var from string
+	var asJSON bool
+	fs.StringVar(&from, "from", "", "input format (adapter name, detected if omitted)")
+	fs.BoolVar(&asJSON, "json", false, "print the schemes as JSON")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 1 {
+		fmt.Fprintf(stderr, "paletteport list: expected at most one input, got %d\n", len(positional))
+		return exitUsage
+	}
+
+	inputPath := ""
+	if len(positional) == 1 {
+		inputPath = positional[0]
+	}
+
+	input, err := readInput(inputPath, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport list: %v\n", err)
+		return exitError
+	}
+
+	reader, code := resolveReader(from, inputPath, input, stderr, "list")
+	if reader == nil {
+		return code
+	}
+	multi, err := adapter.GetMultiAdapter(reader)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport list: %v\n", err)
+		return exitError
+	}
+
+	entries, err := adapter.ListSchemes(input, multi)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport list: %v\n", err)
+		return exitError
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "paletteport list: %v\n", err)
+			return exitError
+		}
+		fmt.Fprintln(stdout, string(data))
+		return exitOK
+	}
+	for _, e := range entries {
+		fmt.Fprintf(stdout, "%-4d %s\n", e.Index, e.Name)
+	}
+	return exitOK
+}
@@ -0,0 +1,108 @@
+// Command paletteport views and converts color schemes between the formats
+// understood by the adapter package.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+)
+
+// Exit codes returned by run
+const (
+	exitOK    = 0
+	exitError = 1 // The command ran but failed (parse, mapping, IO, ...)
+	exitUsage = 2 // The command line itself was invalid
+)
+
+// A subcommand receives its own arguments (without the subcommand name)
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+}
+
+func commands() []command {
+	return []command{
+		{"convert", "Convert a scheme from one format to another", runConvert},
+		{"detect", "Guess the format of a scheme", runDetect},
+		{"list", "List the schemes of an input holding several", runList},
+		{"preview", "Render a scheme in the terminal", runPreview},
+		{"contrast", "Check the contrast of a scheme's text colors", runContrast},
+		{"fix-contrast", "Adjust text colors to reach a contrast level", runFixContrast},
+		{"variant", "Derive the light or dark variant of a scheme", runVariant},
+		{"quantize", "Map a scheme's colors onto the 256 or 16-color palette", runQuantize},
+		{"from-image", "Make a scheme from the dominant colors of an image", runFromImage},
+		{"schema", "Print the JSON Schema of paletteport's own format", runSchema},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run dispatches to the requested subcommand and returns the process exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "-h", "-help", "--help", "help":
+		printUsage(stdout)
+		return exitOK
+	}
+
+	for _, cmd := range commands() {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdin, stdout, stderr)
+		}
+	}
+
+	fmt.Fprintf(stderr, "paletteport: unknown command %q\n\n", args[0])
+	printUsage(stderr)
+	return exitUsage
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: paletteport <command> [flags] [input]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands() {
+		fmt.Fprintf(w, "  %-12s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Formats: %s\n", strings.Join(adapter.AdapterNames(), ", "))
+	fmt.Fprintln(w, "Run 'paletteport <command> -h' for command flags.")
+}
+
+// readInput reads the named file, or stdin when path is empty or "-"
+func readInput(path string, stdin io.Reader) (string, error) {
+	var data []byte
+	var err error
+	if path == "" || path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return string(data), nil
+}
+
+// writeOutput writes to the named file, or stdout when path is empty or "-"
+func writeOutput(path string, stdout io.Writer, output string) error {
+	if path == "" || path == "-" {
+		_, err := io.WriteString(stdout, output)
+		return err
+	}
+	if err := os.WriteFile(path, []byte(output), 0o644); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,584 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	stdcolor "image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/contrast"
+)
+
+// runCLI runs the command line with the given stdin and captures its output.
+// The user's template directory is left empty, so the built-in templates are
+// used.
+func runCLI(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	return runCLIWithConfig(t, t.TempDir(), stdin, args...)
+}
+
+// runCLIWithConfig is runCLI with XDG_CONFIG_HOME set to the given directory
+func runCLIWithConfig(t *testing.T, config string, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", config)
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func readTheme(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "..", "themes", name))
+	if err != nil {
+		t.Fatalf("failed to read theme %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestRun_NoArgs(t *testing.T) {
+	code, _, stderr := runCLI(t, "")
+	if code != exitUsage {
+		t.Errorf("expected exit code %d, got %d", exitUsage, code)
+	}
+	if !strings.Contains(stderr, "Usage") {
+		t.Errorf("expected usage on stderr, got %q", stderr)
+	}
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	code, _, _ := runCLI(t, "", "frobnicate")
+	if code != exitUsage {
+		t.Errorf("expected exit code %d, got %d", exitUsage, code)
+	}
+}
+
+func TestConvert_StdinToStdout(t *testing.T) {
+	code, stdout, stderr := runCLI(t, readTheme(t, "wt.json"), "convert", "--from", "wt", "--to", "alacritty")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.Contains(stdout, "[colors.primary]") {
+		t.Errorf("expected alacritty output, got:\n%s", stdout)
+	}
+	if !strings.Contains(stdout, "#0f1419") {
+		t.Errorf("expected background color to carry over, got:\n%s", stdout)
+	}
+}
+
+func TestConvert_FileToFile(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.itermcolors")
+	in := filepath.Join("..", "..", "themes", "alacritty.toml")
+
+	code, stdout, stderr := runCLI(t, "", "convert", in, "--from", "alacritty", "--to", "iterm", "-o", out)
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if stdout != "" {
+		t.Errorf("expected nothing on stdout, got %q", stdout)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("expected output file: %v", err)
+	}
+	if !strings.Contains(string(data), "<key>Ansi 0 Color</key>") {
+		t.Errorf("expected iterm output, got:\n%s", data)
+	}
+}
+
+func TestConvert_DetectsFormat(t *testing.T) {
+	in := filepath.Join("..", "..", "themes", "gogh.yml")
+	code, stdout, stderr := runCLI(t, "", "convert", "--to", "wt", in)
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.Contains(stdout, "#161719") {
+		t.Errorf("expected gogh background in output, got:\n%s", stdout)
+	}
+}
+
+func TestConvert_Report(t *testing.T) {
+	// Windows Terminal has no cursor text color, so alacritty's is filled from the background
+	code, _, stderr := runCLI(t, readTheme(t, "wt.json"), "convert", "--from", "wt", "--to", "alacritty", "--report", "json")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+
+	var report adapter.ConversionReport
+	if err := json.Unmarshal([]byte(stderr), &report); err != nil {
+		t.Fatalf("expected JSON report on stderr: %v\n%s", err, stderr)
+	}
+	if report.Reader != "wt" || report.Writer != "alacritty" {
+		t.Errorf("unexpected report adapters: %s -> %s", report.Reader, report.Writer)
+	}
+	found := false
+	for _, f := range report.Filled {
+		if f.Path == "Colors.Cursor.Text" && f.Source == "SpecialColors.Background" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected Colors.Cursor.Text to be filled from the background, got %+v", report.Filled)
+	}
+}
+
+func TestConvert_Fallbacks(t *testing.T) {
+	rules := filepath.Join(t.TempDir(), "fallbacks.yml")
+	data := "rules:\n  - {target: SpecialColors.CursorText, from: AnsiColors.Red, derive: darken(0.2), priority: 1}\n"
+	if err := os.WriteFile(rules, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, _, stderr := runCLI(t, readTheme(t, "wt.json"), "convert", "--from", "wt", "--to", "alacritty", "--fallbacks", rules, "--report", "text")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.Contains(stderr, "(from AnsiColors.Red | darken(0.2))") {
+		t.Errorf("expected the user rule to take precedence, got:\n%s", stderr)
+	}
+
+	code, _, _ = runCLI(t, readTheme(t, "wt.json"), "convert", "--from", "wt", "--to", "alacritty", "--fallbacks", "missing.yml")
+	if code != exitError {
+		t.Errorf("expected exit code %d for a missing rule file, got %d", exitError, code)
+	}
+}
+
+func TestConvert_Missing(t *testing.T) {
+	sparse := `{"name": "Sparse", "background": "#102030", "foreground": "#c0c0c0"}`
+
+	code, stdout, stderr := runCLI(t, sparse, "convert", "--from", "wt", "--to", "alacritty")
+	if code != exitError || stdout != "" || !strings.Contains(stderr, "missing colors (policy fallback): colors.normal.red") {
+		t.Errorf("expected the colors no fallback derives to be listed, got %d: %s", code, stderr)
+	}
+
+	code, stdout, stderr = runCLI(t, sparse, "convert", "--from", "wt", "--to", "alacritty", "--missing", "placeholder", "--placeholder", "#00ff00")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.Contains(stdout, "red     = '#00ff00'") || strings.Contains(stdout, "#ff0000") {
+		t.Errorf("expected the placeholder for missing colors, got:\n%s", stdout)
+	}
+
+	code, stdout, stderr = runCLI(t, sparse, "convert", "--from", "wt", "--to", "kitty", "--missing", "omit")
+	if code != exitOK || strings.Contains(stdout, "cursor ") || !strings.Contains(stdout, "background #102030") {
+		t.Errorf("expected missing colors left out, got %d (stderr: %s):\n%s", code, stderr, stdout)
+	}
+
+	code, _, stderr = runCLI(t, sparse, "convert", "--from", "wt", "--to", "kitty", "--missing", "fail")
+	if code != exitError || !strings.Contains(stderr, "missing colors (policy fail): ") {
+		t.Errorf("expected every missing color to fail, got %d: %s", code, stderr)
+	}
+
+	for _, args := range [][]string{{"--missing", "red"}, {"--placeholder", "#00ff00"}, {"--missing", "placeholder", "--placeholder", "green"}} {
+		code, _, _ = runCLI(t, sparse, append([]string{"convert", "--from", "wt", "--to", "kitty"}, args...)...)
+		if code != exitUsage {
+			t.Errorf("%v: expected exit code %d, got %d", args, exitUsage, code)
+		}
+	}
+}
+
+// A Terminator config holding several profiles
+const terminatorConfig = `[global_config]
+[profiles]
+  [[default]]
+    background_color = "#000000"
+    foreground_color = "#ffffff"
+  [[Solarized]]
+    background_color = "#002b36"
+    foreground_color = "#839496"
+[layouts]
+  [[default]]
+    [[[window0]]]
+      type = Window
+`
+
+func TestConvert_Scheme(t *testing.T) {
+	for _, ref := range []string{"Solarized", "1"} {
+		code, stdout, stderr := runCLI(t, terminatorConfig, "convert", "--from", "terminator", "--to", "alacritty", "--missing", "placeholder", "--scheme", ref)
+		if code != exitOK {
+			t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+		}
+		if !strings.Contains(stdout, "background = '#002b36'") {
+			t.Errorf("expected scheme %q to be converted, got:\n%s", ref, stdout)
+		}
+	}
+
+	code, stdout, _ := runCLI(t, terminatorConfig, "convert", "--from", "terminator", "--to", "alacritty", "--missing", "placeholder")
+	if code != exitOK || !strings.Contains(stdout, "background = '#000000'") {
+		t.Errorf("expected the default profile without --scheme, got %d:\n%s", code, stdout)
+	}
+
+	code, _, stderr := runCLI(t, terminatorConfig, "convert", "--from", "terminator", "--to", "alacritty", "--missing", "placeholder", "--scheme", "Missing")
+	if code != exitError || !strings.Contains(stderr, `available: 0 "default", 1 "Solarized"`) {
+		t.Errorf("expected an error listing the schemes, got %d: %s", code, stderr)
+	}
+
+	code, _, _ = runCLI(t, readTheme(t, "alacritty.toml"), "convert", "--from", "alacritty", "--to", "wt", "--scheme", "0")
+	if code != exitError {
+		t.Errorf("expected exit code %d for a format holding a single scheme, got %d", exitError, code)
+	}
+}
+
+func TestConvert_Profile(t *testing.T) {
+	code, stdout, stderr := runCLI(t, terminatorConfig, "convert", "--from", "terminator", "--to", "alacritty", "--missing", "placeholder", "--profile", "Solarized")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.Contains(stdout, "background = '#002b36'") {
+		t.Errorf("expected the selected profile to be converted, got:\n%s", stdout)
+	}
+
+	code, _, stderr = runCLI(t, terminatorConfig, "convert", "--from", "terminator", "--to", "alacritty", "--profile", "Solarized", "--all")
+	if code != exitUsage {
+		t.Errorf("expected exit code %d for --profile with --all, got %d (stderr: %s)", exitUsage, code, stderr)
+	}
+}
+
+func TestConvert_All(t *testing.T) {
+	code, stdout, stderr := runCLI(t, terminatorConfig, "convert", "--from", "terminator", "--to", "wt", "--missing", "placeholder", "--all", "--report", "text")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	var bundle struct {
+		Schemes []struct {
+			Background string `json:"background"`
+		} `json:"schemes"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &bundle); err != nil {
+		t.Fatalf("expected a JSON bundle, got %v:\n%s", err, stdout)
+	}
+	if len(bundle.Schemes) != 2 || bundle.Schemes[1].Background != "#002b36" {
+		t.Errorf("expected both profiles in the bundle, got:\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "Conversion report: terminator -> wt (Solarized)") {
+		t.Errorf("expected a report per scheme, got:\n%s", stderr)
+	}
+
+	code, _, _ = runCLI(t, terminatorConfig, "convert", "--from", "terminator", "--to", "alacritty", "--missing", "placeholder", "--all")
+	if code != exitError {
+		t.Errorf("expected exit code %d for a writer holding a single scheme, got %d", exitError, code)
+	}
+	code, _, _ = runCLI(t, terminatorConfig, "convert", "--from", "terminator", "--to", "wt", "--missing", "placeholder", "--all", "--scheme", "0")
+	if code != exitUsage {
+		t.Errorf("expected exit code %d for --all with --scheme, got %d", exitUsage, code)
+	}
+}
+
+func TestList(t *testing.T) {
+	code, stdout, stderr := runCLI(t, terminatorConfig, "list", "--from", "terminator")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if stdout != "0    default\n1    Solarized\n" {
+		t.Errorf("unexpected listing:\n%s", stdout)
+	}
+
+	code, stdout, _ = runCLI(t, terminatorConfig, "list", "--from", "terminator", "--json")
+	if code != exitOK || !strings.Contains(stdout, `"name": "Solarized"`) {
+		t.Errorf("expected a JSON listing, got %d:\n%s", code, stdout)
+	}
+
+	code, _, _ = runCLI(t, readTheme(t, "alacritty.toml"), "list", "--from", "alacritty")
+	if code != exitError {
+		t.Errorf("expected exit code %d for a format holding a single scheme, got %d", exitError, code)
+	}
+}
+
+func TestConvert_TemplateDir(t *testing.T) {
+	config := t.TempDir()
+	dir := t.TempDir()
+	for _, tc := range []struct{ dir, header string }{
+		{filepath.Join(config, "paletteport", "templates"), "# User style"},
+		{dir, "# House style"},
+	} {
+		house := "{{/* format: text */ -}}\n" + tc.header + "\ninclude base.conf\nbackground {{ .Background.Hex }}\n"
+		if err := os.MkdirAll(tc.dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(tc.dir, "kitty.conf.tmpl"), []byte(house), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	code, stdout, stderr := runCLIWithConfig(t, config, readTheme(t, "wt.json"), "convert", "--from", "wt", "--to", "kitty", "--template-dir", dir)
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "# House style\ninclude base.conf\nbackground #") {
+		t.Errorf("expected the template from --template-dir, got:\n%s", stdout)
+	}
+
+	// Without the flag, the user's template replaces the built-in one
+	code, stdout, stderr = runCLIWithConfig(t, config, readTheme(t, "wt.json"), "convert", "--from", "wt", "--to", "kitty")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "# User style\n") {
+		t.Errorf("expected the template from the user's directory, got:\n%s", stdout)
+	}
+}
+
+func TestConvert_Template(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "names.tmpl")
+	if err := os.WriteFile(path, []byte("{{/* format: text */ -}}\n{{ .AnsiColors.Red.Hex }}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI(t, readTheme(t, "alacritty.toml"), "convert", "--from", "alacritty", "--template", path)
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if len(stdout) != len("#000000\n") || !strings.HasPrefix(stdout, "#") {
+		t.Errorf("expected the template to be rendered against the abstract scheme, got %q", stdout)
+	}
+
+	for _, args := range [][]string{
+		{"convert", "--from", "alacritty", "--template", path, "--to", "kitty"},
+		{"convert", "--from", "wt", "--template", path, "--all"},
+	} {
+		if code, _, _ := runCLI(t, "", args...); code != exitUsage {
+			t.Errorf("expected exit code %d for %v, got %d", exitUsage, args, code)
+		}
+	}
+}
+
+func TestPreview(t *testing.T) {
+	t.Setenv("COLORTERM", "truecolor")
+	code, stdout, stderr := runCLI(t, readTheme(t, "kitty.conf"), "preview", "--from", "kitty")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.Contains(stdout, "\x1b[48;2;") || !strings.Contains(stdout, "user@host") {
+		t.Errorf("expected a truecolor preview, got:\n%s", stdout)
+	}
+
+	t.Setenv("COLORTERM", "")
+	code, stdout, _ = runCLI(t, readTheme(t, "kitty.conf"), "preview", "--from", "kitty")
+	if code != exitOK || strings.Contains(stdout, "\x1b[48;2;") || !strings.Contains(stdout, "\x1b[48;5;") {
+		t.Errorf("expected a 256-color preview without COLORTERM, got %d", code)
+	}
+
+	code, stdout, _ = runCLI(t, readTheme(t, "kitty.conf"), "preview", "--from", "kitty", "--color", "truecolor")
+	if code != exitOK || !strings.Contains(stdout, "\x1b[48;2;") {
+		t.Errorf("expected --color to override COLORTERM, got %d", code)
+	}
+	if code, _, _ := runCLI(t, "", "preview", "--color", "16"); code != exitUsage {
+		t.Errorf("expected exit code %d for an unknown color mode, got %d", exitUsage, code)
+	}
+}
+
+func TestVariant(t *testing.T) {
+	code, stdout, stderr := runCLI(t, readTheme(t, "wt.json"), "variant", "--light", "--from", "wt", "--to", "paletteport")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.Contains(stderr, "Derived light variant of a dark scheme") {
+		t.Errorf("expected a report on stderr, got:\n%s", stderr)
+	}
+	if !strings.Contains(stdout, " Light\"") {
+		t.Errorf("expected the name to end in Light, got:\n%s", stdout)
+	}
+
+	// Converting it back needs --dark
+	code, _, stderr = runCLI(t, stdout, "variant", "--light", "--from", "paletteport")
+	if code != exitError || !strings.Contains(stderr, "already light") {
+		t.Errorf("expected an error deriving a light variant of a light scheme, got %d: %s", code, stderr)
+	}
+	if code, _, _ := runCLI(t, stdout, "variant", "--dark", "--from", "paletteport"); code != exitOK {
+		t.Errorf("expected exit code %d for --dark, got %d", exitOK, code)
+	}
+	if code, _, _ := runCLI(t, "", "variant", "--light", "--dark"); code != exitUsage {
+		t.Errorf("expected exit code %d with both --light and --dark, got %d", exitUsage, code)
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	code, stdout, stderr := runCLI(t, readTheme(t, "wt.json"), "quantize", "--from", "wt", "--to", "alacritty", "--report", "json")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	var table struct {
+		Palette  string `json:"palette"`
+		Mappings []struct {
+			Field string `json:"field"`
+			Index int    `json:"index"`
+			To    string `json:"to"`
+		} `json:"mappings"`
+	}
+	if err := json.Unmarshal([]byte(stderr), &table); err != nil {
+		t.Fatalf("expected a JSON mapping table, got %v:\n%s", err, stderr)
+	}
+	if table.Palette != "256" || len(table.Mappings) == 0 {
+		t.Fatalf("unexpected table: %+v", table)
+	}
+	for _, m := range table.Mappings {
+		if m.Index < 16 || m.Index > 255 {
+			t.Errorf("%s: index %d is outside the color cube and grays", m.Field, m.Index)
+		}
+		if m.Field == "SpecialColors.Background" && !strings.Contains(stdout, "background = '"+m.To+"'") {
+			t.Errorf("expected the output to use the palette color %s, got:\n%s", m.To, stdout)
+		}
+	}
+
+	code, _, stderr = runCLI(t, readTheme(t, "wt.json"), "quantize", "--from", "wt", "--palette", "16")
+	if code != exitOK || !strings.Contains(stderr, "AnsiColors.Red") {
+		t.Errorf("expected a table for the 16-color palette, got %d: %s", code, stderr)
+	}
+	if code, _, _ := runCLI(t, "", "quantize", "--palette", "88"); code != exitUsage {
+		t.Errorf("expected exit code %d for an unknown palette, got %d", exitUsage, code)
+	}
+}
+
+func TestFromImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 10))
+	for x := 0; x < 40; x++ {
+		c := stdcolor.NRGBA{0x1a, 0x1b, 0x26, 0xff}
+		if x >= 30 {
+			c = stdcolor.NRGBA{0x7a, 0xa2, 0xf7, 0xff}
+		}
+		for y := 0; y < 10; y++ {
+			img.Set(x, y, c)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "night.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	for _, method := range []string{"kmeans", "median-cut"} {
+		code, stdout, stderr := runCLI(t, "", "from-image", "--to", "paletteport", "--method", method, path)
+		if code != exitOK {
+			t.Fatalf("%s: expected exit code %d, got %d (stderr: %s)", method, exitOK, code, stderr)
+		}
+		for _, want := range []string{`Name: "night"`, "Background:", "BrightCyan:"} {
+			if !strings.Contains(stdout, want) {
+				t.Errorf("%s: expected %q in output:\n%s", method, want, stdout)
+			}
+		}
+	}
+
+	if code, _, _ := runCLI(t, "not an image", "from-image", "--to", "alacritty"); code != exitError {
+		t.Errorf("expected exit code %d for input that isn't an image, got %d", exitError, code)
+	}
+	if code, _, _ := runCLI(t, "", "from-image", path); code != exitUsage {
+		t.Errorf("expected exit code %d without --to, got %d", exitUsage, code)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	code, stdout, stderr := runCLI(t, "", "schema")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &schema); err != nil {
+		t.Fatalf("expected a JSON schema, got %v:\n%s", err, stdout)
+	}
+	for _, key := range []string{"version", "Metadata", "ScopeColors", "AnsiColors", "SpecialColors"} {
+		if _, ok := schema.Properties[key]; !ok {
+			t.Errorf("expected the schema to describe %s", key)
+		}
+	}
+}
+
+func TestConvert_MaxLoss(t *testing.T) {
+	code, stdout, _ := runCLI(t, readTheme(t, "wt.json"), "convert", "--from", "wt", "--to", "alacritty", "--max-loss", "0")
+	if code != exitError {
+		t.Errorf("expected exit code %d, got %d", exitError, code)
+	}
+	if stdout != "" {
+		t.Errorf("expected no output for a failed conversion, got %q", stdout)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	code, stdout, stderr := runCLI(t, readTheme(t, "iterm.itermcolors"), "detect")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "iterm") {
+		t.Errorf("expected iterm as first candidate, got:\n%s", stdout)
+	}
+}
+
+func TestContrast(t *testing.T) {
+	in := filepath.Join("..", "..", "themes", "wt.json")
+	code, stdout, stderr := runCLI(t, "", "contrast", in, "--json")
+	if code != exitError {
+		t.Errorf("expected exit code %d for a scheme with failing pairs, got %d", exitError, code)
+	}
+	if !strings.Contains(stderr, "below wcag AA") {
+		t.Errorf("expected a summary of failing pairs on stderr, got %q", stderr)
+	}
+
+	var report contrast.Report
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("expected JSON report on stdout: %v\n%s", err, stdout)
+	}
+	if len(report.Results) == 0 {
+		t.Errorf("expected checked pairs, got none")
+	}
+
+	code, _, _ = runCLI(t, "", "contrast", in, "--level", "AAAA")
+	if code != exitUsage {
+		t.Errorf("expected exit code %d for an unknown level, got %d", exitUsage, code)
+	}
+}
+
+func TestFixContrast(t *testing.T) {
+	code, stdout, stderr := runCLI(t, readTheme(t, "wt.json"), "fix-contrast", "--from", "wt", "--skip", "AnsiColors.Black")
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.Contains(stderr, "AnsiColors.BrightBlack: #323232 -> ") {
+		t.Errorf("expected the adjusted field in the report, got:\n%s", stderr)
+	}
+
+	// Only the skipped pair is left failing the audit
+	code, _, stderr = runCLI(t, stdout, "contrast", "--from", "wt")
+	if !strings.Contains(stderr, "1 of 17 pairs") {
+		t.Errorf("expected only the skipped pair to fail, got exit code %d (stderr: %s)", code, stderr)
+	}
+}
+
+func TestConvert_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		stdin string
+		args  []string
+		want  int
+	}{
+		{"missing --to", "", []string{"convert", "--from", "wt"}, exitUsage},
+		{"unknown adapter", "", []string{"convert", "--from", "wt", "--to", "nope"}, exitUsage},
+		{"too many inputs", "", []string{"convert", "--from", "wt", "--to", "iterm", "a", "b"}, exitUsage},
+		{"missing file", "", []string{"convert", "--from", "wt", "--to", "iterm", "does-not-exist.json"}, exitError},
+		{"parse error", "{not json", []string{"convert", "--from", "wt", "--to", "iterm"}, exitError},
+		{"bad color", `{"black": "#zzzzzz"}`, []string{"convert", "--from", "wt", "--to", "iterm"}, exitError},
+		{"undetectable", "name: x\n", []string{"convert", "--to", "iterm"}, exitError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			code, stdout, _ := runCLI(t, tc.stdin, tc.args...)
+			if code != tc.want {
+				t.Errorf("expected exit code %d, got %d", tc.want, code)
+			}
+			if stdout != "" {
+				t.Errorf("expected nothing on stdout, got %q", stdout)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/preview"
+)
+
+func runPreview(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("preview", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: paletteport preview [--from <format>] [--color auto|truecolor|256] [input]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Renders the scheme in the terminal: its 16 ANSI colors, a shell prompt,")
+		fmt.Fprintln(stderr, "ls output, a diff and a highlighted code block, all on its background.")
+		fmt.Fprintln(stderr, "Without truecolor support, advertised by COLORTERM, the nearest colors")
+		fmt.Fprintln(stderr, "of the 256-color palette are used.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+
+	var from, modeName, fallbacksPath string
+	fs.StringVar(&from, "from", "", "input format (adapter name, detected if omitted)")
+	fs.StringVar(&modeName, "color", "auto", "color support of the terminal: auto, truecolor or 256")
+	fs.StringVar(&fallbacksPath, "fallbacks", "", "YAML file of fallback rules for missing colors, on top of the defaults")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 1 {
+		fmt.Fprintf(stderr, "paletteport preview: expected at most one input, got %d\n", len(positional))
+		return exitUsage
+	}
+	mode := preview.DetectMode(os.Getenv)
+	if modeName != "auto" {
+		if mode, err = preview.ParseMode(modeName); err != nil {
+			fmt.Fprintf(stderr, "paletteport preview: %v\n", err)
+			return exitUsage
+		}
+	}
+
+	inputPath := ""
+	if len(positional) == 1 {
+		inputPath = positional[0]
+	}
+
+	input, err := readInput(inputPath, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport preview: %v\n", err)
+		return exitError
+	}
+
+	reader, code := resolveReader(from, inputPath, input, stderr, "preview")
+	if reader == nil {
+		return code
+	}
+
+	fallbacks, err := loadFallbacks(fallbacksPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport preview: %v\n", err)
+		return exitError
+	}
+	if fallbacks == nil {
+		fallbacks = adapter.DefaultFallbackRules()
+	}
+
+	scheme, _, err := adapter.ParseAbstract(input, reader)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport preview: %v\n", err)
+		return exitError
+	}
+	fallbacks.Apply(scheme)
+
+	if err := preview.Render(stdout, scheme, mode); err != nil {
+		fmt.Fprintf(stderr, "paletteport preview: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/quantize"
+	"github.com/da-luce/paletteport/templates"
+)
+
+func runQuantize(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("quantize", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: paletteport quantize [--from <format>] [--to <format>] [--palette 256|16] [--metric <name>] [-o output] [input]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Replaces every color of the scheme with the nearest color of the xterm")
+		fmt.Fprintln(stderr, "256-color or 16-color palette, for terminals without truecolor, and prints")
+		fmt.Fprintln(stderr, "the palette index each field was mapped to to stderr.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+
+	var from, to, output, paletteName, metricName, reportFormat, fallbacksPath string
+	fs.StringVar(&from, "from", "", "input format (adapter name, detected if omitted)")
+	fs.StringVar(&to, "to", "", "output format (adapter name, defaults to the input format)")
+	fs.StringVar(&output, "o", "", "output file (default stdout)")
+	fs.StringVar(&output, "output", "", "output file (default stdout)")
+	fs.StringVar(&paletteName, "palette", "256", "palette to map colors onto: 256 or 16")
+	fs.StringVar(&metricName, "metric", "ciede2000", "color distance: ciede2000, cie94, cie76, oklab or rgb")
+	fs.StringVar(&reportFormat, "report", "text", "format of the mapping table printed to stderr: text or json")
+	fs.StringVar(&fallbacksPath, "fallbacks", "", "YAML file of fallback rules for missing colors, on top of the defaults")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 1 {
+		fmt.Fprintf(stderr, "paletteport quantize: expected at most one input, got %d\n", len(positional))
+		return exitUsage
+	}
+	if reportFormat != "text" && reportFormat != "json" {
+		fmt.Fprintf(stderr, "paletteport quantize: unknown report format %q, expected text or json\n", reportFormat)
+		return exitUsage
+	}
+	palette, err := quantize.ParsePalette(paletteName)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport quantize: %v\n", err)
+		return exitUsage
+	}
+	metric, err := color.ParseDistanceMetric(metricName)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport quantize: %v\n", err)
+		return exitUsage
+	}
+
+	inputPath := ""
+	if len(positional) == 1 {
+		inputPath = positional[0]
+	}
+
+	input, err := readInput(inputPath, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport quantize: %v\n", err)
+		return exitError
+	}
+
+	reader, code := resolveReader(from, inputPath, input, stderr, "quantize")
+	if reader == nil {
+		return code
+	}
+	if to == "" {
+		to = reader.Name()
+	}
+	writer, err := adapter.GetAdapter(to)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport quantize: %v\n", err)
+		return exitUsage
+	}
+
+	fallbacks, err := loadFallbacks(fallbacksPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport quantize: %v\n", err)
+		return exitError
+	}
+
+	var table *quantize.Table
+	result, _, err := adapter.ConvertThemeWith(input, reader, writer, adapter.ConvertOptions{
+		Fallbacks: fallbacks,
+		Templates: templates.DefaultSearchPath(),
+		Transform: func(s *adapter.AbstractScheme) error {
+			var quantized *adapter.AbstractScheme
+			quantized, table = quantize.Scheme(s, palette, metric)
+			*s = *quantized
+			return nil
+		},
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport quantize: %v\n", err)
+		return exitError
+	}
+
+	report := table.String()
+	if reportFormat == "json" {
+		if report, err = table.JSON(); err != nil {
+			fmt.Fprintf(stderr, "paletteport quantize: %v\n", err)
+			return exitError
+		}
+	}
+	io.WriteString(stderr, report)
+
+	if err := writeOutput(output, stdout, result); err != nil {
+		fmt.Fprintf(stderr, "paletteport quantize: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/da-luce/paletteport/internal/adapter/native"
+)
+
+func runSchema(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("schema", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: paletteport schema [-o output]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Prints the JSON Schema of paletteport's own format, which validates")
+		fmt.Fprintln(stderr, "paletteport, paletteport-json and paletteport-toml documents alike.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+
+	var output string
+	fs.StringVar(&output, "o", "", "output file (default stdout)")
+	fs.StringVar(&output, "output", "", "output file (default stdout)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 0 {
+		fmt.Fprintln(stderr, "paletteport schema: expected no arguments")
+		return exitUsage
+	}
+
+	schema, err := native.JSONSchemaString()
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport schema: %v\n", err)
+		return exitError
+	}
+	if err := writeOutput(output, stdout, schema); err != nil {
+		fmt.Fprintf(stderr, "paletteport schema: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
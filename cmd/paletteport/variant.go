@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/variant"
+	"github.com/da-luce/paletteport/templates"
+)
+
+func runVariant(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("variant", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: paletteport variant --light|--dark [--from <format>] [--to <format>] [-o output] [input]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Derives the light variant of a dark scheme, or the dark variant of a light")
+		fmt.Fprintln(stderr, "one, by mirroring the lightness of its colors, and prints the colors")
+		fmt.Fprintln(stderr, "adjusted to keep their contrast to stderr.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+
+	var from, to, output, reportFormat, fallbacksPath string
+	var light, dark bool
+	fs.BoolVar(&light, "light", false, "derive the light variant of a dark scheme")
+	fs.BoolVar(&dark, "dark", false, "derive the dark variant of a light scheme")
+	fs.StringVar(&from, "from", "", "input format (adapter name, detected if omitted)")
+	fs.StringVar(&to, "to", "", "output format (adapter name, defaults to the input format)")
+	fs.StringVar(&output, "o", "", "output file (default stdout)")
+	fs.StringVar(&output, "output", "", "output file (default stdout)")
+	fs.StringVar(&reportFormat, "report", "text", "format of the report printed to stderr: text or json")
+	fs.StringVar(&fallbacksPath, "fallbacks", "", "YAML file of fallback rules for missing colors, on top of the defaults")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 1 {
+		fmt.Fprintf(stderr, "paletteport variant: expected at most one input, got %d\n", len(positional))
+		return exitUsage
+	}
+	if light == dark {
+		fmt.Fprintln(stderr, "paletteport variant: exactly one of --light and --dark is required")
+		return exitUsage
+	}
+	if reportFormat != "text" && reportFormat != "json" {
+		fmt.Fprintf(stderr, "paletteport variant: unknown report format %q, expected text or json\n", reportFormat)
+		return exitUsage
+	}
+	polarity := variant.Dark
+	if light {
+		polarity = variant.Light
+	}
+
+	inputPath := ""
+	if len(positional) == 1 {
+		inputPath = positional[0]
+	}
+
+	input, err := readInput(inputPath, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport variant: %v\n", err)
+		return exitError
+	}
+
+	reader, code := resolveReader(from, inputPath, input, stderr, "variant")
+	if reader == nil {
+		return code
+	}
+	if to == "" {
+		to = reader.Name()
+	}
+	writer, err := adapter.GetAdapter(to)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport variant: %v\n", err)
+		return exitUsage
+	}
+
+	fallbacks, err := loadFallbacks(fallbacksPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport variant: %v\n", err)
+		return exitError
+	}
+
+	var variantReport *variant.Report
+	result, _, err := adapter.ConvertThemeWith(input, reader, writer, adapter.ConvertOptions{
+		Fallbacks: fallbacks,
+		Templates: templates.DefaultSearchPath(),
+		Transform: func(s *adapter.AbstractScheme) error {
+			derived, report, err := variant.Derive(s, polarity)
+			if err != nil {
+				return err
+			}
+			*s, variantReport = *derived, report
+			return nil
+		},
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "paletteport variant: %v\n", err)
+		return exitError
+	}
+
+	report := variantReport.String()
+	if reportFormat == "json" {
+		if report, err = variantReport.JSON(); err != nil {
+			fmt.Fprintf(stderr, "paletteport variant: %v\n", err)
+			return exitError
+		}
+	}
+	io.WriteString(stderr, report)
+
+	if err := writeOutput(output, stdout, result); err != nil {
+		fmt.Fprintf(stderr, "paletteport variant: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
@@ -1,19 +1,21 @@
 package adapter
 
 import (
-	"bytes"
 	"fmt"
-	"html/template"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/da-luce/paletteport/internal/adapter/alacritty"
 	"github.com/da-luce/paletteport/internal/adapter/base16"
 	"github.com/da-luce/paletteport/internal/adapter/gogh"
 	"github.com/da-luce/paletteport/internal/adapter/iterm"
+	"github.com/da-luce/paletteport/internal/adapter/kitty"
+	"github.com/da-luce/paletteport/internal/adapter/native"
+	"github.com/da-luce/paletteport/internal/adapter/terminator"
+	"github.com/da-luce/paletteport/internal/adapter/vscode"
 	"github.com/da-luce/paletteport/internal/adapter/windows_terminal"
 	"github.com/da-luce/paletteport/internal/color"
-	log "github.com/da-luce/paletteport/internal/logger"
 	"github.com/da-luce/paletteport/internal/objectmap"
 	"github.com/da-luce/paletteport/internal/structutil"
 	"github.com/da-luce/paletteport/templates"
@@ -31,6 +33,14 @@ type Adapter interface {
 	TemplateName() string          // Return path to the output generation template file
 }
 
+// LosslessAdapter is implemented by adapters holding every abstract field as
+// is, like paletteport's own formats. Writers of these save the scheme as it
+// was read, without colors filled in by the fallback rules.
+type LosslessAdapter interface {
+	Adapter
+	Lossless()
+}
+
 // List of registered adapters
 var Adapters = []Adapter{
 	&base16.Base16Scheme{},
@@ -38,6 +48,43 @@ var Adapters = []Adapter{
 	&gogh.GoghScheme{},
 	&iterm.ItermScheme{},
 	&windows_terminal.WindowsTerminalScheme{},
+	&vscode.VSCodeTheme{},
+	&kitty.KittyScheme{},
+	&terminator.TerminatorScheme{},
+	&native.NativeScheme{},
+	&native.NativeJSONScheme{},
+	&native.NativeTOMLScheme{},
+}
+
+// AdapterNames returns the shorthand names of all registered adapters
+func AdapterNames() []string {
+	names := make([]string, 0, len(Adapters))
+	for _, ad := range Adapters {
+		names = append(names, ad.Name())
+	}
+	return names
+}
+
+// GetAdapter returns a fresh instance of the registered adapter with the given
+// shorthand name. The registry entries themselves are never handed out, so
+// callers are free to fill the returned adapter.
+func GetAdapter(name string) (Adapter, error) {
+	for _, ad := range Adapters {
+		if ad.Name() == name {
+			return newAdapterInstance(ad), nil
+		}
+	}
+	return nil, fmt.Errorf(
+		"unknown adapter %q (available: %s)",
+		name,
+		strings.Join(AdapterNames(), ", "),
+	)
+}
+
+// Helper to allocate new adapter instance
+func newAdapterInstance(ad Adapter) Adapter {
+	typ := reflect.TypeOf(ad).Elem()
+	return reflect.New(typ).Interface().(Adapter)
 }
 
 type Color = color.Color
@@ -149,112 +196,273 @@ type AbstractScheme struct {
 	SpecialColors SpecialColors
 }
 
-func onMissingField(fieldPath []string, srcVal reflect.Value) {
-	pathStr := strings.Join(fieldPath, ".")
-	log.Logger.Warn().
-		Msgf(
-			"Source field %q with value %v was unused during mapping",
-			pathStr,
-			srcVal.Interface(),
-		)
+// Struct tag adapters use to name the abstract field each of their fields maps
+// to. The path can be piped through color transforms, e.g.
+// `abstract:"SpecialColors.Selection|alpha(0.3)"` for a format whose selection
+// is drawn translucent over the background: writing applies the transform and
+// reading undoes it. Alpha can't be recovered, so reading a field tagged alpha
+// makes it opaque.
+const abstractTag = "abstract"
+
+// ToAbstract maps a filled reader onto a new abstract scheme, recording any
+// reader fields that have no abstract counterpart in the report, if there is
+// one. Readers are mapped by the code generated from their tags, see
+// GenerateMappings, and by reflection if there is none.
+func ToAbstract(reader Adapter, report *ConversionReport) (*AbstractScheme, error) {
+	var abstractTheme AbstractScheme
+	generated, err := toAbstractGenerated(reader, &abstractTheme, report)
+	if err == nil && !generated {
+		var onDropped func([]string, reflect.Value)
+		if report != nil {
+			onDropped = recordSet(&report.Dropped)
+		}
+		err = objectmap.MapInto(reader, &abstractTheme, onDropped, nil, abstractTag)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert reader to abstract: %w", err)
+	}
+	return &abstractTheme, nil
 }
 
-func onUnusedIntoAbstract(fieldPath []string, srcVal reflect.Value) {
-	pathStr := strings.Join(fieldPath, ".")
-	log.Logger.Debug().
-		Msgf("Abstract field %q with value %v was unused during mapping",
-			pathStr,
-			srcVal.Interface(),
-		)
+// FromAbstract fills the writer from the abstract scheme, recording abstract
+// fields the writer has no place for, writer fields filled from a fallback or
+// from an abstract field other than the first their tag lists, and writer
+// fields left empty in the report, if there is one. Writers are filled by the
+// code generated from their tags, see GenerateMappings, and by reflection if
+// there is none.
+func FromAbstract(abstractTheme *AbstractScheme, writer Adapter, report *ConversionReport) error {
+	generated, err := fromAbstractGenerated(abstractTheme, writer, report)
+	if err != nil {
+		return fmt.Errorf("failed to convert abstract to writer: %w", err)
+	}
+	if generated {
+		return nil
+	}
+
+	// The abstract field each writer field was filled from
+	sources := make(map[string]string)
+	var onUnused func([]string, reflect.Value)
+	if report != nil {
+		onUnused = recordSet(&report.Unused)
+	}
+	onMapped := func(dstPath, srcPath []string) {
+		sources[strings.Join(dstPath, ".")] = strings.Join(srcPath, ".")
+	}
+	if err := objectmap.MapFromWithSources(abstractTheme, writer, onUnused, nil, onMapped, abstractTag); err != nil {
+		return fmt.Errorf("failed to convert abstract to writer: %w", err)
+	}
+	if report == nil {
+		return nil
+	}
+
+	structutil.TraverseStructDFS(writer, func(path []string, field reflect.StructField, value reflect.Value) bool {
+		if !isLeafField(value) {
+			return true
+		}
+		pathStr := strings.Join(path, ".")
+		if isZeroValue(value) {
+			report.Empty = append(report.Empty, FieldReport{Path: pathStr})
+			return false
+		}
+
+		abstractPath, ok := sources[pathStr]
+		if !ok {
+			return false
+		}
+		hex, _ := formatFieldValue(value)
+		if source, filled := report.fallbacks[abstractPath]; filled {
+			report.Filled = append(report.Filled, FieldReport{Path: pathStr, Value: hex, Source: source})
+		} else if tag := field.Tag.Get(abstractTag); tag != "" && objectmap.TagPaths(tag)[0] != abstractPath {
+			report.Filled = append(report.Filled, FieldReport{Path: pathStr, Value: hex, Source: abstractPath})
+		}
+		return false
+	})
+
+	return nil
 }
 
-func onUnusedFromAbstract(fieldPath []string, srcVal reflect.Value) {
-	pathStr := strings.Join(fieldPath, ".")
-	log.Logger.Debug().
-		Msgf("Abstract field %q with value %v was unused during mapping",
-			pathStr,
-			srcVal.Interface(),
-		)
+// adaptScheme converts the reader into the writer by way of the abstract scheme
+func adaptScheme(reader Adapter, writer Adapter) (*ConversionReport, error) {
+	return adaptSchemeWith(reader, writer, ConvertOptions{})
 }
 
-func onMissingDest(fieldPath []string, srcVal reflect.Value) {
-	pathStr := strings.Join(fieldPath, ".")
-	log.Logger.Warn().
-		Msgf(
-			"Destination field %q with value %v was unused during mapping",
-			pathStr,
-			srcVal.Interface(),
-		)
+// adaptSchemeWith is adaptScheme with the given options
+func adaptSchemeWith(reader Adapter, writer Adapter, opts ConvertOptions) (*ConversionReport, error) {
+	report := newConversionReport(reader.Name(), writer.Name())
 
+	abstractTheme, err := ToAbstract(reader, report)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := completeAbstract(abstractTheme, writer, opts, report); err != nil {
+		return nil, err
+	}
+
+	if err := FromAbstract(abstractTheme, writer, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
 }
 
-func adaptScheme(reader Adapter, writer Adapter) error {
-	// Convert reader adapter to abstract scheme
-	var abstractTheme AbstractScheme
-	if err := objectmap.MapInto(
-		reader,
-		&abstractTheme,
-		onMissingField,
-		onUnusedIntoAbstract,
-		"abstract",
-	); err != nil {
-		return fmt.Errorf("failed to convert reader to abstract: %w", err)
-	}
-
-	// Fill in missing fields
-	fillUnsetInGroups(&abstractTheme)
-
-	if err := objectmap.MapFrom(
-		&abstractTheme,
-		writer,
-		onUnusedFromAbstract,
-		onMissingDest,
-		"abstract",
-	); err != nil {
-		return fmt.Errorf("failed to convert abstract to writer: %w", err)
+// completeAbstract fills in missing fields by the fallback rules, unless the
+// missing color policy says otherwise or the writer is lossless, then applies
+// the transform of the options. The writer is nil for templates.
+func completeAbstract(abstractTheme *AbstractScheme, writer Adapter, opts ConvertOptions, report *ConversionReport) error {
+	_, lossless := writer.(LosslessAdapter)
+	if opts.Missing.Policy == templates.MissingFallback && !lossless {
+		fallbacks := opts.Fallbacks
+		if fallbacks == nil {
+			fallbacks = DefaultFallbackRules()
+		}
+		fillFallbacks(abstractTheme, fallbacks, report)
 	}
 
+	if opts.Transform != nil {
+		return opts.Transform(abstractTheme)
+	}
 	return nil
 }
 
-// Renders an Adapter to a string using its TemplatePath.
+// Renders an Adapter to a string using its embedded template
 func RenderAdapterToString(a Adapter) (string, error) {
-	templateFile := a.TemplateName()
-	tmplData, err := templates.FS.ReadFile(templateFile)
+	return RenderAdapterWith(a, ConvertOptions{})
+}
+
+// RenderAdapterWith renders an Adapter with its template looked up in the
+// search path of the options, and missing colors resolved by their policy
+func RenderAdapterWith(a Adapter, opts ConvertOptions) (string, error) {
+	return renderTemplate(opts, a.TemplateName(), a)
+}
+
+// RenderAbstract renders the abstract scheme with the template file at the
+// given path, missing colors resolved by the policy of the options
+func RenderAbstract(scheme *AbstractScheme, templateFile string, opts ConvertOptions) (string, error) {
+	tmpl, err := templates.ParseFile(templateFile)
 	if err != nil {
 		return "", err
 	}
+	return templates.Execute(tmpl, scheme, opts.Missing)
+}
 
-	tmpl := template.New("out")
-
-	// Add important functions
-	tmpl.Funcs(template.FuncMap{
-		"indent": templates.Indent,
-	})
-
-	tmpl, err = tmpl.Parse(string(tmplData))
+// renderTemplate executes the named template, looked up in the search path of
+// the options, with the given data
+func renderTemplate(opts ConvertOptions, templateFile string, data any) (string, error) {
+	tmpl, err := opts.Templates.Lookup(templateFile)
 	if err != nil {
 		return "", err
 	}
+	return templates.Execute(tmpl, data, opts.Missing)
+}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, a); err != nil {
-		return "", err
+// ParseAbstract parses the input with the reader and maps it onto a new
+// abstract scheme, without filling in fallbacks. The report lists the reader
+// fields that were dropped on the way.
+func ParseAbstract(input string, reader Adapter) (*AbstractScheme, *ConversionReport, error) {
+	if err := reader.FromString(input); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse input file: %w", err)
+	}
+
+	report := newConversionReport(reader.Name(), "")
+	abstractTheme, err := ToAbstract(reader, report)
+	if err != nil {
+		return nil, nil, err
 	}
+	return abstractTheme, report, nil
+}
+
+// ConvertTheme parses the input with the reader and renders it with the writer,
+// returning the rendered text along with a report of what was lost on the way.
+func ConvertTheme[S Adapter, W Adapter](input string, reader S, writer W) (string, *ConversionReport, error) {
+	return ConvertThemeWith(input, reader, writer, ConvertOptions{})
+}
 
-	return buf.String(), nil
+// ConvertOptions adjust how a theme is converted
+type ConvertOptions struct {
+	// Rules to fill missing colors with, DefaultFallbackRules if nil
+	Fallbacks *FallbackRules
+	// Applied to the abstract scheme between reading and writing, once
+	// fallbacks have been filled in, so it sees every color the writer will
+	Transform func(*AbstractScheme) error
+	// Directories searched for templates before the embedded ones, none if
+	// nil. templates.DefaultSearchPath adds the user's template directory.
+	Templates templates.SearchPath
+	// What to do with colors the writer has a place for but the scheme has
+	// no value for. Fallbacks are only filled in under MissingFallback.
+	Missing templates.Missing
 }
 
-func ConvertTheme[S Adapter, W Adapter](input string, reader S, writer W) (string, error) {
+// ConvertThemeWith is ConvertTheme with the given options
+func ConvertThemeWith(input string, reader Adapter, writer Adapter, opts ConvertOptions) (string, *ConversionReport, error) {
 	if err := reader.FromString(input); err != nil {
-		return "", fmt.Errorf("failed to parse input file: %w", err)
+		return "", nil, fmt.Errorf("failed to parse input file: %w", err)
 	}
 
-	if err := adaptScheme(reader, writer); err != nil {
-		return "", err
+	report, err := adaptSchemeWith(reader, writer, opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	output, err := RenderAdapterWith(writer, opts)
+	if err != nil {
+		return "", nil, err
+	}
+	return output, report, nil
+}
+
+// RenderSchemeWith renders an abstract scheme that wasn't read from any input,
+// such as one generated from an image, with the writer. Fallbacks are filled
+// in and the transform applied as for a conversion.
+func RenderSchemeWith(abstractTheme *AbstractScheme, writer Adapter, opts ConvertOptions) (string, *ConversionReport, error) {
+	report := newConversionReport("", writer.Name())
+	if err := completeAbstract(abstractTheme, writer, opts, report); err != nil {
+		return "", nil, err
+	}
+	if err := FromAbstract(abstractTheme, writer, report); err != nil {
+		return "", nil, err
+	}
+
+	output, err := RenderAdapterWith(writer, opts)
+	if err != nil {
+		return "", nil, err
+	}
+	return output, report, nil
+}
+
+// ConvertToTemplateWith parses the input with the reader and renders the
+// abstract scheme, fallbacks filled in, with the template file at the given
+// path. The report lists the abstract fields filled by a fallback.
+func ConvertToTemplateWith(input string, reader Adapter, templateFile string, opts ConvertOptions) (string, *ConversionReport, error) {
+	abstractTheme, report, err := ParseAbstract(input, reader)
+	if err != nil {
+		return "", nil, err
 	}
+	report.Writer = templateFile
 
-	return RenderAdapterToString(writer)
+	if err := completeAbstract(abstractTheme, nil, opts, report); err != nil {
+		return "", nil, err
+	}
+
+	// The template sees the abstract fields themselves
+	paths := make([]string, 0, len(report.fallbacks))
+	for path := range report.fallbacks {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		report.Filled = append(report.Filled, FieldReport{
+			Path:   path,
+			Value:  schemeColor(abstractTheme, path).Hex(),
+			Source: report.fallbacks[path],
+		})
+	}
+
+	output, err := RenderAbstract(abstractTheme, templateFile, opts)
+	if err != nil {
+		return "", nil, err
+	}
+	return output, report, nil
 }
 
 func isColor(t reflect.Type) bool {
@@ -284,9 +492,15 @@ func isZeroValue(v reflect.Value) bool {
 }
 
 // FieldSimilarity compares two structs and returns the ratio of matching pointer field values,
-// counting all fields where at least one side is set (non-nil).
-// FIXME: the logic here sucks!!!
+// counting all fields where at least one side is set (non-nil). Colors match
+// when they are visually identical under CIEDE2000.
 func FieldSimilarity(a, b any) float64 {
+	return FieldSimilarityWith(a, b, color.CIEDE2000)
+}
+
+// FieldSimilarityWith is FieldSimilarity with colors compared under the given metric
+// FIXME: the logic here sucks!!!
+func FieldSimilarityWith(a, b any, metric color.DistanceMetric) float64 {
 	va := reflect.ValueOf(a)
 	vb := reflect.ValueOf(b)
 
@@ -349,7 +563,7 @@ func FieldSimilarity(a, b any) float64 {
 			}
 
 			total++
-			if color.ColorsSimilar(c1, c2, 0.01) {
+			if color.VisuallyIdentical(c1, c2, metric) {
 				matching++
 			}
 			return false
@@ -377,48 +591,3 @@ func FieldSimilarity(a, b any) float64 {
 	}
 	return float64(matching) / float64(total)
 }
-
-// First pointer represents optionality of color, then we get a reference to the color
-type ColorGroup []**color.Color
-
-// TODO: expand these greatly
-func fallbackGroups(s *AbstractScheme) []ColorGroup {
-	return []ColorGroup{
-		{
-			&s.AnsiColors.Black,
-			&s.SpecialColors.Background,
-		},
-		{
-			&s.AnsiColors.Blue,
-			&s.SpecialColors.Links,
-		},
-	}
-}
-
-func processGroups(s *AbstractScheme, groups []ColorGroup, groupFn func(ColorGroup)) {
-	for _, group := range groups {
-		groupFn(group)
-	}
-}
-
-func fallbackGroup(group ColorGroup) {
-	var fallback *color.Color
-	for _, field := range group {
-		if field != nil && *field != nil {
-			fallback = *field
-			break
-		}
-	}
-	if fallback == nil {
-		return // No fallback found
-	}
-	for _, field := range group {
-		if field != nil && *field == nil {
-			*field = fallback
-		}
-	}
-}
-
-func fillUnsetInGroups(s *AbstractScheme) {
-	processGroups(s, fallbackGroups(s), fallbackGroup)
-}
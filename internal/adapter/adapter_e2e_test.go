@@ -2,8 +2,10 @@ package adapter
 
 import (
 	"fmt"
-	"reflect"
 	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter/base16"
+	"github.com/da-luce/paletteport/internal/adapter/iterm"
 )
 
 // End-to-end adapter transformation test
@@ -17,7 +19,7 @@ func TestAdapterRoundTripCompatibility(t *testing.T) {
 				t.Run(fmt.Sprintf("To_%s", dstAdapter.Name()), func(t *testing.T) {
 
 					// Adapt from src → dst
-					err := adaptScheme(srcAdapter, dstAdapter)
+					_, err := adaptScheme(srcAdapter, dstAdapter)
 					if err != nil {
 						t.Fatalf("adaptScheme failed: %v", err)
 					}
@@ -53,8 +55,30 @@ func TestAdapterRoundTripCompatibility(t *testing.T) {
 	}
 }
 
-// Helper to allocate new adapter instance
-func newAdapterInstance(ad Adapter) Adapter {
-	typ := reflect.TypeOf(ad).Elem()
-	return reflect.New(typ).Interface().(Adapter)
+// iTerm stores colors as fractions, which other adapters write as hex with
+// every component rounded to the nearest 8-bit value: 0.5 is 0x80, where it
+// used to be cut down to 0x7F
+func TestAdaptScheme_RoundsComponents(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>Background Color</key>
+	<dict>
+		<key>Red Component</key><real>0.5</real>
+		<key>Green Component</key><real>0.2</real>
+		<key>Blue Component</key><real>0.9</real>
+	</dict>
+</dict>
+</plist>`
+	reader := &iterm.ItermScheme{}
+	if err := reader.FromString(input); err != nil {
+		t.Fatalf("FromString failed: %v", err)
+	}
+	writer := &base16.Base16Scheme{}
+	if _, err := adaptScheme(reader, writer); err != nil {
+		t.Fatalf("adaptScheme failed: %v", err)
+	}
+	if got := writer.Base00.Hex(); got != "#8033e6" {
+		t.Errorf("expected the background rounded to #8033e6, got %s", got)
+	}
 }
@@ -2,12 +2,21 @@ package adapter
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"reflect"
+	"slices"
 
+	"github.com/da-luce/paletteport/internal/adapter/alacritty"
+	"github.com/da-luce/paletteport/internal/adapter/base16"
+	"github.com/da-luce/paletteport/internal/adapter/windows_terminal"
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/objectmap"
 	"github.com/da-luce/paletteport/internal/structutil"
+	"github.com/da-luce/paletteport/templates"
 )
 
 func TestAllAdapters(t *testing.T) {
@@ -25,10 +34,50 @@ func TestAllAdapters(t *testing.T) {
 			t.Run("TransitivePropertyPart2", func(t *testing.T) {
 				testTransitivePropertyPart2(t, ad)
 			})
+			t.Run("SpecialCharacters", func(t *testing.T) {
+				testSpecialCharacters(t, ad)
+			})
 		})
 	}
 }
 
+// Names with quotes, ampersands and unicode must come back exactly as written,
+// whatever the escaping rules of the format
+func testSpecialCharacters(t *testing.T, ad Adapter) {
+	const name = `O'Brien & Co "Ünïcødé" <dark> 🎨 \ back`
+
+	scheme := newAdapterInstance(ad)
+	fillDummyScheme(scheme)
+	var paths []string
+	structutil.TraverseStructDFS(scheme, func(path []string, _ reflect.StructField, value reflect.Value) bool {
+		if s, ok := value.Interface().(*string); ok && s != nil {
+			*s = name
+			paths = append(paths, strings.Join(path, "."))
+		}
+		return true
+	})
+	if len(paths) == 0 {
+		t.Skip("adapter has no string fields")
+	}
+
+	rendered, err := RenderAdapterToString(scheme)
+	if err != nil {
+		t.Fatalf("RenderAdapterToString failed: %v", err)
+	}
+	parsed := newAdapterInstance(ad)
+	if err := parsed.FromString(rendered); err != nil {
+		t.Fatalf("FromString failed: %v\n%s", err, rendered)
+	}
+
+	for _, path := range paths {
+		_, _, value := structutil.HasNestedFieldSlice(reflect.ValueOf(parsed), strings.Split(path, "."))
+		got, _ := value.Interface().(*string)
+		if got == nil || *got != name {
+			t.Errorf("%s: expected %q, got %v\n%s", path, name, got, rendered)
+		}
+	}
+}
+
 // Helper: check all pointer fields are non-nil
 func checkAllFieldsSet(t *testing.T, v interface{}) {
 	val := reflect.ValueOf(v).Elem()
@@ -58,7 +107,7 @@ func testTransitiveProperty(t *testing.T, ad Adapter, simThresh float64) {
 	newScheme := newSchemeVal.Interface().(Adapter)
 
 	// Run the adapter mapping: scheme -> abstract -> newScheme
-	err := adaptScheme(scheme, newScheme) // <-- no & here
+	_, err := adaptScheme(scheme, newScheme)
 	if err != nil {
 		t.Fatalf("Failed to adapt scheme transitive property for %T: %v", scheme, err)
 	}
@@ -184,3 +233,184 @@ func TestFieldSimilarity(t *testing.T) {
 		t.Errorf("expected less than 1.0 similarity, got %.2f", sim2)
 	}
 }
+
+type mockColorScheme struct {
+	A *Color
+	B *Color
+}
+
+func TestFieldSimilarity_Colors(t *testing.T) {
+	s1 := &mockColorScheme{A: mustHex(t, "#336699"), B: mustHex(t, "#000000")}
+	s2 := &mockColorScheme{A: mustHex(t, "#336799"), B: mustHex(t, "#000000")}
+	s3 := &mockColorScheme{A: mustHex(t, "#3366CC"), B: mustHex(t, "#000000")}
+
+	if sim := FieldSimilarity(s1, s2); sim != 1.0 {
+		t.Errorf("expected visually identical colors to match, got %.2f", sim)
+	}
+	if sim := FieldSimilarity(s1, s3); sim != 0.5 {
+		t.Errorf("expected one of two colors to match, got %.2f", sim)
+	}
+	if sim := FieldSimilarityWith(s1, s2, color.OKLabDeltaE); sim != 1.0 {
+		t.Errorf("expected visually identical colors to match under OKLab, got %.2f", sim)
+	}
+}
+
+// A house-style variant of a built-in template replaces it when found on the
+// search path
+func TestConvertThemeWith_Templates(t *testing.T) {
+	dir := t.TempDir()
+	house := "{{/* format: toml */ -}}\n# Managed by the platform team\nimport = [\"~/.config/alacritty/base.toml\"]\n\n[colors.primary]\nbackground = '{{ .Colors.Primary.Background.Hex }}'\n"
+	if err := os.WriteFile(filepath.Join(dir, "alacritty.toml.tmpl"), []byte(house), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	input := `{"name": "Test", "background": "#102030", "foreground": "#c0c0c0"}`
+	opts := ConvertOptions{Templates: templates.SearchPath{dir}}
+	output, _, err := ConvertThemeWith(input, &windows_terminal.WindowsTerminalScheme{}, &alacritty.AlacrittyScheme{}, opts)
+	if err != nil {
+		t.Fatalf("ConvertThemeWith failed: %v", err)
+	}
+	if !strings.HasPrefix(output, "# Managed by the platform team\n") || !strings.Contains(output, "background = '#102030'") {
+		t.Errorf("expected the house-style template to be used, got:\n%s", output)
+	}
+}
+
+// Only the command line looks in the user's template directory
+func TestRenderAdapterToString_IgnoresUserDir(t *testing.T) {
+	config := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", config)
+	dir := filepath.Join(config, "paletteport", "templates")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "alacritty.toml.tmpl"), []byte("{{ broken"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	scheme := &alacritty.AlacrittyScheme{}
+	fillDummyScheme(scheme)
+	output, err := RenderAdapterToString(scheme)
+	if err != nil {
+		t.Fatalf("RenderAdapterToString failed: %v", err)
+	}
+	if !strings.Contains(output, "[colors.primary]") {
+		t.Errorf("expected the embedded template, got:\n%s", output)
+	}
+}
+
+func TestConvertToTemplateWith(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.tmpl")
+	text := "{{/* format: yaml */ -}}\nname: {{ escape .Metadata.Name }}\nbg: {{ .SpecialColors.Background.Hex }}\ncursor: {{ .SpecialColors.Cursor.Hex }}\n"
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	input := `{"name": "A & B", "background": "#102030", "foreground": "#c0c0c0"}`
+	output, report, err := ConvertToTemplateWith(input, &windows_terminal.WindowsTerminalScheme{}, path, ConvertOptions{})
+	if err != nil {
+		t.Fatalf("ConvertToTemplateWith failed: %v", err)
+	}
+	// The cursor is filled in from the foreground by the default fallbacks
+	if want := "name: \"A & B\"\nbg: #102030\ncursor: #c0c0c0\n"; output != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, output)
+	}
+	if report.Writer != path || len(report.Filled) == 0 {
+		t.Errorf("expected a report of the fallbacks filled in for %s, got %+v", path, report)
+	}
+
+	if _, _, err := ConvertToTemplateWith(input, &windows_terminal.WindowsTerminalScheme{}, filepath.Join(t.TempDir(), "missing.tmpl"), ConvertOptions{}); err == nil {
+		t.Errorf("expected an error for a missing template file")
+	}
+}
+
+func TestRenderSchemeWith(t *testing.T) {
+	bg, _ := color.FromHex("#102030")
+	fg, _ := color.FromHex("#c0c0c0")
+	var scheme AbstractScheme
+	scheme.SpecialColors.Background = &bg
+	scheme.SpecialColors.Foreground = &fg
+	ansi := reflect.ValueOf(&scheme.AnsiColors).Elem()
+	for i := 0; i < ansi.NumField(); i++ {
+		ansi.Field(i).Set(reflect.ValueOf(&fg))
+	}
+
+	output, report, err := RenderSchemeWith(&scheme, &windows_terminal.WindowsTerminalScheme{}, ConvertOptions{})
+	if err != nil {
+		t.Fatalf("RenderSchemeWith failed: %v", err)
+	}
+	// The cursor is filled in from the foreground by the default fallbacks
+	if !strings.Contains(output, `"cursorColor": "#c0c0c0"`) {
+		t.Errorf("expected the cursor filled in from the foreground, got:\n%s", output)
+	}
+	if report.Writer != "wt" || len(report.Filled) == 0 {
+		t.Errorf("expected a report of the fallbacks filled in, got %+v", report)
+	}
+}
+
+func TestColorTransforms(t *testing.T) {
+	type translucent struct {
+		Selection *Color `abstract:"SpecialColors.Selection|alpha(0.3)"`
+		Bold      *Color `abstract:"SpecialColors.Foreground|lighten(0.1)"`
+	}
+	sel, _ := color.FromHex("#336699")
+	fg, _ := color.FromHex("#808080")
+	var scheme AbstractScheme
+	scheme.SpecialColors.Selection = &sel
+	scheme.SpecialColors.Foreground = &fg
+
+	var written translucent
+	if err := objectmap.MapFrom(&scheme, &written, nil, nil, abstractTag); err != nil {
+		t.Fatalf("MapFrom failed: %v", err)
+	}
+	if got := written.Selection.HexAlpha(); got != "#3366994d" {
+		t.Errorf("expected the selection at 30%% alpha, got %s", got)
+	}
+	lighter := fg.Lighten(0.1)
+	if want := lighter.Hex(); written.Bold.Hex() != want {
+		t.Errorf("expected the foreground lightened to %s, got %s", want, written.Bold.Hex())
+	}
+
+	var read AbstractScheme
+	if err := objectmap.MapInto(&written, &read, nil, nil, abstractTag); err != nil {
+		t.Fatalf("MapInto failed: %v", err)
+	}
+	if got := read.SpecialColors.Selection.HexAlpha(); got != "#336699" {
+		t.Errorf("expected the selection read back opaque, got %s", got)
+	}
+	if got := read.SpecialColors.Foreground.Hex(); got != "#808080" {
+		t.Errorf("expected the foreground darkened back, got %s", got)
+	}
+}
+
+func TestBase16_SeveralPaths(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "themes", "base16.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheme, _, err := ParseAbstract(string(data), &base16.Base16Scheme{})
+	if err != nil {
+		t.Fatalf("ParseAbstract failed: %v", err)
+	}
+	if scheme.SpecialColors.Selection.Hex() != "#222222" {
+		t.Errorf("expected the selection from base02, got %s", scheme.SpecialColors.Selection.Hex())
+	}
+	if scheme.SpecialColors.Foreground.Hex() != "#555555" || scheme.SpecialColors.Cursor.Hex() != "#555555" {
+		t.Errorf("expected the foreground and cursor from base05, got %s and %s",
+			scheme.SpecialColors.Foreground.Hex(), scheme.SpecialColors.Cursor.Hex())
+	}
+
+	// Without a foreground, base05 is written from the cursor
+	scheme.SpecialColors.Foreground = nil
+	writer := &base16.Base16Scheme{}
+	report := newConversionReport("", writer.Name())
+	if err := FromAbstract(scheme, writer, report); err != nil {
+		t.Fatalf("FromAbstract failed: %v", err)
+	}
+	if writer.Base05.Hex() != "#555555" {
+		t.Errorf("expected base05 from the cursor, got %s", writer.Base05.Hex())
+	}
+	want := FieldReport{Path: "Base05", Value: "#555555", Source: "SpecialColors.Cursor"}
+	if !slices.Contains(report.Filled, want) {
+		t.Errorf("expected the cursor reported as the source of base05, got %+v", report.Filled)
+	}
+}
@@ -0,0 +1,60 @@
+// Package adaptertest holds the helpers shared by the tests of the adapter
+// packages
+package adaptertest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+)
+
+// Theme returns the path of a fixture in the repository's themes directory
+func Theme(name string) string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..", "themes", name)
+}
+
+// Load reads the fixture at path into the adapter and returns its contents
+func Load(t testing.TB, path string, ad adapter.Adapter) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read theme file: %v", err)
+	}
+	if err := ad.FromString(string(data)); err != nil {
+		t.Fatalf("Failed to parse theme: %v", err)
+	}
+	return string(data)
+}
+
+// RoundTrip renders the adapter with its template and parses the output into a
+// new adapter of the same type, returning it along with the output
+func RoundTrip[A adapter.Adapter](t testing.TB, ad A) (A, string) {
+	t.Helper()
+	rendered, err := adapter.RenderAdapterToString(ad)
+	if err != nil {
+		t.Fatalf("Failed to render theme: %v", err)
+	}
+	t.Logf("Rendered theme:\n%s", rendered)
+
+	reparsed := reflect.New(reflect.TypeOf(ad).Elem()).Interface().(A)
+	if err := reparsed.FromString(rendered); err != nil {
+		t.Fatalf("Failed to parse the rendered theme: %v\n%s", err, rendered)
+	}
+	return reparsed, rendered
+}
+
+// AssertRoundTrip fails the test unless rendering the adapter and parsing the
+// output gives back the same adapter, and returns the output
+func AssertRoundTrip[A adapter.Adapter](t testing.TB, ad A) string {
+	t.Helper()
+	reparsed, rendered := RoundTrip(t, ad)
+	if !reflect.DeepEqual(ad, reparsed) {
+		t.Errorf("round trip changed the theme")
+	}
+	return rendered
+}
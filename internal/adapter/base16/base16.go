@@ -11,11 +11,11 @@ type Base16Scheme struct {
 	Scheme *string `yaml:"scheme" abstract:"Metadata.Name"`
 	Author *string `yaml:"author" abstract:"Metadata.Author"`
 	Base00 *Color  `yaml:"base00" abstract:"SpecialColors.Background"`
-	Base01 *Color  `yaml:"base01" abstract:"SpecialColors.Selection"`
-	Base02 *Color  `yaml:"base02" abstract:"SpecialColors.Cursor"`
+	Base01 *Color  `yaml:"base01" abstract:"ScopeColors.Editor.CursorLine"`
+	Base02 *Color  `yaml:"base02" abstract:"SpecialColors.Selection"`
 	Base03 *Color  `yaml:"base03" abstract:"SpecialColors.CursorText"`
 	Base04 *Color  `yaml:"base04" abstract:"SpecialColors.SelectedText"`
-	Base05 *Color  `yaml:"base05" abstract:"SpecialColors.Foreground"`
+	Base05 *Color  `yaml:"base05" abstract:"SpecialColors.Foreground,SpecialColors.Cursor"`
 	Base06 *Color  `yaml:"base06" abstract:"SpecialColors.ForegroundBright"`
 	Base07 *Color  `yaml:"base07" abstract:"AnsiColors.White"`
 	Base08 *Color  `yaml:"base08" abstract:"AnsiColors.Red"`
@@ -0,0 +1,82 @@
+package adapter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter/windows_terminal"
+)
+
+// BenchmarkConvertTheme converts a scheme into every format, as converting a
+// library of schemes does
+func BenchmarkConvertTheme(b *testing.B) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "themes", "wt.json"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	input := string(data)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, writer := range Adapters {
+			if _, _, err := ConvertThemeWith(input, &windows_terminal.WindowsTerminalScheme{}, newAdapterInstance(writer), ConvertOptions{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkMapping maps a scheme to and from every format, without the
+// parsing and rendering around it
+func BenchmarkMapping(b *testing.B) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "themes", "wt.json"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	scheme, _, err := ParseAbstract(string(data), &windows_terminal.WindowsTerminalScheme{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, a := range Adapters {
+			writer := newAdapterInstance(a)
+			report := newConversionReport("", writer.Name())
+			if err := FromAbstract(scheme, writer, report); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := ToAbstract(writer, report); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkMapping_NoReport is BenchmarkMapping without reports, leaving
+// just the generated mappings
+func BenchmarkMapping_NoReport(b *testing.B) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "themes", "wt.json"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	scheme, _, err := ParseAbstract(string(data), &windows_terminal.WindowsTerminalScheme{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	writers := make([]Adapter, len(Adapters))
+	for i, a := range Adapters {
+		writers[i] = newAdapterInstance(a)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, writer := range writers {
+			if err := FromAbstract(scheme, writer, nil); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := ToAbstract(writer, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package adapter
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Candidate is a possible source format for an input along with how confident
+// detection is that the input is in that format, from 0 (no evidence) to 1.
+type Candidate struct {
+	Name       string  `json:"name"`
+	Confidence float64 `json:"confidence"`
+}
+
+// A signature is a content pattern typical of a format, weighted by how
+// strongly it identifies that format
+type signature struct {
+	pattern *regexp.Regexp
+	weight  float64
+}
+
+// detectRule describes how to recognize the input of a single adapter
+type detectRule struct {
+	adapter    string
+	extensions []string
+	signatures []signature
+}
+
+const (
+	// Share of the confidence carried by the file extension. Content is far more
+	// reliable, as extensions like .yml and .json are shared between formats.
+	extensionWeight = 0.25
+	contentWeight   = 1 - extensionWeight
+
+	// Minimum confidence for a candidate to be picked automatically
+	minDetectConfidence = 0.3
+	// Minimum lead the best candidate needs over the runner up
+	minDetectMargin = 0.15
+)
+
+func sig(pattern string, weight float64) signature {
+	return signature{regexp.MustCompile(pattern), weight}
+}
+
+// Detection rules, one per registered adapter
+var detectRules = []detectRule{
+	{
+		adapter:    "iterm",
+		extensions: []string{".itermcolors", ".plist"},
+		signatures: []signature{
+			sig(`<plist[\s>]`, 1),
+			sig(`<key>Ansi \d+ Color</key>`, 2),
+		},
+	},
+	{
+		adapter:    "alacritty",
+		extensions: []string{".toml"},
+		signatures: []signature{
+			sig(`(?m)^\s*\[colors\.primary\]`, 2),
+			sig(`(?m)^\s*\[colors\.(normal|bright|cursor|selection)\]`, 1),
+		},
+	},
+	{
+		adapter:    "base16",
+		extensions: []string{".yml", ".yaml"},
+		signatures: []signature{
+			sig(`(?m)^\s*base0[0-9A-Fa-f]\s*:`, 2),
+			sig(`(?m)^\s*scheme\s*:`, 1),
+		},
+	},
+	{
+		adapter:    "gogh",
+		extensions: []string{".yml", ".yaml"},
+		signatures: []signature{
+			sig(`(?m)^\s*color_(0[1-9]|1[0-6])\s*:`, 2),
+			sig(`(?m)^\s*(background|foreground|cursor)\s*:`, 1),
+		},
+	},
+	{
+		adapter:    "wt",
+		extensions: []string{".json"},
+		signatures: []signature{
+			sig(`"brightPurple"\s*:`, 2),
+			sig(`"(selectionBackground|cursorColor|purple)"\s*:`, 1),
+		},
+	},
+	{
+		adapter:    "vscode",
+		extensions: []string{".json", ".jsonc"},
+		signatures: []signature{
+			sig(`"tokenColors"\s*:`, 2),
+			sig(`"(editor\.background|terminal\.ansi\w+)"\s*:`, 1),
+		},
+	},
+	{
+		adapter:    "terminator",
+		extensions: []string{".config"},
+		signatures: []signature{
+			sig(`(?m)^\s*palette\s*=\s*["']?#[0-9A-Fa-f]+(:#[0-9A-Fa-f]+){15}`, 2),
+			sig(`(?m)^\s*(background|foreground|cursor)_color\s*=`, 1),
+		},
+	},
+	{
+		adapter:    "kitty",
+		extensions: []string{".conf"},
+		signatures: []signature{
+			sig(`(?m)^\s*color(1[0-5]|[0-9])\s+#?[0-9A-Fa-f]{3,8}\s*$`, 2),
+			sig(`(?m)^\s*(foreground|background|selection_background|cursor_text_color|url_color)\s+\S`, 1),
+		},
+	},
+	{
+		adapter:    "paletteport",
+		extensions: []string{".yml", ".yaml"},
+		signatures: []signature{
+			sig(`(?m)^(ScopeColors|AnsiColors|SpecialColors)\s*:`, 2),
+			sig(`(?m)^version\s*:\s*\d+\s*$`, 1),
+		},
+	},
+	{
+		adapter:    "paletteport-json",
+		extensions: []string{".json"},
+		signatures: []signature{
+			sig(`"(ScopeColors|AnsiColors|SpecialColors)"\s*:`, 2),
+			sig(`"version"\s*:\s*\d+`, 1),
+		},
+	},
+	{
+		adapter:    "paletteport-toml",
+		extensions: []string{".toml"},
+		signatures: []signature{
+			sig(`(?m)^\[(ScopeColors|AnsiColors|SpecialColors)[.\]]`, 2),
+			sig(`(?m)^version\s*=\s*\d+\s*$`, 1),
+		},
+	},
+}
+
+// Detect ranks the registered adapters by how likely it is that input, read
+// from a file called filename, is in their format. The filename may be empty
+// when reading from stdin, in which case only the content is considered.
+// Adapters without any evidence are left out.
+func Detect(filename string, input string) []Candidate {
+	ext := strings.ToLower(filepath.Ext(filename))
+
+	var candidates []Candidate
+	for _, rule := range detectRules {
+		confidence := 0.0
+
+		for _, e := range rule.extensions {
+			if e == ext {
+				confidence += extensionWeight
+				break
+			}
+		}
+
+		var matched, total float64
+		for _, s := range rule.signatures {
+			total += s.weight
+			if s.pattern.MatchString(input) {
+				matched += s.weight
+			}
+		}
+		if total > 0 {
+			confidence += contentWeight * matched / total
+		}
+
+		if confidence > 0 {
+			candidates = append(candidates, Candidate{Name: rule.adapter, Confidence: confidence})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+	return candidates
+}
+
+// DetectAdapter returns a fresh instance of the adapter that best matches the
+// input. It fails if no adapter is a convincing match, or if the best matches
+// are too close to call.
+func DetectAdapter(filename string, input string) (Adapter, error) {
+	candidates := Detect(filename, input)
+
+	if len(candidates) == 0 || candidates[0].Confidence < minDetectConfidence {
+		return nil, fmt.Errorf("could not detect input format, candidates: %s", formatCandidates(candidates))
+	}
+	if len(candidates) > 1 && candidates[0].Confidence-candidates[1].Confidence < minDetectMargin {
+		return nil, fmt.Errorf("input format is ambiguous, candidates: %s", formatCandidates(candidates))
+	}
+
+	return GetAdapter(candidates[0].Name)
+}
+
+func formatCandidates(candidates []Candidate) string {
+	if len(candidates) == 0 {
+		return "none"
+	}
+	parts := make([]string, len(candidates))
+	for i, c := range candidates {
+		parts[i] = fmt.Sprintf("%s (%.2f)", c.Name, c.Confidence)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,96 @@
+package adapter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetect_Themes(t *testing.T) {
+	tests := []struct {
+		file string // Relative to the repository root
+		want string
+	}{
+		{"themes/alacritty.toml", "alacritty"},
+		{"themes/base16.yaml", "base16"},
+		{"themes/gogh.yml", "gogh"},
+		{"themes/iterm.itermcolors", "iterm"},
+		{"themes/wt.json", "wt"},
+		{"internal/adapter/vscode/tokyo-night-color-theme.json", "vscode"},
+		{"themes/kitty.conf", "kitty"},
+		{"themes/terminator.config", "terminator"},
+		{"themes/paletteport.yml", "paletteport"},
+	}
+
+	for _, tc := range tests {
+		name := filepath.Base(tc.file)
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("..", "..", tc.file))
+			if err != nil {
+				t.Fatalf("failed to read theme: %v", err)
+			}
+
+			// With and without the help of the file name
+			for _, name := range []string{name, ""} {
+				ad, err := DetectAdapter(name, string(data))
+				if err != nil {
+					t.Fatalf("DetectAdapter(%q) failed: %v", name, err)
+				}
+				if ad.Name() != tc.want {
+					t.Errorf("DetectAdapter(%q) = %s, want %s", name, ad.Name(), tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDetect_Ranking(t *testing.T) {
+	candidates := Detect("theme.yml", "scheme: x\nbase00: \"000000\"\n")
+	if len(candidates) < 2 {
+		t.Fatalf("expected both YAML formats as candidates, got %v", candidates)
+	}
+	if candidates[0].Name != "base16" {
+		t.Errorf("expected base16 first, got %v", candidates)
+	}
+	for i := 1; i < len(candidates); i++ {
+		if candidates[i].Confidence > candidates[i-1].Confidence {
+			t.Errorf("candidates not sorted by confidence: %v", candidates)
+		}
+	}
+}
+
+func TestDetectAdapter_Failures(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		input    string
+	}{
+		{"no evidence", "", "hello world"},
+		{"ambiguous extension", "theme.yml", "name: x\n"},
+		{"extension only", "theme.toml", "[window]\nopacity = 0.9\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if ad, err := DetectAdapter(tc.filename, tc.input); err == nil {
+				t.Errorf("expected detection to fail, got %s", ad.Name())
+			}
+		})
+	}
+}
+
+// Rules are kept apart from the adapters, so make sure none is left without
+func TestDetectRules_CoverAdapters(t *testing.T) {
+	rules := make(map[string]int)
+	for _, rule := range detectRules {
+		if _, err := GetAdapter(rule.adapter); err != nil {
+			t.Errorf("rule for unregistered adapter: %v", err)
+		}
+		rules[rule.adapter]++
+	}
+	for _, name := range AdapterNames() {
+		if rules[name] != 1 {
+			t.Errorf("adapter %s has %d detection rules, want 1", name, rules[name])
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package adapter
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/structutil"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed fallbacks.yml
+var defaultFallbacksYAML []byte
+
+// FallbackRule fills an unset abstract color from another one. See
+// fallbacks.yml for the format.
+type FallbackRule struct {
+	Target   string             `yaml:"target"`
+	From     string             `yaml:"from"`
+	Derive   string             `yaml:"derive,omitempty"`
+	Priority int                `yaml:"priority,omitempty"`
+	When     *FallbackCondition `yaml:"when,omitempty"`
+
+	transform color.Transform
+}
+
+// FallbackCondition restricts when a rule applies. All given conditions must hold.
+type FallbackCondition struct {
+	Dark  *bool    `yaml:"dark,omitempty"`  // Whether SpecialColors.Background is dark
+	Set   []string `yaml:"set,omitempty"`   // Fields that must have a value
+	Unset []string `yaml:"unset,omitempty"` // Fields that must not have a value
+}
+
+// FallbackRules is an ordered set of fallback rules
+type FallbackRules struct {
+	// Inherit the default rules, tried after these at equal priority. Only
+	// read from user rule files, and true unless given.
+	Inherit *bool          `yaml:"inherit,omitempty"`
+	Rules   []FallbackRule `yaml:"rules"`
+}
+
+// Backgrounds with an OKLCH lightness below this are considered dark
+const darkBackgroundLightness = 0.5
+
+var (
+	defaultFallbacks     *FallbackRules
+	defaultFallbacksOnce sync.Once
+)
+
+// DefaultFallbackRules returns the rules embedded from fallbacks.yml
+func DefaultFallbackRules() *FallbackRules {
+	defaultFallbacksOnce.Do(func() {
+		rules, err := ParseFallbackRules(defaultFallbacksYAML)
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded fallback rules: %v", err))
+		}
+		defaultFallbacks = rules
+	})
+	return defaultFallbacks
+}
+
+// ParseFallbackRules parses and validates a YAML rule set. Every field must
+// name a color of the abstract scheme.
+func ParseFallbackRules(data []byte) (*FallbackRules, error) {
+	var rules FallbackRules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse fallback rules: %w", err)
+	}
+
+	for i := range rules.Rules {
+		rule := &rules.Rules[i]
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("fallback rule %d: %w", i+1, err)
+		}
+	}
+	rules.sort()
+	return &rules, nil
+}
+
+// LoadFallbackRules reads a user rule file. Unless the file sets
+// "inherit: false", the default rules are appended to its own, so that the
+// file only needs to list what it adds or overrides.
+func LoadFallbackRules(path string) (*FallbackRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fallback rules: %w", err)
+	}
+	rules, err := ParseFallbackRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if rules.Inherit == nil || *rules.Inherit {
+		rules.Rules = append(rules.Rules, DefaultFallbackRules().Rules...)
+		rules.sort()
+	}
+	return rules, nil
+}
+
+// sort orders the rules by descending priority, keeping the order of rules
+// with equal priority
+func (r *FallbackRules) sort() {
+	sort.SliceStable(r.Rules, func(i, j int) bool {
+		return r.Rules[i].Priority > r.Rules[j].Priority
+	})
+}
+
+func (rule *FallbackRule) validate() error {
+	if rule.Target == "" || rule.From == "" {
+		return fmt.Errorf("target and from are required")
+	}
+	paths := []string{rule.Target, rule.From}
+	if rule.When != nil {
+		paths = append(append(paths, rule.When.Set...), rule.When.Unset...)
+	}
+	for _, path := range paths {
+		if _, err := schemeColorField(&AbstractScheme{}, path); err != nil {
+			return err
+		}
+	}
+
+	if rule.Derive != "" {
+		transform, err := color.ParseTransform(rule.Derive)
+		if err != nil {
+			return err
+		}
+		rule.transform = transform
+	}
+	return nil
+}
+
+// source describes where the rule takes its color from, for reports
+func (rule *FallbackRule) source() string {
+	if rule.Derive == "" {
+		return rule.From
+	}
+	return rule.From + " | " + rule.Derive
+}
+
+func (cond *FallbackCondition) holds(s *AbstractScheme) bool {
+	if cond == nil {
+		return true
+	}
+	if cond.Dark != nil {
+		bg := s.SpecialColors.Background
+		if bg == nil || (bg.ToOKLCH().L < darkBackgroundLightness) != *cond.Dark {
+			return false
+		}
+	}
+	for _, path := range cond.Set {
+		if schemeColor(s, path) == nil {
+			return false
+		}
+	}
+	for _, path := range cond.Unset {
+		if schemeColor(s, path) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply fills the unset colors of the scheme. Rules are applied repeatedly
+// until none fills anything, so filled colors can be used by other rules. It
+// returns the filled fields, mapped to a description of their source.
+func (r *FallbackRules) Apply(s *AbstractScheme) map[string]string {
+	filled := make(map[string]string)
+	for changed := true; changed; {
+		changed = false
+		for i := range r.Rules {
+			rule := &r.Rules[i]
+			target, _ := schemeColorField(s, rule.Target)
+			if !target.IsNil() {
+				continue
+			}
+			source := schemeColor(s, rule.From)
+			if source == nil || !rule.When.holds(s) {
+				continue
+			}
+
+			// Filled colors are copies, so adjusting one later leaves its source alone
+			value := *source
+			if rule.transform != nil {
+				value = rule.transform(value)
+			}
+			target.Set(reflect.ValueOf(&value))
+			filled[rule.Target] = rule.source()
+			changed = true
+		}
+	}
+	return filled
+}
+
+// schemeColorField returns the settable color field at the dot-separated path
+func schemeColorField(s *AbstractScheme, path string) (reflect.Value, error) {
+	found, _, value := structutil.HasNestedFieldSlice(reflect.ValueOf(s), strings.Split(path, "."))
+	if !found {
+		return reflect.Value{}, fmt.Errorf("abstract scheme has no field %q", path)
+	}
+	if value.Type() != reflect.TypeOf((*color.Color)(nil)) {
+		return reflect.Value{}, fmt.Errorf("abstract field %q is not a color", path)
+	}
+	return value, nil
+}
+
+// schemeColor returns the color at a path already validated by
+// schemeColorField, or nil if it is unset
+func schemeColor(s *AbstractScheme, path string) *color.Color {
+	value, _ := schemeColorField(s, path)
+	return value.Interface().(*color.Color)
+}
+
+// fillFallbacks fills unset colors by the rules, recording each filled field
+// and where its value came from in the report
+func fillFallbacks(s *AbstractScheme, rules *FallbackRules, report *ConversionReport) {
+	for path, source := range rules.Apply(s) {
+		report.fallbacks[path] = source
+	}
+}
@@ -0,0 +1,164 @@
+package adapter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultFallbackRules(t *testing.T) {
+	rules := DefaultFallbackRules()
+	if len(rules.Rules) == 0 {
+		t.Fatal("expected default rules")
+	}
+	for i := 1; i < len(rules.Rules); i++ {
+		if rules.Rules[i].Priority > rules.Rules[i-1].Priority {
+			t.Fatalf("expected rules sorted by priority, got %+v before %+v", rules.Rules[i-1], rules.Rules[i])
+		}
+	}
+}
+
+func TestFallbackRules_Apply(t *testing.T) {
+	s := &AbstractScheme{}
+	s.SpecialColors.Background = mustHex(t, "#1A1B26")
+	s.SpecialColors.Foreground = mustHex(t, "#C0CAF5")
+	s.AnsiColors.Red = mustHex(t, "#F7768E")
+	s.AnsiColors.Green = mustHex(t, "#9ECE6A")
+
+	filled := DefaultFallbackRules().Apply(s)
+
+	if got := filled["SpecialColors.CursorText"]; got != "SpecialColors.Background" {
+		t.Errorf("expected cursor text from the background, got %q", got)
+	}
+	if s.SpecialColors.CursorText == s.SpecialColors.Background {
+		t.Errorf("expected a filled color to be a copy of its source")
+	}
+	if got := filled["ScopeColors.Basic.String"]; got != "AnsiColors.Green" {
+		t.Errorf("expected strings from green, got %q", got)
+	}
+
+	// Derived values
+	if got := filled["AnsiColors.BrightRed"]; got != "AnsiColors.Red | lighten(0.1)" {
+		t.Errorf("expected bright red derived from red, got %q", got)
+	}
+	if want := s.AnsiColors.Red.Lighten(0.1); *s.AnsiColors.BrightRed != want {
+		t.Errorf("expected bright red %s, got %s", want.ToHex(true), s.AnsiColors.BrightRed.ToHex(true))
+	}
+
+	// Chains: black comes from the background, bright black from black
+	if filled["AnsiColors.Black"] != "SpecialColors.Background" || filled["AnsiColors.BrightBlack"] != "AnsiColors.Black | lighten(0.25)" {
+		t.Errorf("expected bright black to be derived from a filled black, got %q and %q",
+			filled["AnsiColors.Black"], filled["AnsiColors.BrightBlack"])
+	}
+
+	// Conditions: the background is dark, so the cursor line is lightened
+	if got := filled["ScopeColors.Editor.CursorLine"]; got != "SpecialColors.Background | lighten(0.05)" {
+		t.Errorf("expected the cursor line lightened on a dark background, got %q", got)
+	}
+
+	// Set colors are never replaced
+	if _, ok := filled["AnsiColors.Red"]; ok || s.AnsiColors.Red.ToHex(true) != "#F7768E" {
+		t.Errorf("expected red to be left alone")
+	}
+}
+
+func TestFallbackRules_PriorityAndConditions(t *testing.T) {
+	rules, err := ParseFallbackRules([]byte(`
+rules:
+  - target: SpecialColors.Cursor
+    from: AnsiColors.White
+  - target: SpecialColors.Cursor
+    from: AnsiColors.Red
+    priority: 2
+    when: {unset: [SpecialColors.Selection]}
+  - target: SpecialColors.Cursor
+    from: AnsiColors.Green
+    priority: 1
+    when: {dark: false}
+`))
+	if err != nil {
+		t.Fatalf("ParseFallbackRules failed: %v", err)
+	}
+
+	newScheme := func(background string) *AbstractScheme {
+		s := &AbstractScheme{}
+		s.SpecialColors.Background = mustHex(t, background)
+		s.AnsiColors.White = mustHex(t, "#FFFFFF")
+		s.AnsiColors.Red = mustHex(t, "#FF0000")
+		s.AnsiColors.Green = mustHex(t, "#00FF00")
+		return s
+	}
+
+	s := newScheme("#000000")
+	rules.Apply(s)
+	if got := s.SpecialColors.Cursor.ToHex(true); got != "#FF0000" {
+		t.Errorf("expected the highest priority rule to win, got %s", got)
+	}
+
+	s = newScheme("#FFFFFF")
+	s.SpecialColors.Selection = mustHex(t, "#333333")
+	rules.Apply(s)
+	if got := s.SpecialColors.Cursor.ToHex(true); got != "#00FF00" {
+		t.Errorf("expected the light background rule to apply, got %s", got)
+	}
+
+	s = newScheme("#000000")
+	s.SpecialColors.Selection = mustHex(t, "#333333")
+	rules.Apply(s)
+	if got := s.SpecialColors.Cursor.ToHex(true); got != "#FFFFFF" {
+		t.Errorf("expected the unconditional rule to apply last, got %s", got)
+	}
+}
+
+func TestParseFallbackRules_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown target":    "rules: [{target: AnsiColors.Orange, from: AnsiColors.Red}]",
+		"not a color":       "rules: [{target: Metadata.Name, from: AnsiColors.Red}]",
+		"missing source":    "rules: [{target: AnsiColors.Red}]",
+		"unknown transform": "rules: [{target: AnsiColors.Red, from: AnsiColors.Blue, derive: brighten(1)}]",
+		"unknown condition": "rules: [{target: AnsiColors.Red, from: AnsiColors.Blue, when: {set: [Nope]}}]",
+		"not yaml":          "rules: [",
+	}
+	for name, data := range tests {
+		if _, err := ParseFallbackRules([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLoadFallbackRules(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	rule := "  - {target: SpecialColors.CursorText, from: AnsiColors.Red}\n"
+	inherited, err := LoadFallbackRules(write("inherit.yml", "rules:\n"+rule))
+	if err != nil {
+		t.Fatalf("LoadFallbackRules failed: %v", err)
+	}
+	if len(inherited.Rules) != len(DefaultFallbackRules().Rules)+1 {
+		t.Errorf("expected the default rules to be inherited, got %d rules", len(inherited.Rules))
+	}
+	if inherited.Rules[0].From != "AnsiColors.Red" {
+		t.Errorf("expected user rules before defaults of equal priority, got %+v", inherited.Rules[0])
+	}
+
+	alone, err := LoadFallbackRules(write("alone.yml", "inherit: false\nrules:\n"+rule))
+	if err != nil {
+		t.Fatalf("LoadFallbackRules failed: %v", err)
+	}
+	if len(alone.Rules) != 1 {
+		t.Errorf("expected only the user rule, got %d rules", len(alone.Rules))
+	}
+
+	_, err = LoadFallbackRules(write("bad.yml", "rules: [{target: Nope, from: AnsiColors.Red}]"))
+	if err == nil || !strings.Contains(err.Error(), "bad.yml") {
+		t.Errorf("expected an error naming the file, got %v", err)
+	}
+}
@@ -1,6 +1,10 @@
 package gogh
 
 import (
+	"errors"
+	"io"
+	"strings"
+
 	"github.com/da-luce/paletteport/internal/color"
 
 	"gopkg.in/yaml.v3"
@@ -39,6 +43,10 @@ func (rw *GoghScheme) TemplateName() string {
 	return "gogh.yml.tmpl"
 }
 
+func (rw *GoghScheme) BundleTemplateName() string {
+	return "gogh.bundle.yml.tmpl"
+}
+
 func (rw *GoghScheme) FromString(input string) error {
 	err := yaml.Unmarshal([]byte(input), rw)
 	if err != nil {
@@ -46,3 +54,37 @@ func (rw *GoghScheme) FromString(input string) error {
 	}
 	return nil
 }
+
+// SplitSchemes accepts a stream of YAML documents, a sequence of schemes like
+// Gogh's own themes.json, or a mix of both
+func (rw *GoghScheme) SplitSchemes(input string) ([]string, []string, error) {
+	var names, docs []string
+	decoder := yaml.NewDecoder(strings.NewReader(input))
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); errors.Is(err, io.EOF) {
+			return names, docs, nil
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		items := node.Content
+		if len(items) == 1 && items[0].Kind == yaml.SequenceNode {
+			items = items[0].Content
+		}
+		for _, item := range items {
+			var named struct {
+				Name string `yaml:"name"`
+			}
+			if err := item.Decode(&named); err != nil {
+				return nil, nil, err
+			}
+			data, err := yaml.Marshal(item)
+			if err != nil {
+				return nil, nil, err
+			}
+			names = append(names, named.Name)
+			docs = append(docs, string(data))
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package kitty
+
+import (
+	"bufio"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+type Color = color.Color
+
+// KittyScheme holds the color settings of a kitty config. The kitty tag is the
+// setting's key.
+type KittyScheme struct {
+	ThemeName *string `abstract:"Metadata.Name"`   // From a "## name:" header comment
+	Author    *string `abstract:"Metadata.Author"` // From a "## author:" header comment
+
+	Foreground          *Color `kitty:"foreground" abstract:"SpecialColors.Foreground"`
+	Background          *Color `kitty:"background" abstract:"SpecialColors.Background"`
+	SelectionForeground *Color `kitty:"selection_foreground" abstract:"SpecialColors.SelectedText"`
+	SelectionBackground *Color `kitty:"selection_background" abstract:"SpecialColors.Selection"`
+	Cursor              *Color `kitty:"cursor" abstract:"SpecialColors.Cursor"`
+	CursorText          *Color `kitty:"cursor_text_color" abstract:"SpecialColors.CursorText"`
+	URL                 *Color `kitty:"url_color" abstract:"SpecialColors.Links"`
+
+	Color0  *Color `kitty:"color0" abstract:"AnsiColors.Black"`
+	Color1  *Color `kitty:"color1" abstract:"AnsiColors.Red"`
+	Color2  *Color `kitty:"color2" abstract:"AnsiColors.Green"`
+	Color3  *Color `kitty:"color3" abstract:"AnsiColors.Yellow"`
+	Color4  *Color `kitty:"color4" abstract:"AnsiColors.Blue"`
+	Color5  *Color `kitty:"color5" abstract:"AnsiColors.Magenta"`
+	Color6  *Color `kitty:"color6" abstract:"AnsiColors.Cyan"`
+	Color7  *Color `kitty:"color7" abstract:"AnsiColors.White"`
+	Color8  *Color `kitty:"color8" abstract:"AnsiColors.BrightBlack"`
+	Color9  *Color `kitty:"color9" abstract:"AnsiColors.BrightRed"`
+	Color10 *Color `kitty:"color10" abstract:"AnsiColors.BrightGreen"`
+	Color11 *Color `kitty:"color11" abstract:"AnsiColors.BrightYellow"`
+	Color12 *Color `kitty:"color12" abstract:"AnsiColors.BrightBlue"`
+	Color13 *Color `kitty:"color13" abstract:"AnsiColors.BrightMagenta"`
+	Color14 *Color `kitty:"color14" abstract:"AnsiColors.BrightCyan"`
+	Color15 *Color `kitty:"color15" abstract:"AnsiColors.BrightWhite"`
+
+	// Other color settings, like active_border_color, the tab bar colors and
+	// mark1_foreground, as written. They have no abstract counterpart, so
+	// they are reported as dropped on conversion, but kept when rendering
+	// the scheme itself.
+	Extra map[string]string
+}
+
+func (rw *KittyScheme) Name() string {
+	return "kitty"
+}
+
+func (rw *KittyScheme) TemplateName() string {
+	return "kitty.conf.tmpl"
+}
+
+// extraColorKey matches the keys of color settings without a field
+var extraColorKey = regexp.MustCompile(`^(color\d+|\w+_(color|foreground|background))$`)
+
+// FromString reads the color settings of a kitty config. Comments, include
+// lines and all other settings are skipped, so a complete kitty.conf can be
+// read. As in kitty, a later setting overrides an earlier one.
+func (rw *KittyScheme) FromString(input string) error {
+	fields := settingFields(rw)
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			rw.readHeader(line)
+			continue
+		}
+
+		i := strings.IndexAny(line, " \t")
+		if i < 0 {
+			continue
+		}
+		key, value := line[:i], strings.TrimSpace(line[i:])
+
+		field, known := fields[key]
+		if !known && !extraColorKey.MatchString(key) {
+			continue
+		}
+
+		// Values that aren't hex colors, like "none" or a color name, are
+		// kept as written
+		c, err := color.FromHex(value)
+		if known && err == nil {
+			field.Set(reflect.ValueOf(&c))
+			delete(rw.Extra, key)
+			continue
+		}
+		if known {
+			field.Set(reflect.Zero(field.Type()))
+		}
+		if rw.Extra == nil {
+			rw.Extra = make(map[string]string)
+		}
+		rw.Extra[key] = value
+	}
+	return scanner.Err()
+}
+
+// readHeader reads the name and author from the header comments used by kitty
+// themes, like "## name: Tokyo Night"
+func (rw *KittyScheme) readHeader(line string) {
+	key, value, ok := strings.Cut(strings.TrimLeft(line, "# "), ":")
+	value = strings.TrimSpace(value)
+	if !ok || value == "" {
+		return
+	}
+	switch strings.ToLower(strings.TrimSpace(key)) {
+	case "name":
+		rw.ThemeName = &value
+	case "author":
+		rw.Author = &value
+	}
+}
+
+// settingFields maps the kitty keys of the scheme's fields to the fields
+func settingFields(rw *KittyScheme) map[string]reflect.Value {
+	fields := make(map[string]reflect.Value)
+	v := reflect.ValueOf(rw).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if key := v.Type().Field(i).Tag.Get("kitty"); key != "" {
+			fields[key] = v.Field(i)
+		}
+	}
+	return fields
+}
+
+// Setting is a single line of a kitty config: a color, or for the other
+// colors a value kept as written
+type Setting struct {
+	Key   string
+	Color *Color
+	Value string
+}
+
+// Settings returns the colors in field order, unset ones included so the
+// template decides what to do with them
+func (rw *KittyScheme) Settings() []Setting {
+	var settings []Setting
+	v := reflect.ValueOf(rw).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		key := v.Type().Field(i).Tag.Get("kitty")
+		if c, ok := v.Field(i).Interface().(*Color); ok && key != "" {
+			settings = append(settings, Setting{Key: key, Color: c})
+		}
+	}
+	return settings
+}
+
+// ExtraSettings returns the other color settings sorted by key
+func (rw *KittyScheme) ExtraSettings() []Setting {
+	keys := make([]string, 0, len(rw.Extra))
+	for key := range rw.Extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	settings := make([]Setting, len(keys))
+	for i, key := range keys {
+		settings[i] = Setting{Key: key, Value: rw.Extra[key]}
+	}
+	return settings
+}
@@ -0,0 +1,123 @@
+package kitty_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/adapter/adaptertest"
+	"github.com/da-luce/paletteport/internal/adapter/kitty"
+)
+
+// A user config, with colors mixed into other settings
+const userConfig = `# vim:fileencoding=utf-8:foldmethod=marker
+
+include ./theme.conf
+globinclude kitty.d/**/*.conf
+
+font_family      JetBrains Mono
+font_size 12.0
+background_opacity 0.9
+cursor_shape beam
+map ctrl+shift+enter new_window_with_cwd
+
+#: Color scheme {{{
+foreground   #dddddd
+background	#000000
+cursor #cccccc
+cursor_text_color background
+selection_foreground none
+selection_background #fffacd
+# color1 #123456
+color1 #cc0403
+color9 #f2201f
+tab_bar_background #333333
+mark1_foreground black
+#: }}}
+
+foreground #eeeeee
+`
+
+func TestFromString_UserConfig(t *testing.T) {
+	var scheme kitty.KittyScheme
+	if err := scheme.FromString(userConfig); err != nil {
+		t.Fatalf("FromString failed: %v", err)
+	}
+
+	checks := map[string]*kitty.Color{
+		"#eeeeee": scheme.Foreground, // The last setting wins
+		"#000000": scheme.Background,
+		"#cccccc": scheme.Cursor,
+		"#fffacd": scheme.SelectionBackground,
+		"#cc0403": scheme.Color1,
+		"#f2201f": scheme.Color9,
+	}
+	for want, c := range checks {
+		if c.Hex() != want {
+			t.Errorf("expected %s, got %s", want, c.Hex())
+		}
+	}
+	if scheme.CursorText != nil || scheme.SelectionForeground != nil {
+		t.Errorf("expected special values to leave their colors unset")
+	}
+
+	wantExtra := map[string]string{
+		"cursor_text_color":    "background",
+		"selection_foreground": "none",
+		"tab_bar_background":   "#333333",
+		"mark1_foreground":     "black",
+	}
+	if !reflect.DeepEqual(scheme.Extra, wantExtra) {
+		t.Errorf("expected extra colors %v, got %v", wantExtra, scheme.Extra)
+	}
+}
+
+// Rendering the fixture and parsing it back must give the same scheme,
+// including the colors without an abstract counterpart
+func TestRoundTrip(t *testing.T) {
+	var scheme kitty.KittyScheme
+	adaptertest.Load(t, adaptertest.Theme("kitty.conf"), &scheme)
+	if scheme.ThemeName == nil || *scheme.ThemeName != "Tokyo Night" {
+		t.Errorf("expected the theme name, got %v", scheme.ThemeName)
+	}
+	if scheme.Extra["active_border_color"] != "#7aa2f7" || scheme.Extra["color16"] != "#ff9e64" {
+		t.Errorf("expected extra colors to be kept, got %v", scheme.Extra)
+	}
+
+	adaptertest.AssertRoundTrip(t, &scheme)
+}
+
+// A name holding a line break must not add a setting of its own
+func TestRender_EscapesHeader(t *testing.T) {
+	name := "Night\nbackground #ff0000"
+	reparsed, rendered := adaptertest.RoundTrip(t, &kitty.KittyScheme{ThemeName: &name})
+	if reparsed.Background != nil {
+		t.Errorf("name was written as a setting:\n%s", rendered)
+	}
+	if reparsed.ThemeName == nil || *reparsed.ThemeName != "Night background #ff0000" {
+		t.Errorf("expected the name on a single line, got %v", reparsed.ThemeName)
+	}
+}
+
+// Extra colors can't be converted, and must show up in the report
+func TestConvert_ReportsExtraColors(t *testing.T) {
+	input := adaptertest.Load(t, adaptertest.Theme("kitty.conf"), &kitty.KittyScheme{})
+
+	_, report, err := adapter.ConvertTheme(input, &kitty.KittyScheme{}, &kitty.KittyScheme{})
+	if err != nil {
+		t.Fatalf("ConvertTheme failed: %v", err)
+	}
+	dropped := make(map[string]bool)
+	for _, entry := range report.Dropped {
+		dropped[entry.Path] = true
+	}
+	for _, field := range []string{"Extra.active_border_color", "Extra.inactive_tab_background", "Extra.color16"} {
+		if !dropped[field] {
+			t.Errorf("expected %s to be reported as dropped, got %v", field, report.Dropped)
+		}
+	}
+	if strings.Contains(report.String(), "Extra.tab_bar_background") {
+		t.Errorf("commented out settings must not be read")
+	}
+}
@@ -0,0 +1,531 @@
+package adapter
+
+//go:generate go run ./mapgen -o mappings_gen.go -native native/scheme_gen.go
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/objectmap"
+)
+
+// Typed mappings between the adapters and the abstract scheme, set by the
+// generated mappings_gen.go. They fill the report, if there is one, the way
+// the reflective mappings do, and return false for adapters without generated
+// code, which ToAbstract and FromAbstract map by reflection instead. The tree
+// builds without the file, so a stale one that no longer compiles can be
+// deleted and generated again.
+//
+// The mappings are functions of package adapter rather than ToAbstract and
+// FromAbstract methods of each adapter: the adapter packages can't import
+// this one, which imports them, so their methods couldn't name AbstractScheme
+// or ConversionReport.
+var (
+	generatedToAbstract   func(reader Adapter, s *AbstractScheme, r *ConversionReport) (bool, error)
+	generatedFromAbstract func(s *AbstractScheme, writer Adapter, r *ConversionReport) (bool, error)
+)
+
+func toAbstractGenerated(reader Adapter, s *AbstractScheme, r *ConversionReport) (bool, error) {
+	if generatedToAbstract == nil {
+		return false, nil
+	}
+	return generatedToAbstract(reader, s, r)
+}
+
+func fromAbstractGenerated(s *AbstractScheme, writer Adapter, r *ConversionReport) (bool, error) {
+	if generatedFromAbstract == nil {
+		return false, nil
+	}
+	return generatedFromAbstract(s, writer, r)
+}
+
+// transformColor runs the pipeline of a tag on a color for the generated
+// mappings. Transforms may have been removed since the code was generated, so
+// it can still fail.
+func transformColor(c *Color, path, pipeline string, inverse bool) (*Color, error) {
+	transformed, err := objectmap.ApplyPipeline(pipeline, c, inverse)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return transformed, nil
+}
+
+// GenerateMappings returns the source of mappings_gen.go: typed functions
+// mapping every registered adapter onto the abstract scheme and back, and
+// filling the conversion report, which ToAbstract and FromAbstract run in
+// place of objectmap's reflection. Tags naming a path the abstract scheme
+// doesn't have, or a field of another type, are an error here rather than a
+// field left unused at runtime.
+func GenerateMappings() ([]byte, error) {
+	var types []reflect.Type
+	for _, ad := range Adapters {
+		typ := reflect.TypeOf(ad).Elem()
+		if !containsType(types, typ) {
+			types = append(types, typ)
+		}
+	}
+	return generateMappings(types)
+}
+
+// GenerateNativeScheme returns the source of native/scheme_gen.go: the types
+// of paletteport's own format, which mirror the abstract scheme field for
+// field under the same names, with NativeScheme in place of AbstractScheme
+func GenerateNativeScheme() ([]byte, error) {
+	var out bytes.Buffer
+	out.WriteString("// Code generated by mapgen from the abstract scheme. DO NOT EDIT.\n\npackage native\n")
+	out.WriteString("\n// NativeScheme mirrors the abstract scheme field for field, so every field\n// maps onto the abstract field of the same name\n")
+	if err := nativeStruct(&out, "NativeScheme", reflect.TypeOf(AbstractScheme{}), map[reflect.Type]bool{}); err != nil {
+		return nil, err
+	}
+	return format.Source(out.Bytes())
+}
+
+// nativeStruct writes the native type of a struct of the abstract scheme,
+// followed by those of the structs it holds
+func nativeStruct(w *bytes.Buffer, name string, typ reflect.Type, seen map[reflect.Type]bool) error {
+	seen[typ] = true
+	var nested []reflect.Type
+	fmt.Fprintf(w, "type %s struct {\n", name)
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		var fieldType string
+		switch {
+		case f.Type.Kind() == reflect.Struct:
+			fieldType = f.Type.Name()
+			if !seen[f.Type] {
+				seen[f.Type] = true
+				nested = append(nested, f.Type)
+			}
+		case f.Type == reflect.TypeOf((*Color)(nil)):
+			fieldType = "*Color"
+		case f.Type.Kind() == reflect.Ptr && f.Type.Elem().PkgPath() == "":
+			fieldType = f.Type.String()
+		default:
+			return fmt.Errorf("%s.%s: no native type for a %s", typ.Name(), f.Name, f.Type)
+		}
+		fmt.Fprintf(w, "%s %s\n", f.Name, fieldType)
+	}
+	w.WriteString("}\n")
+
+	for _, t := range nested {
+		w.WriteString("\n")
+		if err := nativeStruct(w, t.Name(), t, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsType(types []reflect.Type, typ reflect.Type) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// mappingGen writes the mappings of adapter struct types
+type mappingGen struct {
+	abstract  reflect.Type
+	imports   map[string]bool
+	funcs     bytes.Buffer
+	pipelines bool // The function being written runs a pipeline, which can fail
+
+	// The report statements of the function being written, run after the
+	// mapping when there is a report
+	report *bytes.Buffer
+	// Conditions under which the writer field being written is filled at
+	// all, from the pointers to structs holding it
+	guards []string
+	// Conditions under which each abstract field is the source of a writer
+	// field, by path, an empty condition meaning always
+	winners map[string][]string
+}
+
+func generateMappings(types []reflect.Type) ([]byte, error) {
+	g := &mappingGen{abstract: reflect.TypeOf(AbstractScheme{}), imports: map[string]bool{}}
+	var into, from bytes.Buffer
+	for _, typ := range types {
+		if typ.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%s: adapters must be structs", typ)
+		}
+		g.imports[typ.PkgPath()] = true
+		name := typ.Name()
+
+		if err := g.function(fmt.Sprintf("toAbstract%s(rw *%s, s *AbstractScheme, r *ConversionReport)", name, typ), typ, g.into); err != nil {
+			return nil, fmt.Errorf("%s: %w", typ, err)
+		}
+		fmt.Fprintf(&into, "case *%s:\nreturn true, toAbstract%s(rw, s, r)\n", typ, name)
+
+		if err := g.function(fmt.Sprintf("fromAbstract%s(s *AbstractScheme, rw *%s, r *ConversionReport)", name, typ), typ, g.from); err != nil {
+			return nil, fmt.Errorf("%s: %w", typ, err)
+		}
+		fmt.Fprintf(&from, "case *%s:\nreturn true, fromAbstract%s(s, rw, r)\n", typ, name)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by mapgen from the abstract tags of the adapters. DO NOT EDIT.\n\npackage adapter\n\nimport (\n")
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	for _, path := range imports {
+		fmt.Fprintf(&out, "%q\n", path)
+	}
+	out.WriteString(")\n\nfunc init() {\ngeneratedToAbstract = func(reader Adapter, s *AbstractScheme, r *ConversionReport) (bool, error) {\nswitch rw := reader.(type) {\n")
+	out.Write(into.Bytes())
+	out.WriteString("}\nreturn false, nil\n}\ngeneratedFromAbstract = func(s *AbstractScheme, writer Adapter, r *ConversionReport) (bool, error) {\nswitch rw := writer.(type) {\n")
+	out.Write(from.Bytes())
+	out.WriteString("}\nreturn false, nil\n}\n}\n")
+	out.Write(g.funcs.Bytes())
+	return format.Source(out.Bytes())
+}
+
+// function writes a mapping function with the given signature, its body
+// written by fn, followed by the statements filling the report
+func (g *mappingGen) function(signature string, typ reflect.Type, fn func(*bytes.Buffer, reflect.Type, string, []string) error) error {
+	var body bytes.Buffer
+	g.pipelines = false
+	g.report = &bytes.Buffer{}
+	g.winners = nil
+	if err := fn(&body, typ, "rw", nil); err != nil {
+		return err
+	}
+	if g.winners != nil {
+		// Abstract fields no writer field took its value from
+		g.unused(g.abstract, nil)
+	}
+
+	fmt.Fprintf(&g.funcs, "\nfunc %s error {\n", signature)
+	if g.pipelines {
+		g.funcs.WriteString("var err error\n")
+	}
+	g.funcs.Write(body.Bytes())
+	if g.report.Len() > 0 {
+		fmt.Fprintf(&g.funcs, "if r == nil {\nreturn nil\n}\n%s", g.report.String())
+	}
+	g.funcs.WriteString("return nil\n}\n")
+	return nil
+}
+
+// assign writes the statement setting dst to src, run through the pipeline
+// if there is one. The path names the adapter field in errors.
+func (g *mappingGen) assign(w *bytes.Buffer, dst, src, pipeline string, inverse bool, path []string) {
+	if pipeline == "" {
+		fmt.Fprintf(w, "%s = %s\n", dst, src)
+		return
+	}
+	g.pipelines = true
+	fmt.Fprintf(w, "if %s, err = transformColor(%s, %q, %q, %t); err != nil {\nreturn err\n}\n",
+		dst, src, strings.Join(path, "."), pipeline, inverse)
+}
+
+// abstractField is a path of the abstract scheme a tag names
+type abstractField struct {
+	path     string
+	expr     string // Selector of the field on s
+	typ      reflect.Type
+	pipeline string
+}
+
+// sources returns the abstract fields the adapter field maps to or from. An
+// untagged field maps to the abstract field of the same name if there is one
+// of a compatible type, while every path of a tag must name one.
+func (g *mappingGen) sources(f reflect.StructField, path []string, compatible func(abstract reflect.Type) bool) ([]abstractField, error) {
+	tag, tagged := f.Tag.Lookup(abstractTag)
+	tagged = tagged && tag != ""
+	if !f.IsExported() {
+		if tagged {
+			return nil, fmt.Errorf("%s: tagged field is unexported", strings.Join(path, "."))
+		}
+		return nil, nil
+	}
+
+	candidates := []objectmap.TagSource{{Path: path}}
+	if tagged {
+		var err error
+		if candidates, err = objectmap.ParseTag(tag); err != nil {
+			return nil, fmt.Errorf("%s: %w", strings.Join(path, "."), err)
+		}
+	}
+	var fields []abstractField
+	for _, c := range candidates {
+		field, ok := g.lookup(c.Path)
+		if ok && !compatible(field.typ) {
+			if tagged {
+				return nil, fmt.Errorf("%s: abstract field %s is a %s, not a %s",
+					strings.Join(path, "."), strings.Join(c.Path, "."), field.typ, f.Type)
+			}
+			ok = false
+		}
+		if !ok {
+			if tagged {
+				return nil, fmt.Errorf("%s: abstract scheme has no field %s", strings.Join(path, "."), strings.Join(c.Path, "."))
+			}
+			continue
+		}
+		if c.Pipeline != "" {
+			if err := checkPipeline(f.Type, c.Pipeline); err != nil {
+				return nil, fmt.Errorf("%s: %w", strings.Join(path, "."), err)
+			}
+			field.pipeline = c.Pipeline
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// lookup returns the abstract field at the path, which may only run through
+// exported struct fields
+func (g *mappingGen) lookup(path []string) (abstractField, bool) {
+	if len(path) == 0 {
+		return abstractField{}, false
+	}
+	curr := g.abstract
+	for i, part := range path {
+		if curr.Kind() != reflect.Struct {
+			return abstractField{}, false
+		}
+		f, ok := curr.FieldByName(part)
+		if !ok || !f.IsExported() || len(f.Index) > 1 {
+			return abstractField{}, false
+		}
+		if i < len(path)-1 && f.Type.Kind() != reflect.Struct {
+			return abstractField{}, false
+		}
+		curr = f.Type
+	}
+	return abstractField{path: strings.Join(path, "."), expr: "s." + strings.Join(path, "."), typ: curr}, true
+}
+
+// checkPipeline makes sure a pipeline parses and is on a color field, which is
+// all transformColor handles
+func checkPipeline(typ reflect.Type, pipeline string) error {
+	if typ != reflect.TypeOf((*Color)(nil)) {
+		return fmt.Errorf("pipeline %q on a %s rather than a color", pipeline, typ)
+	}
+	_, err := objectmap.ApplyPipeline(pipeline, nil, false)
+	return err
+}
+
+// into writes the statements filling the abstract scheme from the fields of
+// the struct type held at expr, like objectmap.MapInto: every field sets each
+// abstract field its tag names, and fields mapping to none are descended into,
+// or reported as dropped if they hold a single setting
+func (g *mappingGen) into(w *bytes.Buffer, typ reflect.Type, expr string, path []string) error {
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		fieldPath := append(append([]string(nil), path...), f.Name)
+		fieldExpr := expr + "." + f.Name
+		fields, err := g.sources(f, fieldPath, func(abstract reflect.Type) bool {
+			return f.Type.AssignableTo(abstract)
+		})
+		if err != nil {
+			return err
+		}
+		for _, field := range fields {
+			g.assign(w, field.expr, fieldExpr, field.pipeline, true, fieldPath)
+		}
+		if len(fields) > 0 || !f.IsExported() {
+			continue
+		}
+		if isLeafType(f.Type) {
+			fmt.Fprintf(g.report, "r.recordDropped(%q, %s)\n", strings.Join(fieldPath, "."), fieldExpr)
+		} else if err := g.descend(w, f, fieldExpr, fieldPath, g.into); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// from writes the statements filling the fields of the struct type held at
+// expr from the abstract scheme, like objectmap.MapFrom: every field takes the
+// first abstract field its tag names that has a value, and fields mapping
+// from none are descended into, or reported if left empty
+func (g *mappingGen) from(w *bytes.Buffer, typ reflect.Type, expr string, path []string) error {
+	if g.winners == nil {
+		g.winners = make(map[string][]string)
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		fieldPath := append(append([]string(nil), path...), f.Name)
+		fieldExpr := expr + "." + f.Name
+		fields, err := g.sources(f, fieldPath, func(abstract reflect.Type) bool {
+			return abstract.AssignableTo(f.Type)
+		})
+		if err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			if !f.IsExported() {
+				continue
+			}
+			if isLeafType(f.Type) {
+				fmt.Fprintf(g.report, "r.recordUnwritten(%q, %s)\n", strings.Join(fieldPath, "."), fieldExpr)
+			} else if err := g.descend(w, f, fieldExpr, fieldPath, g.from); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Only pointers and the like can lack a value, others take the first
+		if !nillable(fields[0].typ) {
+			fields = fields[:1]
+		}
+		var unset []string // The earlier fields, each of which must be nil
+		for j, field := range fields {
+			if j > 0 {
+				w.WriteString("} else ")
+				g.report.WriteString("} else ")
+			}
+			if j < len(fields)-1 {
+				fmt.Fprintf(w, "if %s != nil {\n", field.expr)
+				fmt.Fprintf(g.report, "if %s != nil {\n", field.expr)
+			} else if j > 0 {
+				w.WriteString("{\n")
+				g.report.WriteString("{\n")
+			}
+			g.assign(w, fieldExpr, field.expr, field.pipeline, false, fieldPath)
+			g.written(f.Type, fieldExpr, fieldPath, field.path, j > 0)
+			g.markWinner(field, strings.Join(append(append([]string(nil), g.guards...), unset...), " && "))
+			unset = append(unset, field.expr+" == nil")
+		}
+		if len(fields) > 1 {
+			w.WriteString("}\n")
+			g.report.WriteString("}\n")
+		}
+	}
+	return nil
+}
+
+// written writes the report statement of a writer field filled from the
+// abstract field at source, which is an alternate if it isn't the first its
+// tag names. Filled structs have their fields reported if left empty.
+func (g *mappingGen) written(typ reflect.Type, expr string, path []string, source string, alternate bool) {
+	if isLeafType(typ) {
+		fmt.Fprintf(g.report, "r.recordWritten(%q, %s, %q, %t)\n", strings.Join(path, "."), expr, source, alternate)
+		return
+	}
+	if typ.Kind() == reflect.Ptr {
+		fmt.Fprintf(g.report, "if %s != nil {\n", expr)
+		defer g.report.WriteString("}\n")
+		typ = typ.Elem()
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fieldPath := append(append([]string(nil), path...), f.Name)
+		if isLeafType(f.Type) {
+			fmt.Fprintf(g.report, "r.recordUnwritten(%q, %s.%s)\n", strings.Join(fieldPath, "."), expr, f.Name)
+		} else {
+			g.written(f.Type, expr+"."+f.Name, fieldPath, "", false)
+		}
+	}
+}
+
+// markWinner records that the abstract field, and every field inside it, is
+// the source of a writer field under the condition
+func (g *mappingGen) markWinner(field abstractField, cond string) {
+	var mark func(typ reflect.Type, path string)
+	mark = func(typ reflect.Type, path string) {
+		if isLeafType(typ) || typ.Kind() != reflect.Struct {
+			g.winners[path] = append(g.winners[path], cond)
+			return
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			mark(typ.Field(i).Type, path+"."+typ.Field(i).Name)
+		}
+	}
+	mark(field.typ, field.path)
+}
+
+// unused writes the report statements of the abstract fields inside the
+// struct type at path that no writer field took its value from
+func (g *mappingGen) unused(typ reflect.Type, path []string) {
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		fieldPath := append(append([]string(nil), path...), f.Name)
+		if !isLeafType(f.Type) {
+			if f.Type.Kind() == reflect.Struct {
+				g.unused(f.Type, fieldPath)
+			}
+			continue
+		}
+
+		pathStr := strings.Join(fieldPath, ".")
+		conds := g.winners[pathStr]
+		if slices.Contains(conds, "") {
+			continue // Always a source
+		}
+		record := fmt.Sprintf("r.recordUnused(%q, s.%s)\n", pathStr, pathStr)
+		if len(conds) == 0 {
+			g.report.WriteString(record)
+			continue
+		}
+		if len(conds) == 1 && !strings.Contains(conds[0], "&&") && strings.HasSuffix(conds[0], " == nil") {
+			// Unused while the field before it is set
+			fmt.Fprintf(g.report, "if %s {\n%s}\n", strings.TrimSuffix(conds[0], " == nil")+" != nil", record)
+			continue
+		}
+		for i, cond := range conds {
+			if len(conds) > 1 && strings.Contains(cond, "&&") {
+				conds[i] = "(" + cond + ")"
+			}
+		}
+		fmt.Fprintf(g.report, "if !(%s) {\n%s}\n", strings.Join(conds, " || "), record)
+	}
+}
+
+// descend writes the statements of the fields of a struct or pointer to
+// struct field, which are skipped while the pointer is nil
+func (g *mappingGen) descend(w *bytes.Buffer, f reflect.StructField, expr string, path []string, fn func(*bytes.Buffer, reflect.Type, string, []string) error) error {
+	switch {
+	case f.Type.Kind() == reflect.Struct:
+		return fn(w, f.Type, expr, path)
+	case f.Type.Kind() == reflect.Ptr && f.Type.Elem().Kind() == reflect.Struct:
+		var body bytes.Buffer
+		outer := g.report
+		g.report = &bytes.Buffer{}
+		g.guards = append(g.guards, expr+" != nil")
+		err := fn(&body, f.Type.Elem(), expr, path)
+		g.guards = g.guards[:len(g.guards)-1]
+		report := g.report
+		g.report = outer
+		if err != nil {
+			return err
+		}
+		if body.Len() > 0 {
+			fmt.Fprintf(w, "if %s != nil {\n%s}\n", expr, body.String())
+		}
+		if report.Len() > 0 {
+			fmt.Fprintf(g.report, "if %s != nil {\n%s}\n", expr, report.String())
+		}
+	}
+	return nil
+}
+
+// isLeafType reports whether fields of the type hold a single setting rather
+// than a group of settings, like isLeafField
+func isLeafType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() != reflect.Struct || isColor(t)
+}
+
+func nillable(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return true
+	}
+	return false
+}
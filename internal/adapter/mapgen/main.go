@@ -0,0 +1,44 @@
+// Command mapgen writes the typed mappings between the adapters and the
+// abstract scheme, generated from the adapters' abstract tags, along with the
+// types of paletteport's own format, generated from the abstract scheme:
+//
+//	go generate ./internal/adapter
+//
+// It builds the adapter package along with the files it last wrote, so if
+// a change to an adapter leaves them failing to compile, delete
+// mappings_gen.go and run it again. The mappings of the native format are
+// generated from its types as last written, so a change to the abstract
+// scheme takes two runs.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+)
+
+func main() {
+	output := flag.String("o", "mappings_gen.go", "file to write the mappings to")
+	native := flag.String("native", "", "file to write the types of the native format to, if any")
+	flag.Parse()
+
+	err := write(*output, adapter.GenerateMappings)
+	if err == nil && *native != "" {
+		err = write(*native, adapter.GenerateNativeScheme)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mapgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// write writes the source generated by gen to the file
+func write(file string, gen func() ([]byte, error)) error {
+	src, err := gen()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, src, 0o644)
+}
@@ -0,0 +1,184 @@
+package adapter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter/alacritty"
+	"github.com/da-luce/paletteport/internal/adapter/windows_terminal"
+)
+
+func TestGenerateMappings_UpToDate(t *testing.T) {
+	want, err := GenerateMappings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("mappings_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Fatal("mappings_gen.go is out of date, regenerate it with: go generate ./internal/adapter")
+	}
+}
+
+// The native format must hold every field the abstract scheme has
+func TestGenerateNativeScheme_UpToDate(t *testing.T) {
+	want, err := GenerateNativeScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join("native", "scheme_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Fatal("native/scheme_gen.go is out of date, regenerate it with: go generate ./internal/adapter")
+	}
+}
+
+// reflectively runs fn with the generated mappings out of the way
+func reflectively(fn func()) {
+	toAbstract, fromAbstract := generatedToAbstract, generatedFromAbstract
+	generatedToAbstract, generatedFromAbstract = nil, nil
+	defer func() { generatedToAbstract, generatedFromAbstract = toAbstract, fromAbstract }()
+	fn()
+}
+
+// The generated mappings must fill, and report, exactly what the reflective
+// ones do
+func TestGeneratedMappings_MatchReflection(t *testing.T) {
+	for _, ad := range Adapters {
+		t.Run(ad.Name(), func(t *testing.T) {
+			reader := newAdapterInstance(ad)
+			fillDummyScheme(reader)
+			wantReport, gotReport := newConversionReport(ad.Name(), ""), newConversionReport(ad.Name(), "")
+			var want *AbstractScheme
+			var err error
+			reflectively(func() { want, err = ToAbstract(reader, wantReport) })
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ToAbstract(reader, gotReport)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(gotReport, wantReport) {
+				t.Errorf("generated ToAbstract differs:\n got  %+v\n%+v\n want %+v\n%+v", *got, gotReport, *want, wantReport)
+			}
+
+			// Fields tagged with several paths fall back on the later ones
+			sparse := *want
+			sparse.SpecialColors.Foreground = nil
+			sparse.AnsiColors.Red = nil
+			for _, scheme := range []*AbstractScheme{want, &sparse} {
+				wantWriter, gotWriter := newAdapterInstance(ad), newAdapterInstance(ad)
+				wantReport, gotReport := newConversionReport("", ad.Name()), newConversionReport("", ad.Name())
+				for _, report := range []*ConversionReport{wantReport, gotReport} {
+					report.fallbacks["SpecialColors.Background"] = "AnsiColors.Black"
+					report.fallbacks["AnsiColors.BrightBlue"] = "AnsiColors.Blue"
+				}
+				reflectively(func() { err = FromAbstract(scheme, wantWriter, wantReport) })
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := FromAbstract(scheme, gotWriter, gotReport); err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(gotWriter, wantWriter) || !reflect.DeepEqual(gotReport, wantReport) {
+					t.Errorf("generated FromAbstract differs:\n got  %+v\n%+v\n want %+v\n%+v", gotWriter, gotReport, wantWriter, wantReport)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertTheme_Generated(t *testing.T) {
+	var readers, writers []string
+	toAbstract, fromAbstract := generatedToAbstract, generatedFromAbstract
+	defer func() { generatedToAbstract, generatedFromAbstract = toAbstract, fromAbstract }()
+	generatedToAbstract = func(reader Adapter, s *AbstractScheme, r *ConversionReport) (bool, error) {
+		readers = append(readers, reader.Name())
+		return toAbstract(reader, s, r)
+	}
+	generatedFromAbstract = func(s *AbstractScheme, writer Adapter, r *ConversionReport) (bool, error) {
+		writers = append(writers, writer.Name())
+		return fromAbstract(s, writer, r)
+	}
+
+	data, err := os.ReadFile(filepath.Join("..", "..", "themes", "wt.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ConvertTheme(string(data), &windows_terminal.WindowsTerminalScheme{}, &alacritty.AlacrittyScheme{}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(readers, []string{"wt"}) || !reflect.DeepEqual(writers, []string{"alacritty"}) {
+		t.Errorf("expected the generated mappings to convert wt to alacritty, got readers %v and writers %v", readers, writers)
+	}
+}
+
+func TestGenerateMappings_Invalid(t *testing.T) {
+	tests := []struct {
+		typ  any
+		want string
+	}{
+		{struct {
+			Bg *Color `abstract:"SpecialColors.Backgrund"`
+		}{}, "abstract scheme has no field SpecialColors.Backgrund"},
+		{struct {
+			Bg *Color `abstract:"SpecialColors.Background,AnsiColors.Blak"`
+		}{}, "abstract scheme has no field AnsiColors.Blak"},
+		{struct {
+			Name *Color `abstract:"Metadata.Name"`
+		}{}, "is a *string"},
+		{struct {
+			Bg *Color `abstract:"SpecialColors.Background|fade(0.5)"`
+		}{}, `unknown function "fade"`},
+		{struct {
+			Name *string `abstract:"Metadata.Name|alpha(0.5)"`
+		}{}, "rather than a color"},
+		{struct {
+			bg *Color `abstract:"SpecialColors.Background"`
+		}{}, "unexported"},
+	}
+	for _, tc := range tests {
+		_, err := generateMappings([]reflect.Type{reflect.TypeOf(tc.typ)})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("expected an error containing %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestGenerateMappings_Pipelines(t *testing.T) {
+	type translucent struct {
+		Selection *Color `abstract:"SpecialColors.Selection|alpha(0.3)"`
+	}
+	src, err := generateMappings([]reflect.Type{reflect.TypeOf(translucent{})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`if s.SpecialColors.Selection, err = transformColor(rw.Selection, "Selection", "alpha(0.3)", true); err != nil {`,
+		`if rw.Selection, err = transformColor(s.SpecialColors.Selection, "Selection", "alpha(0.3)", false); err != nil {`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("expected the mappings to contain %s, got\n%s", want, src)
+		}
+	}
+
+	c := mustHex(t, "#336699")
+	if got, err := transformColor(c, "Selection", "alpha(0.3)", false); err != nil || got.Alpha != 0.3 {
+		t.Errorf("expected alpha 0.3, got %v (%v)", got, err)
+	}
+	if got, err := transformColor(nil, "Selection", "alpha(0.3)", true); err != nil || got != nil {
+		t.Errorf("expected nil passed through, got %v (%v)", got, err)
+	}
+	// Transforms may be gone by the time the generated code runs
+	if _, err := transformColor(c, "Selection", "fade(0.3)", false); err == nil || !strings.Contains(err.Error(), "Selection") {
+		t.Errorf("expected an error naming the field for an unknown transform, got %v", err)
+	}
+}
@@ -0,0 +1,1749 @@
+// Code generated by mapgen from the abstract tags of the adapters. DO NOT EDIT.
+
+package adapter
+
+import (
+	"github.com/da-luce/paletteport/internal/adapter/alacritty"
+	"github.com/da-luce/paletteport/internal/adapter/base16"
+	"github.com/da-luce/paletteport/internal/adapter/gogh"
+	"github.com/da-luce/paletteport/internal/adapter/iterm"
+	"github.com/da-luce/paletteport/internal/adapter/kitty"
+	"github.com/da-luce/paletteport/internal/adapter/native"
+	"github.com/da-luce/paletteport/internal/adapter/terminator"
+	"github.com/da-luce/paletteport/internal/adapter/vscode"
+	"github.com/da-luce/paletteport/internal/adapter/windows_terminal"
+)
+
+func init() {
+	generatedToAbstract = func(reader Adapter, s *AbstractScheme, r *ConversionReport) (bool, error) {
+		switch rw := reader.(type) {
+		case *base16.Base16Scheme:
+			return true, toAbstractBase16Scheme(rw, s, r)
+		case *alacritty.AlacrittyScheme:
+			return true, toAbstractAlacrittyScheme(rw, s, r)
+		case *gogh.GoghScheme:
+			return true, toAbstractGoghScheme(rw, s, r)
+		case *iterm.ItermScheme:
+			return true, toAbstractItermScheme(rw, s, r)
+		case *windows_terminal.WindowsTerminalScheme:
+			return true, toAbstractWindowsTerminalScheme(rw, s, r)
+		case *vscode.VSCodeTheme:
+			return true, toAbstractVSCodeTheme(rw, s, r)
+		case *kitty.KittyScheme:
+			return true, toAbstractKittyScheme(rw, s, r)
+		case *terminator.TerminatorScheme:
+			return true, toAbstractTerminatorScheme(rw, s, r)
+		case *native.NativeScheme:
+			return true, toAbstractNativeScheme(rw, s, r)
+		case *native.NativeJSONScheme:
+			return true, toAbstractNativeJSONScheme(rw, s, r)
+		case *native.NativeTOMLScheme:
+			return true, toAbstractNativeTOMLScheme(rw, s, r)
+		}
+		return false, nil
+	}
+	generatedFromAbstract = func(s *AbstractScheme, writer Adapter, r *ConversionReport) (bool, error) {
+		switch rw := writer.(type) {
+		case *base16.Base16Scheme:
+			return true, fromAbstractBase16Scheme(s, rw, r)
+		case *alacritty.AlacrittyScheme:
+			return true, fromAbstractAlacrittyScheme(s, rw, r)
+		case *gogh.GoghScheme:
+			return true, fromAbstractGoghScheme(s, rw, r)
+		case *iterm.ItermScheme:
+			return true, fromAbstractItermScheme(s, rw, r)
+		case *windows_terminal.WindowsTerminalScheme:
+			return true, fromAbstractWindowsTerminalScheme(s, rw, r)
+		case *vscode.VSCodeTheme:
+			return true, fromAbstractVSCodeTheme(s, rw, r)
+		case *kitty.KittyScheme:
+			return true, fromAbstractKittyScheme(s, rw, r)
+		case *terminator.TerminatorScheme:
+			return true, fromAbstractTerminatorScheme(s, rw, r)
+		case *native.NativeScheme:
+			return true, fromAbstractNativeScheme(s, rw, r)
+		case *native.NativeJSONScheme:
+			return true, fromAbstractNativeJSONScheme(s, rw, r)
+		case *native.NativeTOMLScheme:
+			return true, fromAbstractNativeTOMLScheme(s, rw, r)
+		}
+		return false, nil
+	}
+}
+
+func toAbstractBase16Scheme(rw *base16.Base16Scheme, s *AbstractScheme, r *ConversionReport) error {
+	s.Metadata.Name = rw.Scheme
+	s.Metadata.Author = rw.Author
+	s.SpecialColors.Background = rw.Base00
+	s.ScopeColors.Editor.CursorLine = rw.Base01
+	s.SpecialColors.Selection = rw.Base02
+	s.SpecialColors.CursorText = rw.Base03
+	s.SpecialColors.SelectedText = rw.Base04
+	s.SpecialColors.Foreground = rw.Base05
+	s.SpecialColors.Cursor = rw.Base05
+	s.SpecialColors.ForegroundBright = rw.Base06
+	s.AnsiColors.White = rw.Base07
+	s.AnsiColors.Red = rw.Base08
+	s.AnsiColors.Yellow = rw.Base09
+	s.AnsiColors.Blue = rw.Base0A
+	s.AnsiColors.Green = rw.Base0B
+	s.AnsiColors.Cyan = rw.Base0C
+	s.AnsiColors.BrightBlue = rw.Base0D
+	s.AnsiColors.Magenta = rw.Base0E
+	s.AnsiColors.BrightMagenta = rw.Base0F
+	return nil
+}
+
+func fromAbstractBase16Scheme(s *AbstractScheme, rw *base16.Base16Scheme, r *ConversionReport) error {
+	rw.Scheme = s.Metadata.Name
+	rw.Author = s.Metadata.Author
+	rw.Base00 = s.SpecialColors.Background
+	rw.Base01 = s.ScopeColors.Editor.CursorLine
+	rw.Base02 = s.SpecialColors.Selection
+	rw.Base03 = s.SpecialColors.CursorText
+	rw.Base04 = s.SpecialColors.SelectedText
+	if s.SpecialColors.Foreground != nil {
+		rw.Base05 = s.SpecialColors.Foreground
+	} else {
+		rw.Base05 = s.SpecialColors.Cursor
+	}
+	rw.Base06 = s.SpecialColors.ForegroundBright
+	rw.Base07 = s.AnsiColors.White
+	rw.Base08 = s.AnsiColors.Red
+	rw.Base09 = s.AnsiColors.Yellow
+	rw.Base0A = s.AnsiColors.Blue
+	rw.Base0B = s.AnsiColors.Green
+	rw.Base0C = s.AnsiColors.Cyan
+	rw.Base0D = s.AnsiColors.BrightBlue
+	rw.Base0E = s.AnsiColors.Magenta
+	rw.Base0F = s.AnsiColors.BrightMagenta
+	if r == nil {
+		return nil
+	}
+	r.recordWritten("Scheme", rw.Scheme, "Metadata.Name", false)
+	r.recordWritten("Author", rw.Author, "Metadata.Author", false)
+	r.recordWritten("Base00", rw.Base00, "SpecialColors.Background", false)
+	r.recordWritten("Base01", rw.Base01, "ScopeColors.Editor.CursorLine", false)
+	r.recordWritten("Base02", rw.Base02, "SpecialColors.Selection", false)
+	r.recordWritten("Base03", rw.Base03, "SpecialColors.CursorText", false)
+	r.recordWritten("Base04", rw.Base04, "SpecialColors.SelectedText", false)
+	if s.SpecialColors.Foreground != nil {
+		r.recordWritten("Base05", rw.Base05, "SpecialColors.Foreground", false)
+	} else {
+		r.recordWritten("Base05", rw.Base05, "SpecialColors.Cursor", true)
+	}
+	r.recordWritten("Base06", rw.Base06, "SpecialColors.ForegroundBright", false)
+	r.recordWritten("Base07", rw.Base07, "AnsiColors.White", false)
+	r.recordWritten("Base08", rw.Base08, "AnsiColors.Red", false)
+	r.recordWritten("Base09", rw.Base09, "AnsiColors.Yellow", false)
+	r.recordWritten("Base0A", rw.Base0A, "AnsiColors.Blue", false)
+	r.recordWritten("Base0B", rw.Base0B, "AnsiColors.Green", false)
+	r.recordWritten("Base0C", rw.Base0C, "AnsiColors.Cyan", false)
+	r.recordWritten("Base0D", rw.Base0D, "AnsiColors.BrightBlue", false)
+	r.recordWritten("Base0E", rw.Base0E, "AnsiColors.Magenta", false)
+	r.recordWritten("Base0F", rw.Base0F, "AnsiColors.BrightMagenta", false)
+	r.recordUnused("Metadata.Date", s.Metadata.Date)
+	r.recordUnused("ScopeColors.Basic.Comment", s.ScopeColors.Basic.Comment)
+	r.recordUnused("ScopeColors.Basic.Keyword", s.ScopeColors.Basic.Keyword)
+	r.recordUnused("ScopeColors.Basic.Constant", s.ScopeColors.Basic.Constant)
+	r.recordUnused("ScopeColors.Basic.String", s.ScopeColors.Basic.String)
+	r.recordUnused("ScopeColors.Basic.Number", s.ScopeColors.Basic.Number)
+	r.recordUnused("ScopeColors.Basic.Function", s.ScopeColors.Basic.Function)
+	r.recordUnused("ScopeColors.Basic.Variable", s.ScopeColors.Basic.Variable)
+	r.recordUnused("ScopeColors.Basic.Operator", s.ScopeColors.Basic.Operator)
+	r.recordUnused("ScopeColors.Advanced.Class", s.ScopeColors.Advanced.Class)
+	r.recordUnused("ScopeColors.Advanced.Type", s.ScopeColors.Advanced.Type)
+	r.recordUnused("ScopeColors.Advanced.Property", s.ScopeColors.Advanced.Property)
+	r.recordUnused("ScopeColors.Advanced.Attribute", s.ScopeColors.Advanced.Attribute)
+	r.recordUnused("ScopeColors.Advanced.Tag", s.ScopeColors.Advanced.Tag)
+	r.recordUnused("ScopeColors.Advanced.Namespace", s.ScopeColors.Advanced.Namespace)
+	r.recordUnused("ScopeColors.Advanced.Parameter", s.ScopeColors.Advanced.Parameter)
+	r.recordUnused("ScopeColors.Advanced.Selector", s.ScopeColors.Advanced.Selector)
+	r.recordUnused("ScopeColors.Markup.Heading", s.ScopeColors.Markup.Heading)
+	r.recordUnused("ScopeColors.Markup.Bold", s.ScopeColors.Markup.Bold)
+	r.recordUnused("ScopeColors.Markup.Italic", s.ScopeColors.Markup.Italic)
+	r.recordUnused("ScopeColors.Markup.Underline", s.ScopeColors.Markup.Underline)
+	r.recordUnused("ScopeColors.Markup.Link", s.ScopeColors.Markup.Link)
+	r.recordUnused("ScopeColors.Markup.Quote", s.ScopeColors.Markup.Quote)
+	r.recordUnused("ScopeColors.Markup.List", s.ScopeColors.Markup.List)
+	r.recordUnused("ScopeColors.Markup.CodeBlock", s.ScopeColors.Markup.CodeBlock)
+	r.recordUnused("ScopeColors.Markup.RawText", s.ScopeColors.Markup.RawText)
+	r.recordUnused("ScopeColors.Markup.TemplateTag", s.ScopeColors.Markup.TemplateTag)
+	r.recordUnused("ScopeColors.Diagnostics.Invalid", s.ScopeColors.Diagnostics.Invalid)
+	r.recordUnused("ScopeColors.Diagnostics.Deprecated", s.ScopeColors.Diagnostics.Deprecated)
+	r.recordUnused("ScopeColors.Editor.Cursor", s.ScopeColors.Editor.Cursor)
+	r.recordUnused("ScopeColors.Editor.LineNumbers", s.ScopeColors.Editor.LineNumbers)
+	r.recordUnused("ScopeColors.Editor.Highlight", s.ScopeColors.Editor.Highlight)
+	r.recordUnused("ScopeColors.Miscellaneous.Meta", s.ScopeColors.Miscellaneous.Meta)
+	r.recordUnused("ScopeColors.Miscellaneous.Annotation", s.ScopeColors.Miscellaneous.Annotation)
+	r.recordUnused("ScopeColors.Miscellaneous.Regex", s.ScopeColors.Miscellaneous.Regex)
+	r.recordUnused("ScopeColors.Miscellaneous.Background", s.ScopeColors.Miscellaneous.Background)
+	r.recordUnused("ScopeColors.Miscellaneous.Foreground", s.ScopeColors.Miscellaneous.Foreground)
+	r.recordUnused("AnsiColors.Black", s.AnsiColors.Black)
+	r.recordUnused("AnsiColors.BrightBlack", s.AnsiColors.BrightBlack)
+	r.recordUnused("AnsiColors.BrightRed", s.AnsiColors.BrightRed)
+	r.recordUnused("AnsiColors.BrightGreen", s.AnsiColors.BrightGreen)
+	r.recordUnused("AnsiColors.BrightYellow", s.AnsiColors.BrightYellow)
+	r.recordUnused("AnsiColors.BrightCyan", s.AnsiColors.BrightCyan)
+	r.recordUnused("AnsiColors.BrightWhite", s.AnsiColors.BrightWhite)
+	if s.SpecialColors.Foreground != nil {
+		r.recordUnused("SpecialColors.Cursor", s.SpecialColors.Cursor)
+	}
+	r.recordUnused("SpecialColors.Links", s.SpecialColors.Links)
+	r.recordUnused("SpecialColors.FindMatch", s.SpecialColors.FindMatch)
+	return nil
+}
+
+func toAbstractAlacrittyScheme(rw *alacritty.AlacrittyScheme, s *AbstractScheme, r *ConversionReport) error {
+	s.SpecialColors.Background = rw.Colors.Primary.Background
+	s.SpecialColors.Foreground = rw.Colors.Primary.Foreground
+	s.SpecialColors.Cursor = rw.Colors.Cursor.Cursor
+	s.SpecialColors.CursorText = rw.Colors.Cursor.Text
+	s.AnsiColors.Black = rw.Colors.Normal.Black
+	s.AnsiColors.Blue = rw.Colors.Normal.Blue
+	s.AnsiColors.Cyan = rw.Colors.Normal.Cyan
+	s.AnsiColors.Green = rw.Colors.Normal.Green
+	s.AnsiColors.Magenta = rw.Colors.Normal.Magenta
+	s.AnsiColors.Red = rw.Colors.Normal.Red
+	s.AnsiColors.White = rw.Colors.Normal.White
+	s.AnsiColors.Yellow = rw.Colors.Normal.Yellow
+	s.AnsiColors.BrightBlack = rw.Colors.Bright.Black
+	s.AnsiColors.BrightBlue = rw.Colors.Bright.Blue
+	s.AnsiColors.BrightCyan = rw.Colors.Bright.Cyan
+	s.AnsiColors.BrightGreen = rw.Colors.Bright.Green
+	s.AnsiColors.BrightMagenta = rw.Colors.Bright.Magenta
+	s.AnsiColors.BrightRed = rw.Colors.Bright.Red
+	s.AnsiColors.BrightWhite = rw.Colors.Bright.White
+	s.AnsiColors.BrightYellow = rw.Colors.Bright.Yellow
+	s.SpecialColors.Selection = rw.Colors.Selection.Background
+	s.SpecialColors.SelectedText = rw.Colors.Selection.Text
+	return nil
+}
+
+func fromAbstractAlacrittyScheme(s *AbstractScheme, rw *alacritty.AlacrittyScheme, r *ConversionReport) error {
+	rw.Colors.Primary.Background = s.SpecialColors.Background
+	rw.Colors.Primary.Foreground = s.SpecialColors.Foreground
+	rw.Colors.Cursor.Cursor = s.SpecialColors.Cursor
+	rw.Colors.Cursor.Text = s.SpecialColors.CursorText
+	rw.Colors.Normal.Black = s.AnsiColors.Black
+	rw.Colors.Normal.Blue = s.AnsiColors.Blue
+	rw.Colors.Normal.Cyan = s.AnsiColors.Cyan
+	rw.Colors.Normal.Green = s.AnsiColors.Green
+	rw.Colors.Normal.Magenta = s.AnsiColors.Magenta
+	rw.Colors.Normal.Red = s.AnsiColors.Red
+	rw.Colors.Normal.White = s.AnsiColors.White
+	rw.Colors.Normal.Yellow = s.AnsiColors.Yellow
+	rw.Colors.Bright.Black = s.AnsiColors.BrightBlack
+	rw.Colors.Bright.Blue = s.AnsiColors.BrightBlue
+	rw.Colors.Bright.Cyan = s.AnsiColors.BrightCyan
+	rw.Colors.Bright.Green = s.AnsiColors.BrightGreen
+	rw.Colors.Bright.Magenta = s.AnsiColors.BrightMagenta
+	rw.Colors.Bright.Red = s.AnsiColors.BrightRed
+	rw.Colors.Bright.White = s.AnsiColors.BrightWhite
+	rw.Colors.Bright.Yellow = s.AnsiColors.BrightYellow
+	rw.Colors.Selection.Background = s.SpecialColors.Selection
+	rw.Colors.Selection.Text = s.SpecialColors.SelectedText
+	if r == nil {
+		return nil
+	}
+	r.recordWritten("Colors.Primary.Background", rw.Colors.Primary.Background, "SpecialColors.Background", false)
+	r.recordWritten("Colors.Primary.Foreground", rw.Colors.Primary.Foreground, "SpecialColors.Foreground", false)
+	r.recordWritten("Colors.Cursor.Cursor", rw.Colors.Cursor.Cursor, "SpecialColors.Cursor", false)
+	r.recordWritten("Colors.Cursor.Text", rw.Colors.Cursor.Text, "SpecialColors.CursorText", false)
+	r.recordWritten("Colors.Normal.Black", rw.Colors.Normal.Black, "AnsiColors.Black", false)
+	r.recordWritten("Colors.Normal.Blue", rw.Colors.Normal.Blue, "AnsiColors.Blue", false)
+	r.recordWritten("Colors.Normal.Cyan", rw.Colors.Normal.Cyan, "AnsiColors.Cyan", false)
+	r.recordWritten("Colors.Normal.Green", rw.Colors.Normal.Green, "AnsiColors.Green", false)
+	r.recordWritten("Colors.Normal.Magenta", rw.Colors.Normal.Magenta, "AnsiColors.Magenta", false)
+	r.recordWritten("Colors.Normal.Red", rw.Colors.Normal.Red, "AnsiColors.Red", false)
+	r.recordWritten("Colors.Normal.White", rw.Colors.Normal.White, "AnsiColors.White", false)
+	r.recordWritten("Colors.Normal.Yellow", rw.Colors.Normal.Yellow, "AnsiColors.Yellow", false)
+	r.recordWritten("Colors.Bright.Black", rw.Colors.Bright.Black, "AnsiColors.BrightBlack", false)
+	r.recordWritten("Colors.Bright.Blue", rw.Colors.Bright.Blue, "AnsiColors.BrightBlue", false)
+	r.recordWritten("Colors.Bright.Cyan", rw.Colors.Bright.Cyan, "AnsiColors.BrightCyan", false)
+	r.recordWritten("Colors.Bright.Green", rw.Colors.Bright.Green, "AnsiColors.BrightGreen", false)
+	r.recordWritten("Colors.Bright.Magenta", rw.Colors.Bright.Magenta, "AnsiColors.BrightMagenta", false)
+	r.recordWritten("Colors.Bright.Red", rw.Colors.Bright.Red, "AnsiColors.BrightRed", false)
+	r.recordWritten("Colors.Bright.White", rw.Colors.Bright.White, "AnsiColors.BrightWhite", false)
+	r.recordWritten("Colors.Bright.Yellow", rw.Colors.Bright.Yellow, "AnsiColors.BrightYellow", false)
+	r.recordWritten("Colors.Selection.Background", rw.Colors.Selection.Background, "SpecialColors.Selection", false)
+	r.recordWritten("Colors.Selection.Text", rw.Colors.Selection.Text, "SpecialColors.SelectedText", false)
+	r.recordUnused("Metadata.Name", s.Metadata.Name)
+	r.recordUnused("Metadata.Author", s.Metadata.Author)
+	r.recordUnused("Metadata.Date", s.Metadata.Date)
+	r.recordUnused("ScopeColors.Basic.Comment", s.ScopeColors.Basic.Comment)
+	r.recordUnused("ScopeColors.Basic.Keyword", s.ScopeColors.Basic.Keyword)
+	r.recordUnused("ScopeColors.Basic.Constant", s.ScopeColors.Basic.Constant)
+	r.recordUnused("ScopeColors.Basic.String", s.ScopeColors.Basic.String)
+	r.recordUnused("ScopeColors.Basic.Number", s.ScopeColors.Basic.Number)
+	r.recordUnused("ScopeColors.Basic.Function", s.ScopeColors.Basic.Function)
+	r.recordUnused("ScopeColors.Basic.Variable", s.ScopeColors.Basic.Variable)
+	r.recordUnused("ScopeColors.Basic.Operator", s.ScopeColors.Basic.Operator)
+	r.recordUnused("ScopeColors.Advanced.Class", s.ScopeColors.Advanced.Class)
+	r.recordUnused("ScopeColors.Advanced.Type", s.ScopeColors.Advanced.Type)
+	r.recordUnused("ScopeColors.Advanced.Property", s.ScopeColors.Advanced.Property)
+	r.recordUnused("ScopeColors.Advanced.Attribute", s.ScopeColors.Advanced.Attribute)
+	r.recordUnused("ScopeColors.Advanced.Tag", s.ScopeColors.Advanced.Tag)
+	r.recordUnused("ScopeColors.Advanced.Namespace", s.ScopeColors.Advanced.Namespace)
+	r.recordUnused("ScopeColors.Advanced.Parameter", s.ScopeColors.Advanced.Parameter)
+	r.recordUnused("ScopeColors.Advanced.Selector", s.ScopeColors.Advanced.Selector)
+	r.recordUnused("ScopeColors.Markup.Heading", s.ScopeColors.Markup.Heading)
+	r.recordUnused("ScopeColors.Markup.Bold", s.ScopeColors.Markup.Bold)
+	r.recordUnused("ScopeColors.Markup.Italic", s.ScopeColors.Markup.Italic)
+	r.recordUnused("ScopeColors.Markup.Underline", s.ScopeColors.Markup.Underline)
+	r.recordUnused("ScopeColors.Markup.Link", s.ScopeColors.Markup.Link)
+	r.recordUnused("ScopeColors.Markup.Quote", s.ScopeColors.Markup.Quote)
+	r.recordUnused("ScopeColors.Markup.List", s.ScopeColors.Markup.List)
+	r.recordUnused("ScopeColors.Markup.CodeBlock", s.ScopeColors.Markup.CodeBlock)
+	r.recordUnused("ScopeColors.Markup.RawText", s.ScopeColors.Markup.RawText)
+	r.recordUnused("ScopeColors.Markup.TemplateTag", s.ScopeColors.Markup.TemplateTag)
+	r.recordUnused("ScopeColors.Diagnostics.Invalid", s.ScopeColors.Diagnostics.Invalid)
+	r.recordUnused("ScopeColors.Diagnostics.Deprecated", s.ScopeColors.Diagnostics.Deprecated)
+	r.recordUnused("ScopeColors.Editor.Cursor", s.ScopeColors.Editor.Cursor)
+	r.recordUnused("ScopeColors.Editor.CursorLine", s.ScopeColors.Editor.CursorLine)
+	r.recordUnused("ScopeColors.Editor.LineNumbers", s.ScopeColors.Editor.LineNumbers)
+	r.recordUnused("ScopeColors.Editor.Highlight", s.ScopeColors.Editor.Highlight)
+	r.recordUnused("ScopeColors.Miscellaneous.Meta", s.ScopeColors.Miscellaneous.Meta)
+	r.recordUnused("ScopeColors.Miscellaneous.Annotation", s.ScopeColors.Miscellaneous.Annotation)
+	r.recordUnused("ScopeColors.Miscellaneous.Regex", s.ScopeColors.Miscellaneous.Regex)
+	r.recordUnused("ScopeColors.Miscellaneous.Background", s.ScopeColors.Miscellaneous.Background)
+	r.recordUnused("ScopeColors.Miscellaneous.Foreground", s.ScopeColors.Miscellaneous.Foreground)
+	r.recordUnused("SpecialColors.ForegroundBright", s.SpecialColors.ForegroundBright)
+	r.recordUnused("SpecialColors.Links", s.SpecialColors.Links)
+	r.recordUnused("SpecialColors.FindMatch", s.SpecialColors.FindMatch)
+	return nil
+}
+
+func toAbstractGoghScheme(rw *gogh.GoghScheme, s *AbstractScheme, r *ConversionReport) error {
+	s.Metadata.Name = rw.SchemeName
+	s.Metadata.Author = rw.Author
+	s.AnsiColors.Black = rw.Color01
+	s.AnsiColors.Red = rw.Color02
+	s.AnsiColors.Green = rw.Color03
+	s.AnsiColors.Yellow = rw.Color04
+	s.AnsiColors.Blue = rw.Color05
+	s.AnsiColors.Magenta = rw.Color06
+	s.AnsiColors.Cyan = rw.Color07
+	s.AnsiColors.White = rw.Color08
+	s.AnsiColors.BrightBlack = rw.Color09
+	s.AnsiColors.BrightRed = rw.Color10
+	s.AnsiColors.BrightGreen = rw.Color11
+	s.AnsiColors.BrightYellow = rw.Color12
+	s.AnsiColors.BrightBlue = rw.Color13
+	s.AnsiColors.BrightMagenta = rw.Color14
+	s.AnsiColors.BrightCyan = rw.Color15
+	s.AnsiColors.BrightWhite = rw.Color16
+	s.SpecialColors.Background = rw.Background
+	s.SpecialColors.Foreground = rw.Foreground
+	s.SpecialColors.Cursor = rw.Cursor
+	return nil
+}
+
+func fromAbstractGoghScheme(s *AbstractScheme, rw *gogh.GoghScheme, r *ConversionReport) error {
+	rw.SchemeName = s.Metadata.Name
+	rw.Author = s.Metadata.Author
+	rw.Color01 = s.AnsiColors.Black
+	rw.Color02 = s.AnsiColors.Red
+	rw.Color03 = s.AnsiColors.Green
+	rw.Color04 = s.AnsiColors.Yellow
+	rw.Color05 = s.AnsiColors.Blue
+	rw.Color06 = s.AnsiColors.Magenta
+	rw.Color07 = s.AnsiColors.Cyan
+	rw.Color08 = s.AnsiColors.White
+	rw.Color09 = s.AnsiColors.BrightBlack
+	rw.Color10 = s.AnsiColors.BrightRed
+	rw.Color11 = s.AnsiColors.BrightGreen
+	rw.Color12 = s.AnsiColors.BrightYellow
+	rw.Color13 = s.AnsiColors.BrightBlue
+	rw.Color14 = s.AnsiColors.BrightMagenta
+	rw.Color15 = s.AnsiColors.BrightCyan
+	rw.Color16 = s.AnsiColors.BrightWhite
+	rw.Background = s.SpecialColors.Background
+	rw.Foreground = s.SpecialColors.Foreground
+	rw.Cursor = s.SpecialColors.Cursor
+	if r == nil {
+		return nil
+	}
+	r.recordWritten("SchemeName", rw.SchemeName, "Metadata.Name", false)
+	r.recordWritten("Author", rw.Author, "Metadata.Author", false)
+	r.recordWritten("Color01", rw.Color01, "AnsiColors.Black", false)
+	r.recordWritten("Color02", rw.Color02, "AnsiColors.Red", false)
+	r.recordWritten("Color03", rw.Color03, "AnsiColors.Green", false)
+	r.recordWritten("Color04", rw.Color04, "AnsiColors.Yellow", false)
+	r.recordWritten("Color05", rw.Color05, "AnsiColors.Blue", false)
+	r.recordWritten("Color06", rw.Color06, "AnsiColors.Magenta", false)
+	r.recordWritten("Color07", rw.Color07, "AnsiColors.Cyan", false)
+	r.recordWritten("Color08", rw.Color08, "AnsiColors.White", false)
+	r.recordWritten("Color09", rw.Color09, "AnsiColors.BrightBlack", false)
+	r.recordWritten("Color10", rw.Color10, "AnsiColors.BrightRed", false)
+	r.recordWritten("Color11", rw.Color11, "AnsiColors.BrightGreen", false)
+	r.recordWritten("Color12", rw.Color12, "AnsiColors.BrightYellow", false)
+	r.recordWritten("Color13", rw.Color13, "AnsiColors.BrightBlue", false)
+	r.recordWritten("Color14", rw.Color14, "AnsiColors.BrightMagenta", false)
+	r.recordWritten("Color15", rw.Color15, "AnsiColors.BrightCyan", false)
+	r.recordWritten("Color16", rw.Color16, "AnsiColors.BrightWhite", false)
+	r.recordWritten("Background", rw.Background, "SpecialColors.Background", false)
+	r.recordWritten("Foreground", rw.Foreground, "SpecialColors.Foreground", false)
+	r.recordWritten("Cursor", rw.Cursor, "SpecialColors.Cursor", false)
+	r.recordUnused("Metadata.Date", s.Metadata.Date)
+	r.recordUnused("ScopeColors.Basic.Comment", s.ScopeColors.Basic.Comment)
+	r.recordUnused("ScopeColors.Basic.Keyword", s.ScopeColors.Basic.Keyword)
+	r.recordUnused("ScopeColors.Basic.Constant", s.ScopeColors.Basic.Constant)
+	r.recordUnused("ScopeColors.Basic.String", s.ScopeColors.Basic.String)
+	r.recordUnused("ScopeColors.Basic.Number", s.ScopeColors.Basic.Number)
+	r.recordUnused("ScopeColors.Basic.Function", s.ScopeColors.Basic.Function)
+	r.recordUnused("ScopeColors.Basic.Variable", s.ScopeColors.Basic.Variable)
+	r.recordUnused("ScopeColors.Basic.Operator", s.ScopeColors.Basic.Operator)
+	r.recordUnused("ScopeColors.Advanced.Class", s.ScopeColors.Advanced.Class)
+	r.recordUnused("ScopeColors.Advanced.Type", s.ScopeColors.Advanced.Type)
+	r.recordUnused("ScopeColors.Advanced.Property", s.ScopeColors.Advanced.Property)
+	r.recordUnused("ScopeColors.Advanced.Attribute", s.ScopeColors.Advanced.Attribute)
+	r.recordUnused("ScopeColors.Advanced.Tag", s.ScopeColors.Advanced.Tag)
+	r.recordUnused("ScopeColors.Advanced.Namespace", s.ScopeColors.Advanced.Namespace)
+	r.recordUnused("ScopeColors.Advanced.Parameter", s.ScopeColors.Advanced.Parameter)
+	r.recordUnused("ScopeColors.Advanced.Selector", s.ScopeColors.Advanced.Selector)
+	r.recordUnused("ScopeColors.Markup.Heading", s.ScopeColors.Markup.Heading)
+	r.recordUnused("ScopeColors.Markup.Bold", s.ScopeColors.Markup.Bold)
+	r.recordUnused("ScopeColors.Markup.Italic", s.ScopeColors.Markup.Italic)
+	r.recordUnused("ScopeColors.Markup.Underline", s.ScopeColors.Markup.Underline)
+	r.recordUnused("ScopeColors.Markup.Link", s.ScopeColors.Markup.Link)
+	r.recordUnused("ScopeColors.Markup.Quote", s.ScopeColors.Markup.Quote)
+	r.recordUnused("ScopeColors.Markup.List", s.ScopeColors.Markup.List)
+	r.recordUnused("ScopeColors.Markup.CodeBlock", s.ScopeColors.Markup.CodeBlock)
+	r.recordUnused("ScopeColors.Markup.RawText", s.ScopeColors.Markup.RawText)
+	r.recordUnused("ScopeColors.Markup.TemplateTag", s.ScopeColors.Markup.TemplateTag)
+	r.recordUnused("ScopeColors.Diagnostics.Invalid", s.ScopeColors.Diagnostics.Invalid)
+	r.recordUnused("ScopeColors.Diagnostics.Deprecated", s.ScopeColors.Diagnostics.Deprecated)
+	r.recordUnused("ScopeColors.Editor.Cursor", s.ScopeColors.Editor.Cursor)
+	r.recordUnused("ScopeColors.Editor.CursorLine", s.ScopeColors.Editor.CursorLine)
+	r.recordUnused("ScopeColors.Editor.LineNumbers", s.ScopeColors.Editor.LineNumbers)
+	r.recordUnused("ScopeColors.Editor.Highlight", s.ScopeColors.Editor.Highlight)
+	r.recordUnused("ScopeColors.Miscellaneous.Meta", s.ScopeColors.Miscellaneous.Meta)
+	r.recordUnused("ScopeColors.Miscellaneous.Annotation", s.ScopeColors.Miscellaneous.Annotation)
+	r.recordUnused("ScopeColors.Miscellaneous.Regex", s.ScopeColors.Miscellaneous.Regex)
+	r.recordUnused("ScopeColors.Miscellaneous.Background", s.ScopeColors.Miscellaneous.Background)
+	r.recordUnused("ScopeColors.Miscellaneous.Foreground", s.ScopeColors.Miscellaneous.Foreground)
+	r.recordUnused("SpecialColors.ForegroundBright", s.SpecialColors.ForegroundBright)
+	r.recordUnused("SpecialColors.CursorText", s.SpecialColors.CursorText)
+	r.recordUnused("SpecialColors.Selection", s.SpecialColors.Selection)
+	r.recordUnused("SpecialColors.SelectedText", s.SpecialColors.SelectedText)
+	r.recordUnused("SpecialColors.Links", s.SpecialColors.Links)
+	r.recordUnused("SpecialColors.FindMatch", s.SpecialColors.FindMatch)
+	return nil
+}
+
+func toAbstractItermScheme(rw *iterm.ItermScheme, s *AbstractScheme, r *ConversionReport) error {
+	s.AnsiColors.Black = rw.Ansi0
+	s.AnsiColors.Red = rw.Ansi1
+	s.AnsiColors.Green = rw.Ansi2
+	s.AnsiColors.Yellow = rw.Ansi3
+	s.AnsiColors.Blue = rw.Ansi4
+	s.AnsiColors.Magenta = rw.Ansi5
+	s.AnsiColors.Cyan = rw.Ansi6
+	s.AnsiColors.White = rw.Ansi7
+	s.AnsiColors.BrightBlack = rw.Ansi8
+	s.AnsiColors.BrightRed = rw.Ansi9
+	s.AnsiColors.BrightGreen = rw.Ansi10
+	s.AnsiColors.BrightYellow = rw.Ansi11
+	s.AnsiColors.BrightBlue = rw.Ansi12
+	s.AnsiColors.BrightMagenta = rw.Ansi13
+	s.AnsiColors.BrightCyan = rw.Ansi14
+	s.AnsiColors.BrightWhite = rw.Ansi15
+	s.SpecialColors.Background = rw.Background
+	s.SpecialColors.Foreground = rw.Foreground
+	s.SpecialColors.ForegroundBright = rw.Bold
+	s.SpecialColors.Cursor = rw.Cursor
+	s.SpecialColors.CursorText = rw.CursorText
+	s.SpecialColors.FindMatch = rw.CursorGuide
+	s.SpecialColors.Links = rw.Link
+	s.SpecialColors.SelectedText = rw.SelectedText
+	s.SpecialColors.Selection = rw.Selection
+	return nil
+}
+
+func fromAbstractItermScheme(s *AbstractScheme, rw *iterm.ItermScheme, r *ConversionReport) error {
+	rw.Ansi0 = s.AnsiColors.Black
+	rw.Ansi1 = s.AnsiColors.Red
+	rw.Ansi2 = s.AnsiColors.Green
+	rw.Ansi3 = s.AnsiColors.Yellow
+	rw.Ansi4 = s.AnsiColors.Blue
+	rw.Ansi5 = s.AnsiColors.Magenta
+	rw.Ansi6 = s.AnsiColors.Cyan
+	rw.Ansi7 = s.AnsiColors.White
+	rw.Ansi8 = s.AnsiColors.BrightBlack
+	rw.Ansi9 = s.AnsiColors.BrightRed
+	rw.Ansi10 = s.AnsiColors.BrightGreen
+	rw.Ansi11 = s.AnsiColors.BrightYellow
+	rw.Ansi12 = s.AnsiColors.BrightBlue
+	rw.Ansi13 = s.AnsiColors.BrightMagenta
+	rw.Ansi14 = s.AnsiColors.BrightCyan
+	rw.Ansi15 = s.AnsiColors.BrightWhite
+	rw.Background = s.SpecialColors.Background
+	rw.Foreground = s.SpecialColors.Foreground
+	rw.Bold = s.SpecialColors.ForegroundBright
+	rw.Cursor = s.SpecialColors.Cursor
+	rw.CursorText = s.SpecialColors.CursorText
+	rw.CursorGuide = s.SpecialColors.FindMatch
+	rw.Link = s.SpecialColors.Links
+	rw.SelectedText = s.SpecialColors.SelectedText
+	rw.Selection = s.SpecialColors.Selection
+	if r == nil {
+		return nil
+	}
+	r.recordWritten("Ansi0", rw.Ansi0, "AnsiColors.Black", false)
+	r.recordWritten("Ansi1", rw.Ansi1, "AnsiColors.Red", false)
+	r.recordWritten("Ansi2", rw.Ansi2, "AnsiColors.Green", false)
+	r.recordWritten("Ansi3", rw.Ansi3, "AnsiColors.Yellow", false)
+	r.recordWritten("Ansi4", rw.Ansi4, "AnsiColors.Blue", false)
+	r.recordWritten("Ansi5", rw.Ansi5, "AnsiColors.Magenta", false)
+	r.recordWritten("Ansi6", rw.Ansi6, "AnsiColors.Cyan", false)
+	r.recordWritten("Ansi7", rw.Ansi7, "AnsiColors.White", false)
+	r.recordWritten("Ansi8", rw.Ansi8, "AnsiColors.BrightBlack", false)
+	r.recordWritten("Ansi9", rw.Ansi9, "AnsiColors.BrightRed", false)
+	r.recordWritten("Ansi10", rw.Ansi10, "AnsiColors.BrightGreen", false)
+	r.recordWritten("Ansi11", rw.Ansi11, "AnsiColors.BrightYellow", false)
+	r.recordWritten("Ansi12", rw.Ansi12, "AnsiColors.BrightBlue", false)
+	r.recordWritten("Ansi13", rw.Ansi13, "AnsiColors.BrightMagenta", false)
+	r.recordWritten("Ansi14", rw.Ansi14, "AnsiColors.BrightCyan", false)
+	r.recordWritten("Ansi15", rw.Ansi15, "AnsiColors.BrightWhite", false)
+	r.recordWritten("Background", rw.Background, "SpecialColors.Background", false)
+	r.recordWritten("Foreground", rw.Foreground, "SpecialColors.Foreground", false)
+	r.recordWritten("Bold", rw.Bold, "SpecialColors.ForegroundBright", false)
+	r.recordWritten("Cursor", rw.Cursor, "SpecialColors.Cursor", false)
+	r.recordWritten("CursorText", rw.CursorText, "SpecialColors.CursorText", false)
+	r.recordWritten("CursorGuide", rw.CursorGuide, "SpecialColors.FindMatch", false)
+	r.recordWritten("Link", rw.Link, "SpecialColors.Links", false)
+	r.recordWritten("SelectedText", rw.SelectedText, "SpecialColors.SelectedText", false)
+	r.recordWritten("Selection", rw.Selection, "SpecialColors.Selection", false)
+	r.recordUnused("Metadata.Name", s.Metadata.Name)
+	r.recordUnused("Metadata.Author", s.Metadata.Author)
+	r.recordUnused("Metadata.Date", s.Metadata.Date)
+	r.recordUnused("ScopeColors.Basic.Comment", s.ScopeColors.Basic.Comment)
+	r.recordUnused("ScopeColors.Basic.Keyword", s.ScopeColors.Basic.Keyword)
+	r.recordUnused("ScopeColors.Basic.Constant", s.ScopeColors.Basic.Constant)
+	r.recordUnused("ScopeColors.Basic.String", s.ScopeColors.Basic.String)
+	r.recordUnused("ScopeColors.Basic.Number", s.ScopeColors.Basic.Number)
+	r.recordUnused("ScopeColors.Basic.Function", s.ScopeColors.Basic.Function)
+	r.recordUnused("ScopeColors.Basic.Variable", s.ScopeColors.Basic.Variable)
+	r.recordUnused("ScopeColors.Basic.Operator", s.ScopeColors.Basic.Operator)
+	r.recordUnused("ScopeColors.Advanced.Class", s.ScopeColors.Advanced.Class)
+	r.recordUnused("ScopeColors.Advanced.Type", s.ScopeColors.Advanced.Type)
+	r.recordUnused("ScopeColors.Advanced.Property", s.ScopeColors.Advanced.Property)
+	r.recordUnused("ScopeColors.Advanced.Attribute", s.ScopeColors.Advanced.Attribute)
+	r.recordUnused("ScopeColors.Advanced.Tag", s.ScopeColors.Advanced.Tag)
+	r.recordUnused("ScopeColors.Advanced.Namespace", s.ScopeColors.Advanced.Namespace)
+	r.recordUnused("ScopeColors.Advanced.Parameter", s.ScopeColors.Advanced.Parameter)
+	r.recordUnused("ScopeColors.Advanced.Selector", s.ScopeColors.Advanced.Selector)
+	r.recordUnused("ScopeColors.Markup.Heading", s.ScopeColors.Markup.Heading)
+	r.recordUnused("ScopeColors.Markup.Bold", s.ScopeColors.Markup.Bold)
+	r.recordUnused("ScopeColors.Markup.Italic", s.ScopeColors.Markup.Italic)
+	r.recordUnused("ScopeColors.Markup.Underline", s.ScopeColors.Markup.Underline)
+	r.recordUnused("ScopeColors.Markup.Link", s.ScopeColors.Markup.Link)
+	r.recordUnused("ScopeColors.Markup.Quote", s.ScopeColors.Markup.Quote)
+	r.recordUnused("ScopeColors.Markup.List", s.ScopeColors.Markup.List)
+	r.recordUnused("ScopeColors.Markup.CodeBlock", s.ScopeColors.Markup.CodeBlock)
+	r.recordUnused("ScopeColors.Markup.RawText", s.ScopeColors.Markup.RawText)
+	r.recordUnused("ScopeColors.Markup.TemplateTag", s.ScopeColors.Markup.TemplateTag)
+	r.recordUnused("ScopeColors.Diagnostics.Invalid", s.ScopeColors.Diagnostics.Invalid)
+	r.recordUnused("ScopeColors.Diagnostics.Deprecated", s.ScopeColors.Diagnostics.Deprecated)
+	r.recordUnused("ScopeColors.Editor.Cursor", s.ScopeColors.Editor.Cursor)
+	r.recordUnused("ScopeColors.Editor.CursorLine", s.ScopeColors.Editor.CursorLine)
+	r.recordUnused("ScopeColors.Editor.LineNumbers", s.ScopeColors.Editor.LineNumbers)
+	r.recordUnused("ScopeColors.Editor.Highlight", s.ScopeColors.Editor.Highlight)
+	r.recordUnused("ScopeColors.Miscellaneous.Meta", s.ScopeColors.Miscellaneous.Meta)
+	r.recordUnused("ScopeColors.Miscellaneous.Annotation", s.ScopeColors.Miscellaneous.Annotation)
+	r.recordUnused("ScopeColors.Miscellaneous.Regex", s.ScopeColors.Miscellaneous.Regex)
+	r.recordUnused("ScopeColors.Miscellaneous.Background", s.ScopeColors.Miscellaneous.Background)
+	r.recordUnused("ScopeColors.Miscellaneous.Foreground", s.ScopeColors.Miscellaneous.Foreground)
+	return nil
+}
+
+func toAbstractWindowsTerminalScheme(rw *windows_terminal.WindowsTerminalScheme, s *AbstractScheme, r *ConversionReport) error {
+	s.Metadata.Name = rw.SchemeName
+	s.AnsiColors.Black = rw.Black
+	s.AnsiColors.Red = rw.Red
+	s.AnsiColors.Green = rw.Green
+	s.AnsiColors.Yellow = rw.Yellow
+	s.AnsiColors.Blue = rw.Blue
+	s.AnsiColors.Magenta = rw.Purple
+	s.AnsiColors.Cyan = rw.Cyan
+	s.AnsiColors.White = rw.White
+	s.AnsiColors.BrightBlack = rw.BrightBlack
+	s.AnsiColors.BrightRed = rw.BrightRed
+	s.AnsiColors.BrightGreen = rw.BrightGreen
+	s.AnsiColors.BrightYellow = rw.BrightYellow
+	s.AnsiColors.BrightBlue = rw.BrightBlue
+	s.AnsiColors.BrightMagenta = rw.BrightPurple
+	s.AnsiColors.BrightCyan = rw.BrightCyan
+	s.AnsiColors.BrightWhite = rw.BrightWhite
+	s.SpecialColors.Background = rw.Background
+	s.SpecialColors.Foreground = rw.Foreground
+	s.SpecialColors.Selection = rw.SelectionBackground
+	s.SpecialColors.Cursor = rw.CursorColor
+	return nil
+}
+
+func fromAbstractWindowsTerminalScheme(s *AbstractScheme, rw *windows_terminal.WindowsTerminalScheme, r *ConversionReport) error {
+	rw.SchemeName = s.Metadata.Name
+	rw.Black = s.AnsiColors.Black
+	rw.Red = s.AnsiColors.Red
+	rw.Green = s.AnsiColors.Green
+	rw.Yellow = s.AnsiColors.Yellow
+	rw.Blue = s.AnsiColors.Blue
+	rw.Purple = s.AnsiColors.Magenta
+	rw.Cyan = s.AnsiColors.Cyan
+	rw.White = s.AnsiColors.White
+	rw.BrightBlack = s.AnsiColors.BrightBlack
+	rw.BrightRed = s.AnsiColors.BrightRed
+	rw.BrightGreen = s.AnsiColors.BrightGreen
+	rw.BrightYellow = s.AnsiColors.BrightYellow
+	rw.BrightBlue = s.AnsiColors.BrightBlue
+	rw.BrightPurple = s.AnsiColors.BrightMagenta
+	rw.BrightCyan = s.AnsiColors.BrightCyan
+	rw.BrightWhite = s.AnsiColors.BrightWhite
+	rw.Background = s.SpecialColors.Background
+	rw.Foreground = s.SpecialColors.Foreground
+	rw.SelectionBackground = s.SpecialColors.Selection
+	rw.CursorColor = s.SpecialColors.Cursor
+	if r == nil {
+		return nil
+	}
+	r.recordWritten("SchemeName", rw.SchemeName, "Metadata.Name", false)
+	r.recordWritten("Black", rw.Black, "AnsiColors.Black", false)
+	r.recordWritten("Red", rw.Red, "AnsiColors.Red", false)
+	r.recordWritten("Green", rw.Green, "AnsiColors.Green", false)
+	r.recordWritten("Yellow", rw.Yellow, "AnsiColors.Yellow", false)
+	r.recordWritten("Blue", rw.Blue, "AnsiColors.Blue", false)
+	r.recordWritten("Purple", rw.Purple, "AnsiColors.Magenta", false)
+	r.recordWritten("Cyan", rw.Cyan, "AnsiColors.Cyan", false)
+	r.recordWritten("White", rw.White, "AnsiColors.White", false)
+	r.recordWritten("BrightBlack", rw.BrightBlack, "AnsiColors.BrightBlack", false)
+	r.recordWritten("BrightRed", rw.BrightRed, "AnsiColors.BrightRed", false)
+	r.recordWritten("BrightGreen", rw.BrightGreen, "AnsiColors.BrightGreen", false)
+	r.recordWritten("BrightYellow", rw.BrightYellow, "AnsiColors.BrightYellow", false)
+	r.recordWritten("BrightBlue", rw.BrightBlue, "AnsiColors.BrightBlue", false)
+	r.recordWritten("BrightPurple", rw.BrightPurple, "AnsiColors.BrightMagenta", false)
+	r.recordWritten("BrightCyan", rw.BrightCyan, "AnsiColors.BrightCyan", false)
+	r.recordWritten("BrightWhite", rw.BrightWhite, "AnsiColors.BrightWhite", false)
+	r.recordWritten("Background", rw.Background, "SpecialColors.Background", false)
+	r.recordWritten("Foreground", rw.Foreground, "SpecialColors.Foreground", false)
+	r.recordWritten("SelectionBackground", rw.SelectionBackground, "SpecialColors.Selection", false)
+	r.recordWritten("CursorColor", rw.CursorColor, "SpecialColors.Cursor", false)
+	r.recordUnused("Metadata.Author", s.Metadata.Author)
+	r.recordUnused("Metadata.Date", s.Metadata.Date)
+	r.recordUnused("ScopeColors.Basic.Comment", s.ScopeColors.Basic.Comment)
+	r.recordUnused("ScopeColors.Basic.Keyword", s.ScopeColors.Basic.Keyword)
+	r.recordUnused("ScopeColors.Basic.Constant", s.ScopeColors.Basic.Constant)
+	r.recordUnused("ScopeColors.Basic.String", s.ScopeColors.Basic.String)
+	r.recordUnused("ScopeColors.Basic.Number", s.ScopeColors.Basic.Number)
+	r.recordUnused("ScopeColors.Basic.Function", s.ScopeColors.Basic.Function)
+	r.recordUnused("ScopeColors.Basic.Variable", s.ScopeColors.Basic.Variable)
+	r.recordUnused("ScopeColors.Basic.Operator", s.ScopeColors.Basic.Operator)
+	r.recordUnused("ScopeColors.Advanced.Class", s.ScopeColors.Advanced.Class)
+	r.recordUnused("ScopeColors.Advanced.Type", s.ScopeColors.Advanced.Type)
+	r.recordUnused("ScopeColors.Advanced.Property", s.ScopeColors.Advanced.Property)
+	r.recordUnused("ScopeColors.Advanced.Attribute", s.ScopeColors.Advanced.Attribute)
+	r.recordUnused("ScopeColors.Advanced.Tag", s.ScopeColors.Advanced.Tag)
+	r.recordUnused("ScopeColors.Advanced.Namespace", s.ScopeColors.Advanced.Namespace)
+	r.recordUnused("ScopeColors.Advanced.Parameter", s.ScopeColors.Advanced.Parameter)
+	r.recordUnused("ScopeColors.Advanced.Selector", s.ScopeColors.Advanced.Selector)
+	r.recordUnused("ScopeColors.Markup.Heading", s.ScopeColors.Markup.Heading)
+	r.recordUnused("ScopeColors.Markup.Bold", s.ScopeColors.Markup.Bold)
+	r.recordUnused("ScopeColors.Markup.Italic", s.ScopeColors.Markup.Italic)
+	r.recordUnused("ScopeColors.Markup.Underline", s.ScopeColors.Markup.Underline)
+	r.recordUnused("ScopeColors.Markup.Link", s.ScopeColors.Markup.Link)
+	r.recordUnused("ScopeColors.Markup.Quote", s.ScopeColors.Markup.Quote)
+	r.recordUnused("ScopeColors.Markup.List", s.ScopeColors.Markup.List)
+	r.recordUnused("ScopeColors.Markup.CodeBlock", s.ScopeColors.Markup.CodeBlock)
+	r.recordUnused("ScopeColors.Markup.RawText", s.ScopeColors.Markup.RawText)
+	r.recordUnused("ScopeColors.Markup.TemplateTag", s.ScopeColors.Markup.TemplateTag)
+	r.recordUnused("ScopeColors.Diagnostics.Invalid", s.ScopeColors.Diagnostics.Invalid)
+	r.recordUnused("ScopeColors.Diagnostics.Deprecated", s.ScopeColors.Diagnostics.Deprecated)
+	r.recordUnused("ScopeColors.Editor.Cursor", s.ScopeColors.Editor.Cursor)
+	r.recordUnused("ScopeColors.Editor.CursorLine", s.ScopeColors.Editor.CursorLine)
+	r.recordUnused("ScopeColors.Editor.LineNumbers", s.ScopeColors.Editor.LineNumbers)
+	r.recordUnused("ScopeColors.Editor.Highlight", s.ScopeColors.Editor.Highlight)
+	r.recordUnused("ScopeColors.Miscellaneous.Meta", s.ScopeColors.Miscellaneous.Meta)
+	r.recordUnused("ScopeColors.Miscellaneous.Annotation", s.ScopeColors.Miscellaneous.Annotation)
+	r.recordUnused("ScopeColors.Miscellaneous.Regex", s.ScopeColors.Miscellaneous.Regex)
+	r.recordUnused("ScopeColors.Miscellaneous.Background", s.ScopeColors.Miscellaneous.Background)
+	r.recordUnused("ScopeColors.Miscellaneous.Foreground", s.ScopeColors.Miscellaneous.Foreground)
+	r.recordUnused("SpecialColors.ForegroundBright", s.SpecialColors.ForegroundBright)
+	r.recordUnused("SpecialColors.CursorText", s.SpecialColors.CursorText)
+	r.recordUnused("SpecialColors.SelectedText", s.SpecialColors.SelectedText)
+	r.recordUnused("SpecialColors.Links", s.SpecialColors.Links)
+	r.recordUnused("SpecialColors.FindMatch", s.SpecialColors.FindMatch)
+	return nil
+}
+
+func toAbstractVSCodeTheme(rw *vscode.VSCodeTheme, s *AbstractScheme, r *ConversionReport) error {
+	s.Metadata.Name = rw.ThemeName
+	s.Metadata.Author = rw.Author
+	s.SpecialColors.Background = rw.Colors.Background
+	s.SpecialColors.Foreground = rw.Colors.Foreground
+	s.SpecialColors.Cursor = rw.Colors.Cursor
+	s.SpecialColors.CursorText = rw.Colors.CursorText
+	s.SpecialColors.Selection = rw.Colors.SelectionBackground
+	s.SpecialColors.SelectedText = rw.Colors.SelectionForeground
+	s.SpecialColors.FindMatch = rw.Colors.FindMatch
+	s.SpecialColors.Links = rw.Colors.TextLink
+	s.ScopeColors.Editor.CursorLine = rw.Colors.LineHighlight
+	s.ScopeColors.Editor.LineNumbers = rw.Colors.LineNumber
+	s.ScopeColors.Editor.Highlight = rw.Colors.WordHighlight
+	s.AnsiColors.Black = rw.Colors.AnsiBlack
+	s.AnsiColors.Red = rw.Colors.AnsiRed
+	s.AnsiColors.Green = rw.Colors.AnsiGreen
+	s.AnsiColors.Yellow = rw.Colors.AnsiYellow
+	s.AnsiColors.Blue = rw.Colors.AnsiBlue
+	s.AnsiColors.Magenta = rw.Colors.AnsiMagenta
+	s.AnsiColors.Cyan = rw.Colors.AnsiCyan
+	s.AnsiColors.White = rw.Colors.AnsiWhite
+	s.AnsiColors.BrightBlack = rw.Colors.AnsiBrightBlack
+	s.AnsiColors.BrightRed = rw.Colors.AnsiBrightRed
+	s.AnsiColors.BrightGreen = rw.Colors.AnsiBrightGreen
+	s.AnsiColors.BrightYellow = rw.Colors.AnsiBrightYellow
+	s.AnsiColors.BrightBlue = rw.Colors.AnsiBrightBlue
+	s.AnsiColors.BrightMagenta = rw.Colors.AnsiBrightMagenta
+	s.AnsiColors.BrightCyan = rw.Colors.AnsiBrightCyan
+	s.AnsiColors.BrightWhite = rw.Colors.AnsiBrightWhite
+	s.ScopeColors.Basic.Comment = rw.TokenColors.Comment
+	s.ScopeColors.Basic.Keyword = rw.TokenColors.Keyword
+	s.ScopeColors.Basic.Constant = rw.TokenColors.Constant
+	s.ScopeColors.Basic.String = rw.TokenColors.String
+	s.ScopeColors.Basic.Number = rw.TokenColors.Number
+	s.ScopeColors.Basic.Function = rw.TokenColors.Function
+	s.ScopeColors.Basic.Variable = rw.TokenColors.Variable
+	s.ScopeColors.Basic.Operator = rw.TokenColors.Operator
+	s.ScopeColors.Advanced.Class = rw.TokenColors.Class
+	s.ScopeColors.Advanced.Type = rw.TokenColors.Type
+	s.ScopeColors.Advanced.Property = rw.TokenColors.Property
+	s.ScopeColors.Advanced.Attribute = rw.TokenColors.Attribute
+	s.ScopeColors.Advanced.Tag = rw.TokenColors.Tag
+	s.ScopeColors.Advanced.Namespace = rw.TokenColors.Namespace
+	s.ScopeColors.Advanced.Parameter = rw.TokenColors.Parameter
+	s.ScopeColors.Advanced.Selector = rw.TokenColors.Selector
+	s.ScopeColors.Markup.Heading = rw.TokenColors.Heading
+	s.ScopeColors.Markup.Bold = rw.TokenColors.Bold
+	s.ScopeColors.Markup.Italic = rw.TokenColors.Italic
+	s.ScopeColors.Markup.Underline = rw.TokenColors.Underline
+	s.ScopeColors.Markup.Link = rw.TokenColors.Link
+	s.ScopeColors.Markup.Quote = rw.TokenColors.Quote
+	s.ScopeColors.Markup.List = rw.TokenColors.List
+	s.ScopeColors.Markup.CodeBlock = rw.TokenColors.CodeBlock
+	s.ScopeColors.Markup.RawText = rw.TokenColors.RawText
+	s.ScopeColors.Markup.TemplateTag = rw.TokenColors.TemplateTag
+	s.ScopeColors.Diagnostics.Invalid = rw.TokenColors.Invalid
+	s.ScopeColors.Diagnostics.Deprecated = rw.TokenColors.Deprecated
+	s.ScopeColors.Miscellaneous.Meta = rw.TokenColors.Meta
+	s.ScopeColors.Miscellaneous.Annotation = rw.TokenColors.Annotation
+	s.ScopeColors.Miscellaneous.Regex = rw.TokenColors.Regex
+	if r == nil {
+		return nil
+	}
+	r.recordDropped("Maintainers", rw.Maintainers)
+	r.recordDropped("SemanticClass", rw.SemanticClass)
+	r.recordDropped("Colors.Other", rw.Colors.Other)
+	return nil
+}
+
+func fromAbstractVSCodeTheme(s *AbstractScheme, rw *vscode.VSCodeTheme, r *ConversionReport) error {
+	rw.ThemeName = s.Metadata.Name
+	rw.Author = s.Metadata.Author
+	rw.Colors.Background = s.SpecialColors.Background
+	rw.Colors.Foreground = s.SpecialColors.Foreground
+	rw.Colors.Cursor = s.SpecialColors.Cursor
+	rw.Colors.CursorText = s.SpecialColors.CursorText
+	rw.Colors.SelectionBackground = s.SpecialColors.Selection
+	rw.Colors.SelectionForeground = s.SpecialColors.SelectedText
+	rw.Colors.FindMatch = s.SpecialColors.FindMatch
+	rw.Colors.TextLink = s.SpecialColors.Links
+	rw.Colors.LineHighlight = s.ScopeColors.Editor.CursorLine
+	rw.Colors.LineNumber = s.ScopeColors.Editor.LineNumbers
+	rw.Colors.WordHighlight = s.ScopeColors.Editor.Highlight
+	rw.Colors.AnsiBlack = s.AnsiColors.Black
+	rw.Colors.AnsiRed = s.AnsiColors.Red
+	rw.Colors.AnsiGreen = s.AnsiColors.Green
+	rw.Colors.AnsiYellow = s.AnsiColors.Yellow
+	rw.Colors.AnsiBlue = s.AnsiColors.Blue
+	rw.Colors.AnsiMagenta = s.AnsiColors.Magenta
+	rw.Colors.AnsiCyan = s.AnsiColors.Cyan
+	rw.Colors.AnsiWhite = s.AnsiColors.White
+	rw.Colors.AnsiBrightBlack = s.AnsiColors.BrightBlack
+	rw.Colors.AnsiBrightRed = s.AnsiColors.BrightRed
+	rw.Colors.AnsiBrightGreen = s.AnsiColors.BrightGreen
+	rw.Colors.AnsiBrightYellow = s.AnsiColors.BrightYellow
+	rw.Colors.AnsiBrightBlue = s.AnsiColors.BrightBlue
+	rw.Colors.AnsiBrightMagenta = s.AnsiColors.BrightMagenta
+	rw.Colors.AnsiBrightCyan = s.AnsiColors.BrightCyan
+	rw.Colors.AnsiBrightWhite = s.AnsiColors.BrightWhite
+	rw.TokenColors.Comment = s.ScopeColors.Basic.Comment
+	rw.TokenColors.Keyword = s.ScopeColors.Basic.Keyword
+	rw.TokenColors.Constant = s.ScopeColors.Basic.Constant
+	rw.TokenColors.String = s.ScopeColors.Basic.String
+	rw.TokenColors.Number = s.ScopeColors.Basic.Number
+	rw.TokenColors.Function = s.ScopeColors.Basic.Function
+	rw.TokenColors.Variable = s.ScopeColors.Basic.Variable
+	rw.TokenColors.Operator = s.ScopeColors.Basic.Operator
+	rw.TokenColors.Class = s.ScopeColors.Advanced.Class
+	rw.TokenColors.Type = s.ScopeColors.Advanced.Type
+	rw.TokenColors.Property = s.ScopeColors.Advanced.Property
+	rw.TokenColors.Attribute = s.ScopeColors.Advanced.Attribute
+	rw.TokenColors.Tag = s.ScopeColors.Advanced.Tag
+	rw.TokenColors.Namespace = s.ScopeColors.Advanced.Namespace
+	rw.TokenColors.Parameter = s.ScopeColors.Advanced.Parameter
+	rw.TokenColors.Selector = s.ScopeColors.Advanced.Selector
+	rw.TokenColors.Heading = s.ScopeColors.Markup.Heading
+	rw.TokenColors.Bold = s.ScopeColors.Markup.Bold
+	rw.TokenColors.Italic = s.ScopeColors.Markup.Italic
+	rw.TokenColors.Underline = s.ScopeColors.Markup.Underline
+	rw.TokenColors.Link = s.ScopeColors.Markup.Link
+	rw.TokenColors.Quote = s.ScopeColors.Markup.Quote
+	rw.TokenColors.List = s.ScopeColors.Markup.List
+	rw.TokenColors.CodeBlock = s.ScopeColors.Markup.CodeBlock
+	rw.TokenColors.RawText = s.ScopeColors.Markup.RawText
+	rw.TokenColors.TemplateTag = s.ScopeColors.Markup.TemplateTag
+	rw.TokenColors.Invalid = s.ScopeColors.Diagnostics.Invalid
+	rw.TokenColors.Deprecated = s.ScopeColors.Diagnostics.Deprecated
+	rw.TokenColors.Meta = s.ScopeColors.Miscellaneous.Meta
+	rw.TokenColors.Annotation = s.ScopeColors.Miscellaneous.Annotation
+	rw.TokenColors.Regex = s.ScopeColors.Miscellaneous.Regex
+	if r == nil {
+		return nil
+	}
+	r.recordWritten("ThemeName", rw.ThemeName, "Metadata.Name", false)
+	r.recordWritten("Author", rw.Author, "Metadata.Author", false)
+	r.recordUnwritten("Maintainers", rw.Maintainers)
+	r.recordUnwritten("SemanticClass", rw.SemanticClass)
+	r.recordWritten("Colors.Background", rw.Colors.Background, "SpecialColors.Background", false)
+	r.recordWritten("Colors.Foreground", rw.Colors.Foreground, "SpecialColors.Foreground", false)
+	r.recordWritten("Colors.Cursor", rw.Colors.Cursor, "SpecialColors.Cursor", false)
+	r.recordWritten("Colors.CursorText", rw.Colors.CursorText, "SpecialColors.CursorText", false)
+	r.recordWritten("Colors.SelectionBackground", rw.Colors.SelectionBackground, "SpecialColors.Selection", false)
+	r.recordWritten("Colors.SelectionForeground", rw.Colors.SelectionForeground, "SpecialColors.SelectedText", false)
+	r.recordWritten("Colors.FindMatch", rw.Colors.FindMatch, "SpecialColors.FindMatch", false)
+	r.recordWritten("Colors.TextLink", rw.Colors.TextLink, "SpecialColors.Links", false)
+	r.recordWritten("Colors.LineHighlight", rw.Colors.LineHighlight, "ScopeColors.Editor.CursorLine", false)
+	r.recordWritten("Colors.LineNumber", rw.Colors.LineNumber, "ScopeColors.Editor.LineNumbers", false)
+	r.recordWritten("Colors.WordHighlight", rw.Colors.WordHighlight, "ScopeColors.Editor.Highlight", false)
+	r.recordWritten("Colors.AnsiBlack", rw.Colors.AnsiBlack, "AnsiColors.Black", false)
+	r.recordWritten("Colors.AnsiRed", rw.Colors.AnsiRed, "AnsiColors.Red", false)
+	r.recordWritten("Colors.AnsiGreen", rw.Colors.AnsiGreen, "AnsiColors.Green", false)
+	r.recordWritten("Colors.AnsiYellow", rw.Colors.AnsiYellow, "AnsiColors.Yellow", false)
+	r.recordWritten("Colors.AnsiBlue", rw.Colors.AnsiBlue, "AnsiColors.Blue", false)
+	r.recordWritten("Colors.AnsiMagenta", rw.Colors.AnsiMagenta, "AnsiColors.Magenta", false)
+	r.recordWritten("Colors.AnsiCyan", rw.Colors.AnsiCyan, "AnsiColors.Cyan", false)
+	r.recordWritten("Colors.AnsiWhite", rw.Colors.AnsiWhite, "AnsiColors.White", false)
+	r.recordWritten("Colors.AnsiBrightBlack", rw.Colors.AnsiBrightBlack, "AnsiColors.BrightBlack", false)
+	r.recordWritten("Colors.AnsiBrightRed", rw.Colors.AnsiBrightRed, "AnsiColors.BrightRed", false)
+	r.recordWritten("Colors.AnsiBrightGreen", rw.Colors.AnsiBrightGreen, "AnsiColors.BrightGreen", false)
+	r.recordWritten("Colors.AnsiBrightYellow", rw.Colors.AnsiBrightYellow, "AnsiColors.BrightYellow", false)
+	r.recordWritten("Colors.AnsiBrightBlue", rw.Colors.AnsiBrightBlue, "AnsiColors.BrightBlue", false)
+	r.recordWritten("Colors.AnsiBrightMagenta", rw.Colors.AnsiBrightMagenta, "AnsiColors.BrightMagenta", false)
+	r.recordWritten("Colors.AnsiBrightCyan", rw.Colors.AnsiBrightCyan, "AnsiColors.BrightCyan", false)
+	r.recordWritten("Colors.AnsiBrightWhite", rw.Colors.AnsiBrightWhite, "AnsiColors.BrightWhite", false)
+	r.recordUnwritten("Colors.Other", rw.Colors.Other)
+	r.recordWritten("TokenColors.Comment", rw.TokenColors.Comment, "ScopeColors.Basic.Comment", false)
+	r.recordWritten("TokenColors.Keyword", rw.TokenColors.Keyword, "ScopeColors.Basic.Keyword", false)
+	r.recordWritten("TokenColors.Constant", rw.TokenColors.Constant, "ScopeColors.Basic.Constant", false)
+	r.recordWritten("TokenColors.String", rw.TokenColors.String, "ScopeColors.Basic.String", false)
+	r.recordWritten("TokenColors.Number", rw.TokenColors.Number, "ScopeColors.Basic.Number", false)
+	r.recordWritten("TokenColors.Function", rw.TokenColors.Function, "ScopeColors.Basic.Function", false)
+	r.recordWritten("TokenColors.Variable", rw.TokenColors.Variable, "ScopeColors.Basic.Variable", false)
+	r.recordWritten("TokenColors.Operator", rw.TokenColors.Operator, "ScopeColors.Basic.Operator", false)
+	r.recordWritten("TokenColors.Class", rw.TokenColors.Class, "ScopeColors.Advanced.Class", false)
+	r.recordWritten("TokenColors.Type", rw.TokenColors.Type, "ScopeColors.Advanced.Type", false)
+	r.recordWritten("TokenColors.Property", rw.TokenColors.Property, "ScopeColors.Advanced.Property", false)
+	r.recordWritten("TokenColors.Attribute", rw.TokenColors.Attribute, "ScopeColors.Advanced.Attribute", false)
+	r.recordWritten("TokenColors.Tag", rw.TokenColors.Tag, "ScopeColors.Advanced.Tag", false)
+	r.recordWritten("TokenColors.Namespace", rw.TokenColors.Namespace, "ScopeColors.Advanced.Namespace", false)
+	r.recordWritten("TokenColors.Parameter", rw.TokenColors.Parameter, "ScopeColors.Advanced.Parameter", false)
+	r.recordWritten("TokenColors.Selector", rw.TokenColors.Selector, "ScopeColors.Advanced.Selector", false)
+	r.recordWritten("TokenColors.Heading", rw.TokenColors.Heading, "ScopeColors.Markup.Heading", false)
+	r.recordWritten("TokenColors.Bold", rw.TokenColors.Bold, "ScopeColors.Markup.Bold", false)
+	r.recordWritten("TokenColors.Italic", rw.TokenColors.Italic, "ScopeColors.Markup.Italic", false)
+	r.recordWritten("TokenColors.Underline", rw.TokenColors.Underline, "ScopeColors.Markup.Underline", false)
+	r.recordWritten("TokenColors.Link", rw.TokenColors.Link, "ScopeColors.Markup.Link", false)
+	r.recordWritten("TokenColors.Quote", rw.TokenColors.Quote, "ScopeColors.Markup.Quote", false)
+	r.recordWritten("TokenColors.List", rw.TokenColors.List, "ScopeColors.Markup.List", false)
+	r.recordWritten("TokenColors.CodeBlock", rw.TokenColors.CodeBlock, "ScopeColors.Markup.CodeBlock", false)
+	r.recordWritten("TokenColors.RawText", rw.TokenColors.RawText, "ScopeColors.Markup.RawText", false)
+	r.recordWritten("TokenColors.TemplateTag", rw.TokenColors.TemplateTag, "ScopeColors.Markup.TemplateTag", false)
+	r.recordWritten("TokenColors.Invalid", rw.TokenColors.Invalid, "ScopeColors.Diagnostics.Invalid", false)
+	r.recordWritten("TokenColors.Deprecated", rw.TokenColors.Deprecated, "ScopeColors.Diagnostics.Deprecated", false)
+	r.recordWritten("TokenColors.Meta", rw.TokenColors.Meta, "ScopeColors.Miscellaneous.Meta", false)
+	r.recordWritten("TokenColors.Annotation", rw.TokenColors.Annotation, "ScopeColors.Miscellaneous.Annotation", false)
+	r.recordWritten("TokenColors.Regex", rw.TokenColors.Regex, "ScopeColors.Miscellaneous.Regex", false)
+	r.recordUnused("Metadata.Date", s.Metadata.Date)
+	r.recordUnused("ScopeColors.Editor.Cursor", s.ScopeColors.Editor.Cursor)
+	r.recordUnused("ScopeColors.Miscellaneous.Background", s.ScopeColors.Miscellaneous.Background)
+	r.recordUnused("ScopeColors.Miscellaneous.Foreground", s.ScopeColors.Miscellaneous.Foreground)
+	r.recordUnused("SpecialColors.ForegroundBright", s.SpecialColors.ForegroundBright)
+	return nil
+}
+
+func toAbstractKittyScheme(rw *kitty.KittyScheme, s *AbstractScheme, r *ConversionReport) error {
+	s.Metadata.Name = rw.ThemeName
+	s.Metadata.Author = rw.Author
+	s.SpecialColors.Foreground = rw.Foreground
+	s.SpecialColors.Background = rw.Background
+	s.SpecialColors.SelectedText = rw.SelectionForeground
+	s.SpecialColors.Selection = rw.SelectionBackground
+	s.SpecialColors.Cursor = rw.Cursor
+	s.SpecialColors.CursorText = rw.CursorText
+	s.SpecialColors.Links = rw.URL
+	s.AnsiColors.Black = rw.Color0
+	s.AnsiColors.Red = rw.Color1
+	s.AnsiColors.Green = rw.Color2
+	s.AnsiColors.Yellow = rw.Color3
+	s.AnsiColors.Blue = rw.Color4
+	s.AnsiColors.Magenta = rw.Color5
+	s.AnsiColors.Cyan = rw.Color6
+	s.AnsiColors.White = rw.Color7
+	s.AnsiColors.BrightBlack = rw.Color8
+	s.AnsiColors.BrightRed = rw.Color9
+	s.AnsiColors.BrightGreen = rw.Color10
+	s.AnsiColors.BrightYellow = rw.Color11
+	s.AnsiColors.BrightBlue = rw.Color12
+	s.AnsiColors.BrightMagenta = rw.Color13
+	s.AnsiColors.BrightCyan = rw.Color14
+	s.AnsiColors.BrightWhite = rw.Color15
+	if r == nil {
+		return nil
+	}
+	r.recordDropped("Extra", rw.Extra)
+	return nil
+}
+
+func fromAbstractKittyScheme(s *AbstractScheme, rw *kitty.KittyScheme, r *ConversionReport) error {
+	rw.ThemeName = s.Metadata.Name
+	rw.Author = s.Metadata.Author
+	rw.Foreground = s.SpecialColors.Foreground
+	rw.Background = s.SpecialColors.Background
+	rw.SelectionForeground = s.SpecialColors.SelectedText
+	rw.SelectionBackground = s.SpecialColors.Selection
+	rw.Cursor = s.SpecialColors.Cursor
+	rw.CursorText = s.SpecialColors.CursorText
+	rw.URL = s.SpecialColors.Links
+	rw.Color0 = s.AnsiColors.Black
+	rw.Color1 = s.AnsiColors.Red
+	rw.Color2 = s.AnsiColors.Green
+	rw.Color3 = s.AnsiColors.Yellow
+	rw.Color4 = s.AnsiColors.Blue
+	rw.Color5 = s.AnsiColors.Magenta
+	rw.Color6 = s.AnsiColors.Cyan
+	rw.Color7 = s.AnsiColors.White
+	rw.Color8 = s.AnsiColors.BrightBlack
+	rw.Color9 = s.AnsiColors.BrightRed
+	rw.Color10 = s.AnsiColors.BrightGreen
+	rw.Color11 = s.AnsiColors.BrightYellow
+	rw.Color12 = s.AnsiColors.BrightBlue
+	rw.Color13 = s.AnsiColors.BrightMagenta
+	rw.Color14 = s.AnsiColors.BrightCyan
+	rw.Color15 = s.AnsiColors.BrightWhite
+	if r == nil {
+		return nil
+	}
+	r.recordWritten("ThemeName", rw.ThemeName, "Metadata.Name", false)
+	r.recordWritten("Author", rw.Author, "Metadata.Author", false)
+	r.recordWritten("Foreground", rw.Foreground, "SpecialColors.Foreground", false)
+	r.recordWritten("Background", rw.Background, "SpecialColors.Background", false)
+	r.recordWritten("SelectionForeground", rw.SelectionForeground, "SpecialColors.SelectedText", false)
+	r.recordWritten("SelectionBackground", rw.SelectionBackground, "SpecialColors.Selection", false)
+	r.recordWritten("Cursor", rw.Cursor, "SpecialColors.Cursor", false)
+	r.recordWritten("CursorText", rw.CursorText, "SpecialColors.CursorText", false)
+	r.recordWritten("URL", rw.URL, "SpecialColors.Links", false)
+	r.recordWritten("Color0", rw.Color0, "AnsiColors.Black", false)
+	r.recordWritten("Color1", rw.Color1, "AnsiColors.Red", false)
+	r.recordWritten("Color2", rw.Color2, "AnsiColors.Green", false)
+	r.recordWritten("Color3", rw.Color3, "AnsiColors.Yellow", false)
+	r.recordWritten("Color4", rw.Color4, "AnsiColors.Blue", false)
+	r.recordWritten("Color5", rw.Color5, "AnsiColors.Magenta", false)
+	r.recordWritten("Color6", rw.Color6, "AnsiColors.Cyan", false)
+	r.recordWritten("Color7", rw.Color7, "AnsiColors.White", false)
+	r.recordWritten("Color8", rw.Color8, "AnsiColors.BrightBlack", false)
+	r.recordWritten("Color9", rw.Color9, "AnsiColors.BrightRed", false)
+	r.recordWritten("Color10", rw.Color10, "AnsiColors.BrightGreen", false)
+	r.recordWritten("Color11", rw.Color11, "AnsiColors.BrightYellow", false)
+	r.recordWritten("Color12", rw.Color12, "AnsiColors.BrightBlue", false)
+	r.recordWritten("Color13", rw.Color13, "AnsiColors.BrightMagenta", false)
+	r.recordWritten("Color14", rw.Color14, "AnsiColors.BrightCyan", false)
+	r.recordWritten("Color15", rw.Color15, "AnsiColors.BrightWhite", false)
+	r.recordUnwritten("Extra", rw.Extra)
+	r.recordUnused("Metadata.Date", s.Metadata.Date)
+	r.recordUnused("ScopeColors.Basic.Comment", s.ScopeColors.Basic.Comment)
+	r.recordUnused("ScopeColors.Basic.Keyword", s.ScopeColors.Basic.Keyword)
+	r.recordUnused("ScopeColors.Basic.Constant", s.ScopeColors.Basic.Constant)
+	r.recordUnused("ScopeColors.Basic.String", s.ScopeColors.Basic.String)
+	r.recordUnused("ScopeColors.Basic.Number", s.ScopeColors.Basic.Number)
+	r.recordUnused("ScopeColors.Basic.Function", s.ScopeColors.Basic.Function)
+	r.recordUnused("ScopeColors.Basic.Variable", s.ScopeColors.Basic.Variable)
+	r.recordUnused("ScopeColors.Basic.Operator", s.ScopeColors.Basic.Operator)
+	r.recordUnused("ScopeColors.Advanced.Class", s.ScopeColors.Advanced.Class)
+	r.recordUnused("ScopeColors.Advanced.Type", s.ScopeColors.Advanced.Type)
+	r.recordUnused("ScopeColors.Advanced.Property", s.ScopeColors.Advanced.Property)
+	r.recordUnused("ScopeColors.Advanced.Attribute", s.ScopeColors.Advanced.Attribute)
+	r.recordUnused("ScopeColors.Advanced.Tag", s.ScopeColors.Advanced.Tag)
+	r.recordUnused("ScopeColors.Advanced.Namespace", s.ScopeColors.Advanced.Namespace)
+	r.recordUnused("ScopeColors.Advanced.Parameter", s.ScopeColors.Advanced.Parameter)
+	r.recordUnused("ScopeColors.Advanced.Selector", s.ScopeColors.Advanced.Selector)
+	r.recordUnused("ScopeColors.Markup.Heading", s.ScopeColors.Markup.Heading)
+	r.recordUnused("ScopeColors.Markup.Bold", s.ScopeColors.Markup.Bold)
+	r.recordUnused("ScopeColors.Markup.Italic", s.ScopeColors.Markup.Italic)
+	r.recordUnused("ScopeColors.Markup.Underline", s.ScopeColors.Markup.Underline)
+	r.recordUnused("ScopeColors.Markup.Link", s.ScopeColors.Markup.Link)
+	r.recordUnused("ScopeColors.Markup.Quote", s.ScopeColors.Markup.Quote)
+	r.recordUnused("ScopeColors.Markup.List", s.ScopeColors.Markup.List)
+	r.recordUnused("ScopeColors.Markup.CodeBlock", s.ScopeColors.Markup.CodeBlock)
+	r.recordUnused("ScopeColors.Markup.RawText", s.ScopeColors.Markup.RawText)
+	r.recordUnused("ScopeColors.Markup.TemplateTag", s.ScopeColors.Markup.TemplateTag)
+	r.recordUnused("ScopeColors.Diagnostics.Invalid", s.ScopeColors.Diagnostics.Invalid)
+	r.recordUnused("ScopeColors.Diagnostics.Deprecated", s.ScopeColors.Diagnostics.Deprecated)
+	r.recordUnused("ScopeColors.Editor.Cursor", s.ScopeColors.Editor.Cursor)
+	r.recordUnused("ScopeColors.Editor.CursorLine", s.ScopeColors.Editor.CursorLine)
+	r.recordUnused("ScopeColors.Editor.LineNumbers", s.ScopeColors.Editor.LineNumbers)
+	r.recordUnused("ScopeColors.Editor.Highlight", s.ScopeColors.Editor.Highlight)
+	r.recordUnused("ScopeColors.Miscellaneous.Meta", s.ScopeColors.Miscellaneous.Meta)
+	r.recordUnused("ScopeColors.Miscellaneous.Annotation", s.ScopeColors.Miscellaneous.Annotation)
+	r.recordUnused("ScopeColors.Miscellaneous.Regex", s.ScopeColors.Miscellaneous.Regex)
+	r.recordUnused("ScopeColors.Miscellaneous.Background", s.ScopeColors.Miscellaneous.Background)
+	r.recordUnused("ScopeColors.Miscellaneous.Foreground", s.ScopeColors.Miscellaneous.Foreground)
+	r.recordUnused("SpecialColors.ForegroundBright", s.SpecialColors.ForegroundBright)
+	r.recordUnused("SpecialColors.FindMatch", s.SpecialColors.FindMatch)
+	return nil
+}
+
+func toAbstractTerminatorScheme(rw *terminator.TerminatorScheme, s *AbstractScheme, r *ConversionReport) error {
+	s.Metadata.Name = rw.ProfileName
+	s.SpecialColors.Background = rw.Background
+	s.SpecialColors.Foreground = rw.Foreground
+	s.SpecialColors.Cursor = rw.Cursor
+	s.SpecialColors.CursorText = rw.CursorText
+	s.AnsiColors.Black = rw.Palette.Black
+	s.AnsiColors.Red = rw.Palette.Red
+	s.AnsiColors.Green = rw.Palette.Green
+	s.AnsiColors.Yellow = rw.Palette.Yellow
+	s.AnsiColors.Blue = rw.Palette.Blue
+	s.AnsiColors.Magenta = rw.Palette.Magenta
+	s.AnsiColors.Cyan = rw.Palette.Cyan
+	s.AnsiColors.White = rw.Palette.White
+	s.AnsiColors.BrightBlack = rw.Palette.BrightBlack
+	s.AnsiColors.BrightRed = rw.Palette.BrightRed
+	s.AnsiColors.BrightGreen = rw.Palette.BrightGreen
+	s.AnsiColors.BrightYellow = rw.Palette.BrightYellow
+	s.AnsiColors.BrightBlue = rw.Palette.BrightBlue
+	s.AnsiColors.BrightMagenta = rw.Palette.BrightMagenta
+	s.AnsiColors.BrightCyan = rw.Palette.BrightCyan
+	s.AnsiColors.BrightWhite = rw.Palette.BrightWhite
+	return nil
+}
+
+func fromAbstractTerminatorScheme(s *AbstractScheme, rw *terminator.TerminatorScheme, r *ConversionReport) error {
+	rw.ProfileName = s.Metadata.Name
+	rw.Background = s.SpecialColors.Background
+	rw.Foreground = s.SpecialColors.Foreground
+	rw.Cursor = s.SpecialColors.Cursor
+	rw.CursorText = s.SpecialColors.CursorText
+	rw.Palette.Black = s.AnsiColors.Black
+	rw.Palette.Red = s.AnsiColors.Red
+	rw.Palette.Green = s.AnsiColors.Green
+	rw.Palette.Yellow = s.AnsiColors.Yellow
+	rw.Palette.Blue = s.AnsiColors.Blue
+	rw.Palette.Magenta = s.AnsiColors.Magenta
+	rw.Palette.Cyan = s.AnsiColors.Cyan
+	rw.Palette.White = s.AnsiColors.White
+	rw.Palette.BrightBlack = s.AnsiColors.BrightBlack
+	rw.Palette.BrightRed = s.AnsiColors.BrightRed
+	rw.Palette.BrightGreen = s.AnsiColors.BrightGreen
+	rw.Palette.BrightYellow = s.AnsiColors.BrightYellow
+	rw.Palette.BrightBlue = s.AnsiColors.BrightBlue
+	rw.Palette.BrightMagenta = s.AnsiColors.BrightMagenta
+	rw.Palette.BrightCyan = s.AnsiColors.BrightCyan
+	rw.Palette.BrightWhite = s.AnsiColors.BrightWhite
+	if r == nil {
+		return nil
+	}
+	r.recordWritten("ProfileName", rw.ProfileName, "Metadata.Name", false)
+	r.recordWritten("Background", rw.Background, "SpecialColors.Background", false)
+	r.recordWritten("Foreground", rw.Foreground, "SpecialColors.Foreground", false)
+	r.recordWritten("Cursor", rw.Cursor, "SpecialColors.Cursor", false)
+	r.recordWritten("CursorText", rw.CursorText, "SpecialColors.CursorText", false)
+	r.recordWritten("Palette.Black", rw.Palette.Black, "AnsiColors.Black", false)
+	r.recordWritten("Palette.Red", rw.Palette.Red, "AnsiColors.Red", false)
+	r.recordWritten("Palette.Green", rw.Palette.Green, "AnsiColors.Green", false)
+	r.recordWritten("Palette.Yellow", rw.Palette.Yellow, "AnsiColors.Yellow", false)
+	r.recordWritten("Palette.Blue", rw.Palette.Blue, "AnsiColors.Blue", false)
+	r.recordWritten("Palette.Magenta", rw.Palette.Magenta, "AnsiColors.Magenta", false)
+	r.recordWritten("Palette.Cyan", rw.Palette.Cyan, "AnsiColors.Cyan", false)
+	r.recordWritten("Palette.White", rw.Palette.White, "AnsiColors.White", false)
+	r.recordWritten("Palette.BrightBlack", rw.Palette.BrightBlack, "AnsiColors.BrightBlack", false)
+	r.recordWritten("Palette.BrightRed", rw.Palette.BrightRed, "AnsiColors.BrightRed", false)
+	r.recordWritten("Palette.BrightGreen", rw.Palette.BrightGreen, "AnsiColors.BrightGreen", false)
+	r.recordWritten("Palette.BrightYellow", rw.Palette.BrightYellow, "AnsiColors.BrightYellow", false)
+	r.recordWritten("Palette.BrightBlue", rw.Palette.BrightBlue, "AnsiColors.BrightBlue", false)
+	r.recordWritten("Palette.BrightMagenta", rw.Palette.BrightMagenta, "AnsiColors.BrightMagenta", false)
+	r.recordWritten("Palette.BrightCyan", rw.Palette.BrightCyan, "AnsiColors.BrightCyan", false)
+	r.recordWritten("Palette.BrightWhite", rw.Palette.BrightWhite, "AnsiColors.BrightWhite", false)
+	r.recordUnused("Metadata.Author", s.Metadata.Author)
+	r.recordUnused("Metadata.Date", s.Metadata.Date)
+	r.recordUnused("ScopeColors.Basic.Comment", s.ScopeColors.Basic.Comment)
+	r.recordUnused("ScopeColors.Basic.Keyword", s.ScopeColors.Basic.Keyword)
+	r.recordUnused("ScopeColors.Basic.Constant", s.ScopeColors.Basic.Constant)
+	r.recordUnused("ScopeColors.Basic.String", s.ScopeColors.Basic.String)
+	r.recordUnused("ScopeColors.Basic.Number", s.ScopeColors.Basic.Number)
+	r.recordUnused("ScopeColors.Basic.Function", s.ScopeColors.Basic.Function)
+	r.recordUnused("ScopeColors.Basic.Variable", s.ScopeColors.Basic.Variable)
+	r.recordUnused("ScopeColors.Basic.Operator", s.ScopeColors.Basic.Operator)
+	r.recordUnused("ScopeColors.Advanced.Class", s.ScopeColors.Advanced.Class)
+	r.recordUnused("ScopeColors.Advanced.Type", s.ScopeColors.Advanced.Type)
+	r.recordUnused("ScopeColors.Advanced.Property", s.ScopeColors.Advanced.Property)
+	r.recordUnused("ScopeColors.Advanced.Attribute", s.ScopeColors.Advanced.Attribute)
+	r.recordUnused("ScopeColors.Advanced.Tag", s.ScopeColors.Advanced.Tag)
+	r.recordUnused("ScopeColors.Advanced.Namespace", s.ScopeColors.Advanced.Namespace)
+	r.recordUnused("ScopeColors.Advanced.Parameter", s.ScopeColors.Advanced.Parameter)
+	r.recordUnused("ScopeColors.Advanced.Selector", s.ScopeColors.Advanced.Selector)
+	r.recordUnused("ScopeColors.Markup.Heading", s.ScopeColors.Markup.Heading)
+	r.recordUnused("ScopeColors.Markup.Bold", s.ScopeColors.Markup.Bold)
+	r.recordUnused("ScopeColors.Markup.Italic", s.ScopeColors.Markup.Italic)
+	r.recordUnused("ScopeColors.Markup.Underline", s.ScopeColors.Markup.Underline)
+	r.recordUnused("ScopeColors.Markup.Link", s.ScopeColors.Markup.Link)
+	r.recordUnused("ScopeColors.Markup.Quote", s.ScopeColors.Markup.Quote)
+	r.recordUnused("ScopeColors.Markup.List", s.ScopeColors.Markup.List)
+	r.recordUnused("ScopeColors.Markup.CodeBlock", s.ScopeColors.Markup.CodeBlock)
+	r.recordUnused("ScopeColors.Markup.RawText", s.ScopeColors.Markup.RawText)
+	r.recordUnused("ScopeColors.Markup.TemplateTag", s.ScopeColors.Markup.TemplateTag)
+	r.recordUnused("ScopeColors.Diagnostics.Invalid", s.ScopeColors.Diagnostics.Invalid)
+	r.recordUnused("ScopeColors.Diagnostics.Deprecated", s.ScopeColors.Diagnostics.Deprecated)
+	r.recordUnused("ScopeColors.Editor.Cursor", s.ScopeColors.Editor.Cursor)
+	r.recordUnused("ScopeColors.Editor.CursorLine", s.ScopeColors.Editor.CursorLine)
+	r.recordUnused("ScopeColors.Editor.LineNumbers", s.ScopeColors.Editor.LineNumbers)
+	r.recordUnused("ScopeColors.Editor.Highlight", s.ScopeColors.Editor.Highlight)
+	r.recordUnused("ScopeColors.Miscellaneous.Meta", s.ScopeColors.Miscellaneous.Meta)
+	r.recordUnused("ScopeColors.Miscellaneous.Annotation", s.ScopeColors.Miscellaneous.Annotation)
+	r.recordUnused("ScopeColors.Miscellaneous.Regex", s.ScopeColors.Miscellaneous.Regex)
+	r.recordUnused("ScopeColors.Miscellaneous.Background", s.ScopeColors.Miscellaneous.Background)
+	r.recordUnused("ScopeColors.Miscellaneous.Foreground", s.ScopeColors.Miscellaneous.Foreground)
+	r.recordUnused("SpecialColors.ForegroundBright", s.SpecialColors.ForegroundBright)
+	r.recordUnused("SpecialColors.Selection", s.SpecialColors.Selection)
+	r.recordUnused("SpecialColors.SelectedText", s.SpecialColors.SelectedText)
+	r.recordUnused("SpecialColors.Links", s.SpecialColors.Links)
+	r.recordUnused("SpecialColors.FindMatch", s.SpecialColors.FindMatch)
+	return nil
+}
+
+func toAbstractNativeScheme(rw *native.NativeScheme, s *AbstractScheme, r *ConversionReport) error {
+	s.Metadata.Name = rw.Metadata.Name
+	s.Metadata.Author = rw.Metadata.Author
+	s.Metadata.Date = rw.Metadata.Date
+	s.ScopeColors.Basic.Comment = rw.ScopeColors.Basic.Comment
+	s.ScopeColors.Basic.Keyword = rw.ScopeColors.Basic.Keyword
+	s.ScopeColors.Basic.Constant = rw.ScopeColors.Basic.Constant
+	s.ScopeColors.Basic.String = rw.ScopeColors.Basic.String
+	s.ScopeColors.Basic.Number = rw.ScopeColors.Basic.Number
+	s.ScopeColors.Basic.Function = rw.ScopeColors.Basic.Function
+	s.ScopeColors.Basic.Variable = rw.ScopeColors.Basic.Variable
+	s.ScopeColors.Basic.Operator = rw.ScopeColors.Basic.Operator
+	s.ScopeColors.Advanced.Class = rw.ScopeColors.Advanced.Class
+	s.ScopeColors.Advanced.Type = rw.ScopeColors.Advanced.Type
+	s.ScopeColors.Advanced.Property = rw.ScopeColors.Advanced.Property
+	s.ScopeColors.Advanced.Attribute = rw.ScopeColors.Advanced.Attribute
+	s.ScopeColors.Advanced.Tag = rw.ScopeColors.Advanced.Tag
+	s.ScopeColors.Advanced.Namespace = rw.ScopeColors.Advanced.Namespace
+	s.ScopeColors.Advanced.Parameter = rw.ScopeColors.Advanced.Parameter
+	s.ScopeColors.Advanced.Selector = rw.ScopeColors.Advanced.Selector
+	s.ScopeColors.Markup.Heading = rw.ScopeColors.Markup.Heading
+	s.ScopeColors.Markup.Bold = rw.ScopeColors.Markup.Bold
+	s.ScopeColors.Markup.Italic = rw.ScopeColors.Markup.Italic
+	s.ScopeColors.Markup.Underline = rw.ScopeColors.Markup.Underline
+	s.ScopeColors.Markup.Link = rw.ScopeColors.Markup.Link
+	s.ScopeColors.Markup.Quote = rw.ScopeColors.Markup.Quote
+	s.ScopeColors.Markup.List = rw.ScopeColors.Markup.List
+	s.ScopeColors.Markup.CodeBlock = rw.ScopeColors.Markup.CodeBlock
+	s.ScopeColors.Markup.RawText = rw.ScopeColors.Markup.RawText
+	s.ScopeColors.Markup.TemplateTag = rw.ScopeColors.Markup.TemplateTag
+	s.ScopeColors.Diagnostics.Invalid = rw.ScopeColors.Diagnostics.Invalid
+	s.ScopeColors.Diagnostics.Deprecated = rw.ScopeColors.Diagnostics.Deprecated
+	s.ScopeColors.Editor.Cursor = rw.ScopeColors.Editor.Cursor
+	s.ScopeColors.Editor.CursorLine = rw.ScopeColors.Editor.CursorLine
+	s.ScopeColors.Editor.LineNumbers = rw.ScopeColors.Editor.LineNumbers
+	s.ScopeColors.Editor.Highlight = rw.ScopeColors.Editor.Highlight
+	s.ScopeColors.Miscellaneous.Meta = rw.ScopeColors.Miscellaneous.Meta
+	s.ScopeColors.Miscellaneous.Annotation = rw.ScopeColors.Miscellaneous.Annotation
+	s.ScopeColors.Miscellaneous.Regex = rw.ScopeColors.Miscellaneous.Regex
+	s.ScopeColors.Miscellaneous.Background = rw.ScopeColors.Miscellaneous.Background
+	s.ScopeColors.Miscellaneous.Foreground = rw.ScopeColors.Miscellaneous.Foreground
+	s.AnsiColors.Black = rw.AnsiColors.Black
+	s.AnsiColors.Red = rw.AnsiColors.Red
+	s.AnsiColors.Green = rw.AnsiColors.Green
+	s.AnsiColors.Yellow = rw.AnsiColors.Yellow
+	s.AnsiColors.Blue = rw.AnsiColors.Blue
+	s.AnsiColors.Magenta = rw.AnsiColors.Magenta
+	s.AnsiColors.Cyan = rw.AnsiColors.Cyan
+	s.AnsiColors.White = rw.AnsiColors.White
+	s.AnsiColors.BrightBlack = rw.AnsiColors.BrightBlack
+	s.AnsiColors.BrightRed = rw.AnsiColors.BrightRed
+	s.AnsiColors.BrightGreen = rw.AnsiColors.BrightGreen
+	s.AnsiColors.BrightYellow = rw.AnsiColors.BrightYellow
+	s.AnsiColors.BrightBlue = rw.AnsiColors.BrightBlue
+	s.AnsiColors.BrightMagenta = rw.AnsiColors.BrightMagenta
+	s.AnsiColors.BrightCyan = rw.AnsiColors.BrightCyan
+	s.AnsiColors.BrightWhite = rw.AnsiColors.BrightWhite
+	s.SpecialColors.Foreground = rw.SpecialColors.Foreground
+	s.SpecialColors.ForegroundBright = rw.SpecialColors.ForegroundBright
+	s.SpecialColors.Background = rw.SpecialColors.Background
+	s.SpecialColors.Cursor = rw.SpecialColors.Cursor
+	s.SpecialColors.CursorText = rw.SpecialColors.CursorText
+	s.SpecialColors.Selection = rw.SpecialColors.Selection
+	s.SpecialColors.SelectedText = rw.SpecialColors.SelectedText
+	s.SpecialColors.Links = rw.SpecialColors.Links
+	s.SpecialColors.FindMatch = rw.SpecialColors.FindMatch
+	return nil
+}
+
+func fromAbstractNativeScheme(s *AbstractScheme, rw *native.NativeScheme, r *ConversionReport) error {
+	rw.Metadata.Name = s.Metadata.Name
+	rw.Metadata.Author = s.Metadata.Author
+	rw.Metadata.Date = s.Metadata.Date
+	rw.ScopeColors.Basic.Comment = s.ScopeColors.Basic.Comment
+	rw.ScopeColors.Basic.Keyword = s.ScopeColors.Basic.Keyword
+	rw.ScopeColors.Basic.Constant = s.ScopeColors.Basic.Constant
+	rw.ScopeColors.Basic.String = s.ScopeColors.Basic.String
+	rw.ScopeColors.Basic.Number = s.ScopeColors.Basic.Number
+	rw.ScopeColors.Basic.Function = s.ScopeColors.Basic.Function
+	rw.ScopeColors.Basic.Variable = s.ScopeColors.Basic.Variable
+	rw.ScopeColors.Basic.Operator = s.ScopeColors.Basic.Operator
+	rw.ScopeColors.Advanced.Class = s.ScopeColors.Advanced.Class
+	rw.ScopeColors.Advanced.Type = s.ScopeColors.Advanced.Type
+	rw.ScopeColors.Advanced.Property = s.ScopeColors.Advanced.Property
+	rw.ScopeColors.Advanced.Attribute = s.ScopeColors.Advanced.Attribute
+	rw.ScopeColors.Advanced.Tag = s.ScopeColors.Advanced.Tag
+	rw.ScopeColors.Advanced.Namespace = s.ScopeColors.Advanced.Namespace
+	rw.ScopeColors.Advanced.Parameter = s.ScopeColors.Advanced.Parameter
+	rw.ScopeColors.Advanced.Selector = s.ScopeColors.Advanced.Selector
+	rw.ScopeColors.Markup.Heading = s.ScopeColors.Markup.Heading
+	rw.ScopeColors.Markup.Bold = s.ScopeColors.Markup.Bold
+	rw.ScopeColors.Markup.Italic = s.ScopeColors.Markup.Italic
+	rw.ScopeColors.Markup.Underline = s.ScopeColors.Markup.Underline
+	rw.ScopeColors.Markup.Link = s.ScopeColors.Markup.Link
+	rw.ScopeColors.Markup.Quote = s.ScopeColors.Markup.Quote
+	rw.ScopeColors.Markup.List = s.ScopeColors.Markup.List
+	rw.ScopeColors.Markup.CodeBlock = s.ScopeColors.Markup.CodeBlock
+	rw.ScopeColors.Markup.RawText = s.ScopeColors.Markup.RawText
+	rw.ScopeColors.Markup.TemplateTag = s.ScopeColors.Markup.TemplateTag
+	rw.ScopeColors.Diagnostics.Invalid = s.ScopeColors.Diagnostics.Invalid
+	rw.ScopeColors.Diagnostics.Deprecated = s.ScopeColors.Diagnostics.Deprecated
+	rw.ScopeColors.Editor.Cursor = s.ScopeColors.Editor.Cursor
+	rw.ScopeColors.Editor.CursorLine = s.ScopeColors.Editor.CursorLine
+	rw.ScopeColors.Editor.LineNumbers = s.ScopeColors.Editor.LineNumbers
+	rw.ScopeColors.Editor.Highlight = s.ScopeColors.Editor.Highlight
+	rw.ScopeColors.Miscellaneous.Meta = s.ScopeColors.Miscellaneous.Meta
+	rw.ScopeColors.Miscellaneous.Annotation = s.ScopeColors.Miscellaneous.Annotation
+	rw.ScopeColors.Miscellaneous.Regex = s.ScopeColors.Miscellaneous.Regex
+	rw.ScopeColors.Miscellaneous.Background = s.ScopeColors.Miscellaneous.Background
+	rw.ScopeColors.Miscellaneous.Foreground = s.ScopeColors.Miscellaneous.Foreground
+	rw.AnsiColors.Black = s.AnsiColors.Black
+	rw.AnsiColors.Red = s.AnsiColors.Red
+	rw.AnsiColors.Green = s.AnsiColors.Green
+	rw.AnsiColors.Yellow = s.AnsiColors.Yellow
+	rw.AnsiColors.Blue = s.AnsiColors.Blue
+	rw.AnsiColors.Magenta = s.AnsiColors.Magenta
+	rw.AnsiColors.Cyan = s.AnsiColors.Cyan
+	rw.AnsiColors.White = s.AnsiColors.White
+	rw.AnsiColors.BrightBlack = s.AnsiColors.BrightBlack
+	rw.AnsiColors.BrightRed = s.AnsiColors.BrightRed
+	rw.AnsiColors.BrightGreen = s.AnsiColors.BrightGreen
+	rw.AnsiColors.BrightYellow = s.AnsiColors.BrightYellow
+	rw.AnsiColors.BrightBlue = s.AnsiColors.BrightBlue
+	rw.AnsiColors.BrightMagenta = s.AnsiColors.BrightMagenta
+	rw.AnsiColors.BrightCyan = s.AnsiColors.BrightCyan
+	rw.AnsiColors.BrightWhite = s.AnsiColors.BrightWhite
+	rw.SpecialColors.Foreground = s.SpecialColors.Foreground
+	rw.SpecialColors.ForegroundBright = s.SpecialColors.ForegroundBright
+	rw.SpecialColors.Background = s.SpecialColors.Background
+	rw.SpecialColors.Cursor = s.SpecialColors.Cursor
+	rw.SpecialColors.CursorText = s.SpecialColors.CursorText
+	rw.SpecialColors.Selection = s.SpecialColors.Selection
+	rw.SpecialColors.SelectedText = s.SpecialColors.SelectedText
+	rw.SpecialColors.Links = s.SpecialColors.Links
+	rw.SpecialColors.FindMatch = s.SpecialColors.FindMatch
+	if r == nil {
+		return nil
+	}
+	r.recordWritten("Metadata.Name", rw.Metadata.Name, "Metadata.Name", false)
+	r.recordWritten("Metadata.Author", rw.Metadata.Author, "Metadata.Author", false)
+	r.recordWritten("Metadata.Date", rw.Metadata.Date, "Metadata.Date", false)
+	r.recordWritten("ScopeColors.Basic.Comment", rw.ScopeColors.Basic.Comment, "ScopeColors.Basic.Comment", false)
+	r.recordWritten("ScopeColors.Basic.Keyword", rw.ScopeColors.Basic.Keyword, "ScopeColors.Basic.Keyword", false)
+	r.recordWritten("ScopeColors.Basic.Constant", rw.ScopeColors.Basic.Constant, "ScopeColors.Basic.Constant", false)
+	r.recordWritten("ScopeColors.Basic.String", rw.ScopeColors.Basic.String, "ScopeColors.Basic.String", false)
+	r.recordWritten("ScopeColors.Basic.Number", rw.ScopeColors.Basic.Number, "ScopeColors.Basic.Number", false)
+	r.recordWritten("ScopeColors.Basic.Function", rw.ScopeColors.Basic.Function, "ScopeColors.Basic.Function", false)
+	r.recordWritten("ScopeColors.Basic.Variable", rw.ScopeColors.Basic.Variable, "ScopeColors.Basic.Variable", false)
+	r.recordWritten("ScopeColors.Basic.Operator", rw.ScopeColors.Basic.Operator, "ScopeColors.Basic.Operator", false)
+	r.recordWritten("ScopeColors.Advanced.Class", rw.ScopeColors.Advanced.Class, "ScopeColors.Advanced.Class", false)
+	r.recordWritten("ScopeColors.Advanced.Type", rw.ScopeColors.Advanced.Type, "ScopeColors.Advanced.Type", false)
+	r.recordWritten("ScopeColors.Advanced.Property", rw.ScopeColors.Advanced.Property, "ScopeColors.Advanced.Property", false)
+	r.recordWritten("ScopeColors.Advanced.Attribute", rw.ScopeColors.Advanced.Attribute, "ScopeColors.Advanced.Attribute", false)
+	r.recordWritten("ScopeColors.Advanced.Tag", rw.ScopeColors.Advanced.Tag, "ScopeColors.Advanced.Tag", false)
+	r.recordWritten("ScopeColors.Advanced.Namespace", rw.ScopeColors.Advanced.Namespace, "ScopeColors.Advanced.Namespace", false)
+	r.recordWritten("ScopeColors.Advanced.Parameter", rw.ScopeColors.Advanced.Parameter, "ScopeColors.Advanced.Parameter", false)
+	r.recordWritten("ScopeColors.Advanced.Selector", rw.ScopeColors.Advanced.Selector, "ScopeColors.Advanced.Selector", false)
+	r.recordWritten("ScopeColors.Markup.Heading", rw.ScopeColors.Markup.Heading, "ScopeColors.Markup.Heading", false)
+	r.recordWritten("ScopeColors.Markup.Bold", rw.ScopeColors.Markup.Bold, "ScopeColors.Markup.Bold", false)
+	r.recordWritten("ScopeColors.Markup.Italic", rw.ScopeColors.Markup.Italic, "ScopeColors.Markup.Italic", false)
+	r.recordWritten("ScopeColors.Markup.Underline", rw.ScopeColors.Markup.Underline, "ScopeColors.Markup.Underline", false)
+	r.recordWritten("ScopeColors.Markup.Link", rw.ScopeColors.Markup.Link, "ScopeColors.Markup.Link", false)
+	r.recordWritten("ScopeColors.Markup.Quote", rw.ScopeColors.Markup.Quote, "ScopeColors.Markup.Quote", false)
+	r.recordWritten("ScopeColors.Markup.List", rw.ScopeColors.Markup.List, "ScopeColors.Markup.List", false)
+	r.recordWritten("ScopeColors.Markup.CodeBlock", rw.ScopeColors.Markup.CodeBlock, "ScopeColors.Markup.CodeBlock", false)
+	r.recordWritten("ScopeColors.Markup.RawText", rw.ScopeColors.Markup.RawText, "ScopeColors.Markup.RawText", false)
+	r.recordWritten("ScopeColors.Markup.TemplateTag", rw.ScopeColors.Markup.TemplateTag, "ScopeColors.Markup.TemplateTag", false)
+	r.recordWritten("ScopeColors.Diagnostics.Invalid", rw.ScopeColors.Diagnostics.Invalid, "ScopeColors.Diagnostics.Invalid", false)
+	r.recordWritten("ScopeColors.Diagnostics.Deprecated", rw.ScopeColors.Diagnostics.Deprecated, "ScopeColors.Diagnostics.Deprecated", false)
+	r.recordWritten("ScopeColors.Editor.Cursor", rw.ScopeColors.Editor.Cursor, "ScopeColors.Editor.Cursor", false)
+	r.recordWritten("ScopeColors.Editor.CursorLine", rw.ScopeColors.Editor.CursorLine, "ScopeColors.Editor.CursorLine", false)
+	r.recordWritten("ScopeColors.Editor.LineNumbers", rw.ScopeColors.Editor.LineNumbers, "ScopeColors.Editor.LineNumbers", false)
+	r.recordWritten("ScopeColors.Editor.Highlight", rw.ScopeColors.Editor.Highlight, "ScopeColors.Editor.Highlight", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Meta", rw.ScopeColors.Miscellaneous.Meta, "ScopeColors.Miscellaneous.Meta", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Annotation", rw.ScopeColors.Miscellaneous.Annotation, "ScopeColors.Miscellaneous.Annotation", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Regex", rw.ScopeColors.Miscellaneous.Regex, "ScopeColors.Miscellaneous.Regex", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Background", rw.ScopeColors.Miscellaneous.Background, "ScopeColors.Miscellaneous.Background", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Foreground", rw.ScopeColors.Miscellaneous.Foreground, "ScopeColors.Miscellaneous.Foreground", false)
+	r.recordWritten("AnsiColors.Black", rw.AnsiColors.Black, "AnsiColors.Black", false)
+	r.recordWritten("AnsiColors.Red", rw.AnsiColors.Red, "AnsiColors.Red", false)
+	r.recordWritten("AnsiColors.Green", rw.AnsiColors.Green, "AnsiColors.Green", false)
+	r.recordWritten("AnsiColors.Yellow", rw.AnsiColors.Yellow, "AnsiColors.Yellow", false)
+	r.recordWritten("AnsiColors.Blue", rw.AnsiColors.Blue, "AnsiColors.Blue", false)
+	r.recordWritten("AnsiColors.Magenta", rw.AnsiColors.Magenta, "AnsiColors.Magenta", false)
+	r.recordWritten("AnsiColors.Cyan", rw.AnsiColors.Cyan, "AnsiColors.Cyan", false)
+	r.recordWritten("AnsiColors.White", rw.AnsiColors.White, "AnsiColors.White", false)
+	r.recordWritten("AnsiColors.BrightBlack", rw.AnsiColors.BrightBlack, "AnsiColors.BrightBlack", false)
+	r.recordWritten("AnsiColors.BrightRed", rw.AnsiColors.BrightRed, "AnsiColors.BrightRed", false)
+	r.recordWritten("AnsiColors.BrightGreen", rw.AnsiColors.BrightGreen, "AnsiColors.BrightGreen", false)
+	r.recordWritten("AnsiColors.BrightYellow", rw.AnsiColors.BrightYellow, "AnsiColors.BrightYellow", false)
+	r.recordWritten("AnsiColors.BrightBlue", rw.AnsiColors.BrightBlue, "AnsiColors.BrightBlue", false)
+	r.recordWritten("AnsiColors.BrightMagenta", rw.AnsiColors.BrightMagenta, "AnsiColors.BrightMagenta", false)
+	r.recordWritten("AnsiColors.BrightCyan", rw.AnsiColors.BrightCyan, "AnsiColors.BrightCyan", false)
+	r.recordWritten("AnsiColors.BrightWhite", rw.AnsiColors.BrightWhite, "AnsiColors.BrightWhite", false)
+	r.recordWritten("SpecialColors.Foreground", rw.SpecialColors.Foreground, "SpecialColors.Foreground", false)
+	r.recordWritten("SpecialColors.ForegroundBright", rw.SpecialColors.ForegroundBright, "SpecialColors.ForegroundBright", false)
+	r.recordWritten("SpecialColors.Background", rw.SpecialColors.Background, "SpecialColors.Background", false)
+	r.recordWritten("SpecialColors.Cursor", rw.SpecialColors.Cursor, "SpecialColors.Cursor", false)
+	r.recordWritten("SpecialColors.CursorText", rw.SpecialColors.CursorText, "SpecialColors.CursorText", false)
+	r.recordWritten("SpecialColors.Selection", rw.SpecialColors.Selection, "SpecialColors.Selection", false)
+	r.recordWritten("SpecialColors.SelectedText", rw.SpecialColors.SelectedText, "SpecialColors.SelectedText", false)
+	r.recordWritten("SpecialColors.Links", rw.SpecialColors.Links, "SpecialColors.Links", false)
+	r.recordWritten("SpecialColors.FindMatch", rw.SpecialColors.FindMatch, "SpecialColors.FindMatch", false)
+	return nil
+}
+
+func toAbstractNativeJSONScheme(rw *native.NativeJSONScheme, s *AbstractScheme, r *ConversionReport) error {
+	s.Metadata.Name = rw.Metadata.Name
+	s.Metadata.Author = rw.Metadata.Author
+	s.Metadata.Date = rw.Metadata.Date
+	s.ScopeColors.Basic.Comment = rw.ScopeColors.Basic.Comment
+	s.ScopeColors.Basic.Keyword = rw.ScopeColors.Basic.Keyword
+	s.ScopeColors.Basic.Constant = rw.ScopeColors.Basic.Constant
+	s.ScopeColors.Basic.String = rw.ScopeColors.Basic.String
+	s.ScopeColors.Basic.Number = rw.ScopeColors.Basic.Number
+	s.ScopeColors.Basic.Function = rw.ScopeColors.Basic.Function
+	s.ScopeColors.Basic.Variable = rw.ScopeColors.Basic.Variable
+	s.ScopeColors.Basic.Operator = rw.ScopeColors.Basic.Operator
+	s.ScopeColors.Advanced.Class = rw.ScopeColors.Advanced.Class
+	s.ScopeColors.Advanced.Type = rw.ScopeColors.Advanced.Type
+	s.ScopeColors.Advanced.Property = rw.ScopeColors.Advanced.Property
+	s.ScopeColors.Advanced.Attribute = rw.ScopeColors.Advanced.Attribute
+	s.ScopeColors.Advanced.Tag = rw.ScopeColors.Advanced.Tag
+	s.ScopeColors.Advanced.Namespace = rw.ScopeColors.Advanced.Namespace
+	s.ScopeColors.Advanced.Parameter = rw.ScopeColors.Advanced.Parameter
+	s.ScopeColors.Advanced.Selector = rw.ScopeColors.Advanced.Selector
+	s.ScopeColors.Markup.Heading = rw.ScopeColors.Markup.Heading
+	s.ScopeColors.Markup.Bold = rw.ScopeColors.Markup.Bold
+	s.ScopeColors.Markup.Italic = rw.ScopeColors.Markup.Italic
+	s.ScopeColors.Markup.Underline = rw.ScopeColors.Markup.Underline
+	s.ScopeColors.Markup.Link = rw.ScopeColors.Markup.Link
+	s.ScopeColors.Markup.Quote = rw.ScopeColors.Markup.Quote
+	s.ScopeColors.Markup.List = rw.ScopeColors.Markup.List
+	s.ScopeColors.Markup.CodeBlock = rw.ScopeColors.Markup.CodeBlock
+	s.ScopeColors.Markup.RawText = rw.ScopeColors.Markup.RawText
+	s.ScopeColors.Markup.TemplateTag = rw.ScopeColors.Markup.TemplateTag
+	s.ScopeColors.Diagnostics.Invalid = rw.ScopeColors.Diagnostics.Invalid
+	s.ScopeColors.Diagnostics.Deprecated = rw.ScopeColors.Diagnostics.Deprecated
+	s.ScopeColors.Editor.Cursor = rw.ScopeColors.Editor.Cursor
+	s.ScopeColors.Editor.CursorLine = rw.ScopeColors.Editor.CursorLine
+	s.ScopeColors.Editor.LineNumbers = rw.ScopeColors.Editor.LineNumbers
+	s.ScopeColors.Editor.Highlight = rw.ScopeColors.Editor.Highlight
+	s.ScopeColors.Miscellaneous.Meta = rw.ScopeColors.Miscellaneous.Meta
+	s.ScopeColors.Miscellaneous.Annotation = rw.ScopeColors.Miscellaneous.Annotation
+	s.ScopeColors.Miscellaneous.Regex = rw.ScopeColors.Miscellaneous.Regex
+	s.ScopeColors.Miscellaneous.Background = rw.ScopeColors.Miscellaneous.Background
+	s.ScopeColors.Miscellaneous.Foreground = rw.ScopeColors.Miscellaneous.Foreground
+	s.AnsiColors.Black = rw.AnsiColors.Black
+	s.AnsiColors.Red = rw.AnsiColors.Red
+	s.AnsiColors.Green = rw.AnsiColors.Green
+	s.AnsiColors.Yellow = rw.AnsiColors.Yellow
+	s.AnsiColors.Blue = rw.AnsiColors.Blue
+	s.AnsiColors.Magenta = rw.AnsiColors.Magenta
+	s.AnsiColors.Cyan = rw.AnsiColors.Cyan
+	s.AnsiColors.White = rw.AnsiColors.White
+	s.AnsiColors.BrightBlack = rw.AnsiColors.BrightBlack
+	s.AnsiColors.BrightRed = rw.AnsiColors.BrightRed
+	s.AnsiColors.BrightGreen = rw.AnsiColors.BrightGreen
+	s.AnsiColors.BrightYellow = rw.AnsiColors.BrightYellow
+	s.AnsiColors.BrightBlue = rw.AnsiColors.BrightBlue
+	s.AnsiColors.BrightMagenta = rw.AnsiColors.BrightMagenta
+	s.AnsiColors.BrightCyan = rw.AnsiColors.BrightCyan
+	s.AnsiColors.BrightWhite = rw.AnsiColors.BrightWhite
+	s.SpecialColors.Foreground = rw.SpecialColors.Foreground
+	s.SpecialColors.ForegroundBright = rw.SpecialColors.ForegroundBright
+	s.SpecialColors.Background = rw.SpecialColors.Background
+	s.SpecialColors.Cursor = rw.SpecialColors.Cursor
+	s.SpecialColors.CursorText = rw.SpecialColors.CursorText
+	s.SpecialColors.Selection = rw.SpecialColors.Selection
+	s.SpecialColors.SelectedText = rw.SpecialColors.SelectedText
+	s.SpecialColors.Links = rw.SpecialColors.Links
+	s.SpecialColors.FindMatch = rw.SpecialColors.FindMatch
+	return nil
+}
+
+func fromAbstractNativeJSONScheme(s *AbstractScheme, rw *native.NativeJSONScheme, r *ConversionReport) error {
+	rw.Metadata.Name = s.Metadata.Name
+	rw.Metadata.Author = s.Metadata.Author
+	rw.Metadata.Date = s.Metadata.Date
+	rw.ScopeColors.Basic.Comment = s.ScopeColors.Basic.Comment
+	rw.ScopeColors.Basic.Keyword = s.ScopeColors.Basic.Keyword
+	rw.ScopeColors.Basic.Constant = s.ScopeColors.Basic.Constant
+	rw.ScopeColors.Basic.String = s.ScopeColors.Basic.String
+	rw.ScopeColors.Basic.Number = s.ScopeColors.Basic.Number
+	rw.ScopeColors.Basic.Function = s.ScopeColors.Basic.Function
+	rw.ScopeColors.Basic.Variable = s.ScopeColors.Basic.Variable
+	rw.ScopeColors.Basic.Operator = s.ScopeColors.Basic.Operator
+	rw.ScopeColors.Advanced.Class = s.ScopeColors.Advanced.Class
+	rw.ScopeColors.Advanced.Type = s.ScopeColors.Advanced.Type
+	rw.ScopeColors.Advanced.Property = s.ScopeColors.Advanced.Property
+	rw.ScopeColors.Advanced.Attribute = s.ScopeColors.Advanced.Attribute
+	rw.ScopeColors.Advanced.Tag = s.ScopeColors.Advanced.Tag
+	rw.ScopeColors.Advanced.Namespace = s.ScopeColors.Advanced.Namespace
+	rw.ScopeColors.Advanced.Parameter = s.ScopeColors.Advanced.Parameter
+	rw.ScopeColors.Advanced.Selector = s.ScopeColors.Advanced.Selector
+	rw.ScopeColors.Markup.Heading = s.ScopeColors.Markup.Heading
+	rw.ScopeColors.Markup.Bold = s.ScopeColors.Markup.Bold
+	rw.ScopeColors.Markup.Italic = s.ScopeColors.Markup.Italic
+	rw.ScopeColors.Markup.Underline = s.ScopeColors.Markup.Underline
+	rw.ScopeColors.Markup.Link = s.ScopeColors.Markup.Link
+	rw.ScopeColors.Markup.Quote = s.ScopeColors.Markup.Quote
+	rw.ScopeColors.Markup.List = s.ScopeColors.Markup.List
+	rw.ScopeColors.Markup.CodeBlock = s.ScopeColors.Markup.CodeBlock
+	rw.ScopeColors.Markup.RawText = s.ScopeColors.Markup.RawText
+	rw.ScopeColors.Markup.TemplateTag = s.ScopeColors.Markup.TemplateTag
+	rw.ScopeColors.Diagnostics.Invalid = s.ScopeColors.Diagnostics.Invalid
+	rw.ScopeColors.Diagnostics.Deprecated = s.ScopeColors.Diagnostics.Deprecated
+	rw.ScopeColors.Editor.Cursor = s.ScopeColors.Editor.Cursor
+	rw.ScopeColors.Editor.CursorLine = s.ScopeColors.Editor.CursorLine
+	rw.ScopeColors.Editor.LineNumbers = s.ScopeColors.Editor.LineNumbers
+	rw.ScopeColors.Editor.Highlight = s.ScopeColors.Editor.Highlight
+	rw.ScopeColors.Miscellaneous.Meta = s.ScopeColors.Miscellaneous.Meta
+	rw.ScopeColors.Miscellaneous.Annotation = s.ScopeColors.Miscellaneous.Annotation
+	rw.ScopeColors.Miscellaneous.Regex = s.ScopeColors.Miscellaneous.Regex
+	rw.ScopeColors.Miscellaneous.Background = s.ScopeColors.Miscellaneous.Background
+	rw.ScopeColors.Miscellaneous.Foreground = s.ScopeColors.Miscellaneous.Foreground
+	rw.AnsiColors.Black = s.AnsiColors.Black
+	rw.AnsiColors.Red = s.AnsiColors.Red
+	rw.AnsiColors.Green = s.AnsiColors.Green
+	rw.AnsiColors.Yellow = s.AnsiColors.Yellow
+	rw.AnsiColors.Blue = s.AnsiColors.Blue
+	rw.AnsiColors.Magenta = s.AnsiColors.Magenta
+	rw.AnsiColors.Cyan = s.AnsiColors.Cyan
+	rw.AnsiColors.White = s.AnsiColors.White
+	rw.AnsiColors.BrightBlack = s.AnsiColors.BrightBlack
+	rw.AnsiColors.BrightRed = s.AnsiColors.BrightRed
+	rw.AnsiColors.BrightGreen = s.AnsiColors.BrightGreen
+	rw.AnsiColors.BrightYellow = s.AnsiColors.BrightYellow
+	rw.AnsiColors.BrightBlue = s.AnsiColors.BrightBlue
+	rw.AnsiColors.BrightMagenta = s.AnsiColors.BrightMagenta
+	rw.AnsiColors.BrightCyan = s.AnsiColors.BrightCyan
+	rw.AnsiColors.BrightWhite = s.AnsiColors.BrightWhite
+	rw.SpecialColors.Foreground = s.SpecialColors.Foreground
+	rw.SpecialColors.ForegroundBright = s.SpecialColors.ForegroundBright
+	rw.SpecialColors.Background = s.SpecialColors.Background
+	rw.SpecialColors.Cursor = s.SpecialColors.Cursor
+	rw.SpecialColors.CursorText = s.SpecialColors.CursorText
+	rw.SpecialColors.Selection = s.SpecialColors.Selection
+	rw.SpecialColors.SelectedText = s.SpecialColors.SelectedText
+	rw.SpecialColors.Links = s.SpecialColors.Links
+	rw.SpecialColors.FindMatch = s.SpecialColors.FindMatch
+	if r == nil {
+		return nil
+	}
+	r.recordWritten("Metadata.Name", rw.Metadata.Name, "Metadata.Name", false)
+	r.recordWritten("Metadata.Author", rw.Metadata.Author, "Metadata.Author", false)
+	r.recordWritten("Metadata.Date", rw.Metadata.Date, "Metadata.Date", false)
+	r.recordWritten("ScopeColors.Basic.Comment", rw.ScopeColors.Basic.Comment, "ScopeColors.Basic.Comment", false)
+	r.recordWritten("ScopeColors.Basic.Keyword", rw.ScopeColors.Basic.Keyword, "ScopeColors.Basic.Keyword", false)
+	r.recordWritten("ScopeColors.Basic.Constant", rw.ScopeColors.Basic.Constant, "ScopeColors.Basic.Constant", false)
+	r.recordWritten("ScopeColors.Basic.String", rw.ScopeColors.Basic.String, "ScopeColors.Basic.String", false)
+	r.recordWritten("ScopeColors.Basic.Number", rw.ScopeColors.Basic.Number, "ScopeColors.Basic.Number", false)
+	r.recordWritten("ScopeColors.Basic.Function", rw.ScopeColors.Basic.Function, "ScopeColors.Basic.Function", false)
+	r.recordWritten("ScopeColors.Basic.Variable", rw.ScopeColors.Basic.Variable, "ScopeColors.Basic.Variable", false)
+	r.recordWritten("ScopeColors.Basic.Operator", rw.ScopeColors.Basic.Operator, "ScopeColors.Basic.Operator", false)
+	r.recordWritten("ScopeColors.Advanced.Class", rw.ScopeColors.Advanced.Class, "ScopeColors.Advanced.Class", false)
+	r.recordWritten("ScopeColors.Advanced.Type", rw.ScopeColors.Advanced.Type, "ScopeColors.Advanced.Type", false)
+	r.recordWritten("ScopeColors.Advanced.Property", rw.ScopeColors.Advanced.Property, "ScopeColors.Advanced.Property", false)
+	r.recordWritten("ScopeColors.Advanced.Attribute", rw.ScopeColors.Advanced.Attribute, "ScopeColors.Advanced.Attribute", false)
+	r.recordWritten("ScopeColors.Advanced.Tag", rw.ScopeColors.Advanced.Tag, "ScopeColors.Advanced.Tag", false)
+	r.recordWritten("ScopeColors.Advanced.Namespace", rw.ScopeColors.Advanced.Namespace, "ScopeColors.Advanced.Namespace", false)
+	r.recordWritten("ScopeColors.Advanced.Parameter", rw.ScopeColors.Advanced.Parameter, "ScopeColors.Advanced.Parameter", false)
+	r.recordWritten("ScopeColors.Advanced.Selector", rw.ScopeColors.Advanced.Selector, "ScopeColors.Advanced.Selector", false)
+	r.recordWritten("ScopeColors.Markup.Heading", rw.ScopeColors.Markup.Heading, "ScopeColors.Markup.Heading", false)
+	r.recordWritten("ScopeColors.Markup.Bold", rw.ScopeColors.Markup.Bold, "ScopeColors.Markup.Bold", false)
+	r.recordWritten("ScopeColors.Markup.Italic", rw.ScopeColors.Markup.Italic, "ScopeColors.Markup.Italic", false)
+	r.recordWritten("ScopeColors.Markup.Underline", rw.ScopeColors.Markup.Underline, "ScopeColors.Markup.Underline", false)
+	r.recordWritten("ScopeColors.Markup.Link", rw.ScopeColors.Markup.Link, "ScopeColors.Markup.Link", false)
+	r.recordWritten("ScopeColors.Markup.Quote", rw.ScopeColors.Markup.Quote, "ScopeColors.Markup.Quote", false)
+	r.recordWritten("ScopeColors.Markup.List", rw.ScopeColors.Markup.List, "ScopeColors.Markup.List", false)
+	r.recordWritten("ScopeColors.Markup.CodeBlock", rw.ScopeColors.Markup.CodeBlock, "ScopeColors.Markup.CodeBlock", false)
+	r.recordWritten("ScopeColors.Markup.RawText", rw.ScopeColors.Markup.RawText, "ScopeColors.Markup.RawText", false)
+	r.recordWritten("ScopeColors.Markup.TemplateTag", rw.ScopeColors.Markup.TemplateTag, "ScopeColors.Markup.TemplateTag", false)
+	r.recordWritten("ScopeColors.Diagnostics.Invalid", rw.ScopeColors.Diagnostics.Invalid, "ScopeColors.Diagnostics.Invalid", false)
+	r.recordWritten("ScopeColors.Diagnostics.Deprecated", rw.ScopeColors.Diagnostics.Deprecated, "ScopeColors.Diagnostics.Deprecated", false)
+	r.recordWritten("ScopeColors.Editor.Cursor", rw.ScopeColors.Editor.Cursor, "ScopeColors.Editor.Cursor", false)
+	r.recordWritten("ScopeColors.Editor.CursorLine", rw.ScopeColors.Editor.CursorLine, "ScopeColors.Editor.CursorLine", false)
+	r.recordWritten("ScopeColors.Editor.LineNumbers", rw.ScopeColors.Editor.LineNumbers, "ScopeColors.Editor.LineNumbers", false)
+	r.recordWritten("ScopeColors.Editor.Highlight", rw.ScopeColors.Editor.Highlight, "ScopeColors.Editor.Highlight", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Meta", rw.ScopeColors.Miscellaneous.Meta, "ScopeColors.Miscellaneous.Meta", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Annotation", rw.ScopeColors.Miscellaneous.Annotation, "ScopeColors.Miscellaneous.Annotation", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Regex", rw.ScopeColors.Miscellaneous.Regex, "ScopeColors.Miscellaneous.Regex", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Background", rw.ScopeColors.Miscellaneous.Background, "ScopeColors.Miscellaneous.Background", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Foreground", rw.ScopeColors.Miscellaneous.Foreground, "ScopeColors.Miscellaneous.Foreground", false)
+	r.recordWritten("AnsiColors.Black", rw.AnsiColors.Black, "AnsiColors.Black", false)
+	r.recordWritten("AnsiColors.Red", rw.AnsiColors.Red, "AnsiColors.Red", false)
+	r.recordWritten("AnsiColors.Green", rw.AnsiColors.Green, "AnsiColors.Green", false)
+	r.recordWritten("AnsiColors.Yellow", rw.AnsiColors.Yellow, "AnsiColors.Yellow", false)
+	r.recordWritten("AnsiColors.Blue", rw.AnsiColors.Blue, "AnsiColors.Blue", false)
+	r.recordWritten("AnsiColors.Magenta", rw.AnsiColors.Magenta, "AnsiColors.Magenta", false)
+	r.recordWritten("AnsiColors.Cyan", rw.AnsiColors.Cyan, "AnsiColors.Cyan", false)
+	r.recordWritten("AnsiColors.White", rw.AnsiColors.White, "AnsiColors.White", false)
+	r.recordWritten("AnsiColors.BrightBlack", rw.AnsiColors.BrightBlack, "AnsiColors.BrightBlack", false)
+	r.recordWritten("AnsiColors.BrightRed", rw.AnsiColors.BrightRed, "AnsiColors.BrightRed", false)
+	r.recordWritten("AnsiColors.BrightGreen", rw.AnsiColors.BrightGreen, "AnsiColors.BrightGreen", false)
+	r.recordWritten("AnsiColors.BrightYellow", rw.AnsiColors.BrightYellow, "AnsiColors.BrightYellow", false)
+	r.recordWritten("AnsiColors.BrightBlue", rw.AnsiColors.BrightBlue, "AnsiColors.BrightBlue", false)
+	r.recordWritten("AnsiColors.BrightMagenta", rw.AnsiColors.BrightMagenta, "AnsiColors.BrightMagenta", false)
+	r.recordWritten("AnsiColors.BrightCyan", rw.AnsiColors.BrightCyan, "AnsiColors.BrightCyan", false)
+	r.recordWritten("AnsiColors.BrightWhite", rw.AnsiColors.BrightWhite, "AnsiColors.BrightWhite", false)
+	r.recordWritten("SpecialColors.Foreground", rw.SpecialColors.Foreground, "SpecialColors.Foreground", false)
+	r.recordWritten("SpecialColors.ForegroundBright", rw.SpecialColors.ForegroundBright, "SpecialColors.ForegroundBright", false)
+	r.recordWritten("SpecialColors.Background", rw.SpecialColors.Background, "SpecialColors.Background", false)
+	r.recordWritten("SpecialColors.Cursor", rw.SpecialColors.Cursor, "SpecialColors.Cursor", false)
+	r.recordWritten("SpecialColors.CursorText", rw.SpecialColors.CursorText, "SpecialColors.CursorText", false)
+	r.recordWritten("SpecialColors.Selection", rw.SpecialColors.Selection, "SpecialColors.Selection", false)
+	r.recordWritten("SpecialColors.SelectedText", rw.SpecialColors.SelectedText, "SpecialColors.SelectedText", false)
+	r.recordWritten("SpecialColors.Links", rw.SpecialColors.Links, "SpecialColors.Links", false)
+	r.recordWritten("SpecialColors.FindMatch", rw.SpecialColors.FindMatch, "SpecialColors.FindMatch", false)
+	return nil
+}
+
+func toAbstractNativeTOMLScheme(rw *native.NativeTOMLScheme, s *AbstractScheme, r *ConversionReport) error {
+	s.Metadata.Name = rw.Metadata.Name
+	s.Metadata.Author = rw.Metadata.Author
+	s.Metadata.Date = rw.Metadata.Date
+	s.ScopeColors.Basic.Comment = rw.ScopeColors.Basic.Comment
+	s.ScopeColors.Basic.Keyword = rw.ScopeColors.Basic.Keyword
+	s.ScopeColors.Basic.Constant = rw.ScopeColors.Basic.Constant
+	s.ScopeColors.Basic.String = rw.ScopeColors.Basic.String
+	s.ScopeColors.Basic.Number = rw.ScopeColors.Basic.Number
+	s.ScopeColors.Basic.Function = rw.ScopeColors.Basic.Function
+	s.ScopeColors.Basic.Variable = rw.ScopeColors.Basic.Variable
+	s.ScopeColors.Basic.Operator = rw.ScopeColors.Basic.Operator
+	s.ScopeColors.Advanced.Class = rw.ScopeColors.Advanced.Class
+	s.ScopeColors.Advanced.Type = rw.ScopeColors.Advanced.Type
+	s.ScopeColors.Advanced.Property = rw.ScopeColors.Advanced.Property
+	s.ScopeColors.Advanced.Attribute = rw.ScopeColors.Advanced.Attribute
+	s.ScopeColors.Advanced.Tag = rw.ScopeColors.Advanced.Tag
+	s.ScopeColors.Advanced.Namespace = rw.ScopeColors.Advanced.Namespace
+	s.ScopeColors.Advanced.Parameter = rw.ScopeColors.Advanced.Parameter
+	s.ScopeColors.Advanced.Selector = rw.ScopeColors.Advanced.Selector
+	s.ScopeColors.Markup.Heading = rw.ScopeColors.Markup.Heading
+	s.ScopeColors.Markup.Bold = rw.ScopeColors.Markup.Bold
+	s.ScopeColors.Markup.Italic = rw.ScopeColors.Markup.Italic
+	s.ScopeColors.Markup.Underline = rw.ScopeColors.Markup.Underline
+	s.ScopeColors.Markup.Link = rw.ScopeColors.Markup.Link
+	s.ScopeColors.Markup.Quote = rw.ScopeColors.Markup.Quote
+	s.ScopeColors.Markup.List = rw.ScopeColors.Markup.List
+	s.ScopeColors.Markup.CodeBlock = rw.ScopeColors.Markup.CodeBlock
+	s.ScopeColors.Markup.RawText = rw.ScopeColors.Markup.RawText
+	s.ScopeColors.Markup.TemplateTag = rw.ScopeColors.Markup.TemplateTag
+	s.ScopeColors.Diagnostics.Invalid = rw.ScopeColors.Diagnostics.Invalid
+	s.ScopeColors.Diagnostics.Deprecated = rw.ScopeColors.Diagnostics.Deprecated
+	s.ScopeColors.Editor.Cursor = rw.ScopeColors.Editor.Cursor
+	s.ScopeColors.Editor.CursorLine = rw.ScopeColors.Editor.CursorLine
+	s.ScopeColors.Editor.LineNumbers = rw.ScopeColors.Editor.LineNumbers
+	s.ScopeColors.Editor.Highlight = rw.ScopeColors.Editor.Highlight
+	s.ScopeColors.Miscellaneous.Meta = rw.ScopeColors.Miscellaneous.Meta
+	s.ScopeColors.Miscellaneous.Annotation = rw.ScopeColors.Miscellaneous.Annotation
+	s.ScopeColors.Miscellaneous.Regex = rw.ScopeColors.Miscellaneous.Regex
+	s.ScopeColors.Miscellaneous.Background = rw.ScopeColors.Miscellaneous.Background
+	s.ScopeColors.Miscellaneous.Foreground = rw.ScopeColors.Miscellaneous.Foreground
+	s.AnsiColors.Black = rw.AnsiColors.Black
+	s.AnsiColors.Red = rw.AnsiColors.Red
+	s.AnsiColors.Green = rw.AnsiColors.Green
+	s.AnsiColors.Yellow = rw.AnsiColors.Yellow
+	s.AnsiColors.Blue = rw.AnsiColors.Blue
+	s.AnsiColors.Magenta = rw.AnsiColors.Magenta
+	s.AnsiColors.Cyan = rw.AnsiColors.Cyan
+	s.AnsiColors.White = rw.AnsiColors.White
+	s.AnsiColors.BrightBlack = rw.AnsiColors.BrightBlack
+	s.AnsiColors.BrightRed = rw.AnsiColors.BrightRed
+	s.AnsiColors.BrightGreen = rw.AnsiColors.BrightGreen
+	s.AnsiColors.BrightYellow = rw.AnsiColors.BrightYellow
+	s.AnsiColors.BrightBlue = rw.AnsiColors.BrightBlue
+	s.AnsiColors.BrightMagenta = rw.AnsiColors.BrightMagenta
+	s.AnsiColors.BrightCyan = rw.AnsiColors.BrightCyan
+	s.AnsiColors.BrightWhite = rw.AnsiColors.BrightWhite
+	s.SpecialColors.Foreground = rw.SpecialColors.Foreground
+	s.SpecialColors.ForegroundBright = rw.SpecialColors.ForegroundBright
+	s.SpecialColors.Background = rw.SpecialColors.Background
+	s.SpecialColors.Cursor = rw.SpecialColors.Cursor
+	s.SpecialColors.CursorText = rw.SpecialColors.CursorText
+	s.SpecialColors.Selection = rw.SpecialColors.Selection
+	s.SpecialColors.SelectedText = rw.SpecialColors.SelectedText
+	s.SpecialColors.Links = rw.SpecialColors.Links
+	s.SpecialColors.FindMatch = rw.SpecialColors.FindMatch
+	return nil
+}
+
+func fromAbstractNativeTOMLScheme(s *AbstractScheme, rw *native.NativeTOMLScheme, r *ConversionReport) error {
+	rw.Metadata.Name = s.Metadata.Name
+	rw.Metadata.Author = s.Metadata.Author
+	rw.Metadata.Date = s.Metadata.Date
+	rw.ScopeColors.Basic.Comment = s.ScopeColors.Basic.Comment
+	rw.ScopeColors.Basic.Keyword = s.ScopeColors.Basic.Keyword
+	rw.ScopeColors.Basic.Constant = s.ScopeColors.Basic.Constant
+	rw.ScopeColors.Basic.String = s.ScopeColors.Basic.String
+	rw.ScopeColors.Basic.Number = s.ScopeColors.Basic.Number
+	rw.ScopeColors.Basic.Function = s.ScopeColors.Basic.Function
+	rw.ScopeColors.Basic.Variable = s.ScopeColors.Basic.Variable
+	rw.ScopeColors.Basic.Operator = s.ScopeColors.Basic.Operator
+	rw.ScopeColors.Advanced.Class = s.ScopeColors.Advanced.Class
+	rw.ScopeColors.Advanced.Type = s.ScopeColors.Advanced.Type
+	rw.ScopeColors.Advanced.Property = s.ScopeColors.Advanced.Property
+	rw.ScopeColors.Advanced.Attribute = s.ScopeColors.Advanced.Attribute
+	rw.ScopeColors.Advanced.Tag = s.ScopeColors.Advanced.Tag
+	rw.ScopeColors.Advanced.Namespace = s.ScopeColors.Advanced.Namespace
+	rw.ScopeColors.Advanced.Parameter = s.ScopeColors.Advanced.Parameter
+	rw.ScopeColors.Advanced.Selector = s.ScopeColors.Advanced.Selector
+	rw.ScopeColors.Markup.Heading = s.ScopeColors.Markup.Heading
+	rw.ScopeColors.Markup.Bold = s.ScopeColors.Markup.Bold
+	rw.ScopeColors.Markup.Italic = s.ScopeColors.Markup.Italic
+	rw.ScopeColors.Markup.Underline = s.ScopeColors.Markup.Underline
+	rw.ScopeColors.Markup.Link = s.ScopeColors.Markup.Link
+	rw.ScopeColors.Markup.Quote = s.ScopeColors.Markup.Quote
+	rw.ScopeColors.Markup.List = s.ScopeColors.Markup.List
+	rw.ScopeColors.Markup.CodeBlock = s.ScopeColors.Markup.CodeBlock
+	rw.ScopeColors.Markup.RawText = s.ScopeColors.Markup.RawText
+	rw.ScopeColors.Markup.TemplateTag = s.ScopeColors.Markup.TemplateTag
+	rw.ScopeColors.Diagnostics.Invalid = s.ScopeColors.Diagnostics.Invalid
+	rw.ScopeColors.Diagnostics.Deprecated = s.ScopeColors.Diagnostics.Deprecated
+	rw.ScopeColors.Editor.Cursor = s.ScopeColors.Editor.Cursor
+	rw.ScopeColors.Editor.CursorLine = s.ScopeColors.Editor.CursorLine
+	rw.ScopeColors.Editor.LineNumbers = s.ScopeColors.Editor.LineNumbers
+	rw.ScopeColors.Editor.Highlight = s.ScopeColors.Editor.Highlight
+	rw.ScopeColors.Miscellaneous.Meta = s.ScopeColors.Miscellaneous.Meta
+	rw.ScopeColors.Miscellaneous.Annotation = s.ScopeColors.Miscellaneous.Annotation
+	rw.ScopeColors.Miscellaneous.Regex = s.ScopeColors.Miscellaneous.Regex
+	rw.ScopeColors.Miscellaneous.Background = s.ScopeColors.Miscellaneous.Background
+	rw.ScopeColors.Miscellaneous.Foreground = s.ScopeColors.Miscellaneous.Foreground
+	rw.AnsiColors.Black = s.AnsiColors.Black
+	rw.AnsiColors.Red = s.AnsiColors.Red
+	rw.AnsiColors.Green = s.AnsiColors.Green
+	rw.AnsiColors.Yellow = s.AnsiColors.Yellow
+	rw.AnsiColors.Blue = s.AnsiColors.Blue
+	rw.AnsiColors.Magenta = s.AnsiColors.Magenta
+	rw.AnsiColors.Cyan = s.AnsiColors.Cyan
+	rw.AnsiColors.White = s.AnsiColors.White
+	rw.AnsiColors.BrightBlack = s.AnsiColors.BrightBlack
+	rw.AnsiColors.BrightRed = s.AnsiColors.BrightRed
+	rw.AnsiColors.BrightGreen = s.AnsiColors.BrightGreen
+	rw.AnsiColors.BrightYellow = s.AnsiColors.BrightYellow
+	rw.AnsiColors.BrightBlue = s.AnsiColors.BrightBlue
+	rw.AnsiColors.BrightMagenta = s.AnsiColors.BrightMagenta
+	rw.AnsiColors.BrightCyan = s.AnsiColors.BrightCyan
+	rw.AnsiColors.BrightWhite = s.AnsiColors.BrightWhite
+	rw.SpecialColors.Foreground = s.SpecialColors.Foreground
+	rw.SpecialColors.ForegroundBright = s.SpecialColors.ForegroundBright
+	rw.SpecialColors.Background = s.SpecialColors.Background
+	rw.SpecialColors.Cursor = s.SpecialColors.Cursor
+	rw.SpecialColors.CursorText = s.SpecialColors.CursorText
+	rw.SpecialColors.Selection = s.SpecialColors.Selection
+	rw.SpecialColors.SelectedText = s.SpecialColors.SelectedText
+	rw.SpecialColors.Links = s.SpecialColors.Links
+	rw.SpecialColors.FindMatch = s.SpecialColors.FindMatch
+	if r == nil {
+		return nil
+	}
+	r.recordWritten("Metadata.Name", rw.Metadata.Name, "Metadata.Name", false)
+	r.recordWritten("Metadata.Author", rw.Metadata.Author, "Metadata.Author", false)
+	r.recordWritten("Metadata.Date", rw.Metadata.Date, "Metadata.Date", false)
+	r.recordWritten("ScopeColors.Basic.Comment", rw.ScopeColors.Basic.Comment, "ScopeColors.Basic.Comment", false)
+	r.recordWritten("ScopeColors.Basic.Keyword", rw.ScopeColors.Basic.Keyword, "ScopeColors.Basic.Keyword", false)
+	r.recordWritten("ScopeColors.Basic.Constant", rw.ScopeColors.Basic.Constant, "ScopeColors.Basic.Constant", false)
+	r.recordWritten("ScopeColors.Basic.String", rw.ScopeColors.Basic.String, "ScopeColors.Basic.String", false)
+	r.recordWritten("ScopeColors.Basic.Number", rw.ScopeColors.Basic.Number, "ScopeColors.Basic.Number", false)
+	r.recordWritten("ScopeColors.Basic.Function", rw.ScopeColors.Basic.Function, "ScopeColors.Basic.Function", false)
+	r.recordWritten("ScopeColors.Basic.Variable", rw.ScopeColors.Basic.Variable, "ScopeColors.Basic.Variable", false)
+	r.recordWritten("ScopeColors.Basic.Operator", rw.ScopeColors.Basic.Operator, "ScopeColors.Basic.Operator", false)
+	r.recordWritten("ScopeColors.Advanced.Class", rw.ScopeColors.Advanced.Class, "ScopeColors.Advanced.Class", false)
+	r.recordWritten("ScopeColors.Advanced.Type", rw.ScopeColors.Advanced.Type, "ScopeColors.Advanced.Type", false)
+	r.recordWritten("ScopeColors.Advanced.Property", rw.ScopeColors.Advanced.Property, "ScopeColors.Advanced.Property", false)
+	r.recordWritten("ScopeColors.Advanced.Attribute", rw.ScopeColors.Advanced.Attribute, "ScopeColors.Advanced.Attribute", false)
+	r.recordWritten("ScopeColors.Advanced.Tag", rw.ScopeColors.Advanced.Tag, "ScopeColors.Advanced.Tag", false)
+	r.recordWritten("ScopeColors.Advanced.Namespace", rw.ScopeColors.Advanced.Namespace, "ScopeColors.Advanced.Namespace", false)
+	r.recordWritten("ScopeColors.Advanced.Parameter", rw.ScopeColors.Advanced.Parameter, "ScopeColors.Advanced.Parameter", false)
+	r.recordWritten("ScopeColors.Advanced.Selector", rw.ScopeColors.Advanced.Selector, "ScopeColors.Advanced.Selector", false)
+	r.recordWritten("ScopeColors.Markup.Heading", rw.ScopeColors.Markup.Heading, "ScopeColors.Markup.Heading", false)
+	r.recordWritten("ScopeColors.Markup.Bold", rw.ScopeColors.Markup.Bold, "ScopeColors.Markup.Bold", false)
+	r.recordWritten("ScopeColors.Markup.Italic", rw.ScopeColors.Markup.Italic, "ScopeColors.Markup.Italic", false)
+	r.recordWritten("ScopeColors.Markup.Underline", rw.ScopeColors.Markup.Underline, "ScopeColors.Markup.Underline", false)
+	r.recordWritten("ScopeColors.Markup.Link", rw.ScopeColors.Markup.Link, "ScopeColors.Markup.Link", false)
+	r.recordWritten("ScopeColors.Markup.Quote", rw.ScopeColors.Markup.Quote, "ScopeColors.Markup.Quote", false)
+	r.recordWritten("ScopeColors.Markup.List", rw.ScopeColors.Markup.List, "ScopeColors.Markup.List", false)
+	r.recordWritten("ScopeColors.Markup.CodeBlock", rw.ScopeColors.Markup.CodeBlock, "ScopeColors.Markup.CodeBlock", false)
+	r.recordWritten("ScopeColors.Markup.RawText", rw.ScopeColors.Markup.RawText, "ScopeColors.Markup.RawText", false)
+	r.recordWritten("ScopeColors.Markup.TemplateTag", rw.ScopeColors.Markup.TemplateTag, "ScopeColors.Markup.TemplateTag", false)
+	r.recordWritten("ScopeColors.Diagnostics.Invalid", rw.ScopeColors.Diagnostics.Invalid, "ScopeColors.Diagnostics.Invalid", false)
+	r.recordWritten("ScopeColors.Diagnostics.Deprecated", rw.ScopeColors.Diagnostics.Deprecated, "ScopeColors.Diagnostics.Deprecated", false)
+	r.recordWritten("ScopeColors.Editor.Cursor", rw.ScopeColors.Editor.Cursor, "ScopeColors.Editor.Cursor", false)
+	r.recordWritten("ScopeColors.Editor.CursorLine", rw.ScopeColors.Editor.CursorLine, "ScopeColors.Editor.CursorLine", false)
+	r.recordWritten("ScopeColors.Editor.LineNumbers", rw.ScopeColors.Editor.LineNumbers, "ScopeColors.Editor.LineNumbers", false)
+	r.recordWritten("ScopeColors.Editor.Highlight", rw.ScopeColors.Editor.Highlight, "ScopeColors.Editor.Highlight", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Meta", rw.ScopeColors.Miscellaneous.Meta, "ScopeColors.Miscellaneous.Meta", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Annotation", rw.ScopeColors.Miscellaneous.Annotation, "ScopeColors.Miscellaneous.Annotation", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Regex", rw.ScopeColors.Miscellaneous.Regex, "ScopeColors.Miscellaneous.Regex", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Background", rw.ScopeColors.Miscellaneous.Background, "ScopeColors.Miscellaneous.Background", false)
+	r.recordWritten("ScopeColors.Miscellaneous.Foreground", rw.ScopeColors.Miscellaneous.Foreground, "ScopeColors.Miscellaneous.Foreground", false)
+	r.recordWritten("AnsiColors.Black", rw.AnsiColors.Black, "AnsiColors.Black", false)
+	r.recordWritten("AnsiColors.Red", rw.AnsiColors.Red, "AnsiColors.Red", false)
+	r.recordWritten("AnsiColors.Green", rw.AnsiColors.Green, "AnsiColors.Green", false)
+	r.recordWritten("AnsiColors.Yellow", rw.AnsiColors.Yellow, "AnsiColors.Yellow", false)
+	r.recordWritten("AnsiColors.Blue", rw.AnsiColors.Blue, "AnsiColors.Blue", false)
+	r.recordWritten("AnsiColors.Magenta", rw.AnsiColors.Magenta, "AnsiColors.Magenta", false)
+	r.recordWritten("AnsiColors.Cyan", rw.AnsiColors.Cyan, "AnsiColors.Cyan", false)
+	r.recordWritten("AnsiColors.White", rw.AnsiColors.White, "AnsiColors.White", false)
+	r.recordWritten("AnsiColors.BrightBlack", rw.AnsiColors.BrightBlack, "AnsiColors.BrightBlack", false)
+	r.recordWritten("AnsiColors.BrightRed", rw.AnsiColors.BrightRed, "AnsiColors.BrightRed", false)
+	r.recordWritten("AnsiColors.BrightGreen", rw.AnsiColors.BrightGreen, "AnsiColors.BrightGreen", false)
+	r.recordWritten("AnsiColors.BrightYellow", rw.AnsiColors.BrightYellow, "AnsiColors.BrightYellow", false)
+	r.recordWritten("AnsiColors.BrightBlue", rw.AnsiColors.BrightBlue, "AnsiColors.BrightBlue", false)
+	r.recordWritten("AnsiColors.BrightMagenta", rw.AnsiColors.BrightMagenta, "AnsiColors.BrightMagenta", false)
+	r.recordWritten("AnsiColors.BrightCyan", rw.AnsiColors.BrightCyan, "AnsiColors.BrightCyan", false)
+	r.recordWritten("AnsiColors.BrightWhite", rw.AnsiColors.BrightWhite, "AnsiColors.BrightWhite", false)
+	r.recordWritten("SpecialColors.Foreground", rw.SpecialColors.Foreground, "SpecialColors.Foreground", false)
+	r.recordWritten("SpecialColors.ForegroundBright", rw.SpecialColors.ForegroundBright, "SpecialColors.ForegroundBright", false)
+	r.recordWritten("SpecialColors.Background", rw.SpecialColors.Background, "SpecialColors.Background", false)
+	r.recordWritten("SpecialColors.Cursor", rw.SpecialColors.Cursor, "SpecialColors.Cursor", false)
+	r.recordWritten("SpecialColors.CursorText", rw.SpecialColors.CursorText, "SpecialColors.CursorText", false)
+	r.recordWritten("SpecialColors.Selection", rw.SpecialColors.Selection, "SpecialColors.Selection", false)
+	r.recordWritten("SpecialColors.SelectedText", rw.SpecialColors.SelectedText, "SpecialColors.SelectedText", false)
+	r.recordWritten("SpecialColors.Links", rw.SpecialColors.Links, "SpecialColors.Links", false)
+	r.recordWritten("SpecialColors.FindMatch", rw.SpecialColors.FindMatch, "SpecialColors.FindMatch", false)
+	return nil
+}
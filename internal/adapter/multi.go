@@ -0,0 +1,164 @@
+package adapter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MultiAdapter is implemented by adapters whose documents can hold a
+// collection of schemes, like the schemes of a Windows Terminal settings.json
+// or the profiles of a Terminator config
+type MultiAdapter interface {
+	Adapter
+	// Split the document into one document per scheme, each of which
+	// FromString accepts, in the order they appear, along with the name of
+	// each scheme in the document, or "" if it has none
+	SplitSchemes(input string) (names []string, docs []string, err error)
+	// Return path to the template combining rendered schemes into a single
+	// document. It is executed with a Bundle.
+	BundleTemplateName() string
+}
+
+// Bundle is the data of a bundle template
+type Bundle struct {
+	Schemes []string // Each scheme rendered by its own template
+}
+
+// SchemeEntry describes a single scheme of a document
+type SchemeEntry struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"` // Empty if the scheme has no name
+}
+
+// GetMultiAdapter returns the adapter as a MultiAdapter, or an error naming
+// the format if its documents hold a single scheme
+func GetMultiAdapter(ad Adapter) (MultiAdapter, error) {
+	multi, ok := ad.(MultiAdapter)
+	if !ok {
+		return nil, fmt.Errorf("%s documents hold a single scheme", ad.Name())
+	}
+	return multi, nil
+}
+
+// ReadSchemes parses every scheme of the document, each into a fresh
+// instance of the reader
+func ReadSchemes(input string, reader MultiAdapter) ([]Adapter, error) {
+	_, docs, err := reader.SplitSchemes(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split %s document: %w", reader.Name(), err)
+	}
+
+	schemes := make([]Adapter, len(docs))
+	for i, doc := range docs {
+		scheme := newAdapterInstance(reader)
+		if err := scheme.FromString(doc); err != nil {
+			return nil, fmt.Errorf("scheme %d: %w", i, err)
+		}
+		schemes[i] = scheme
+	}
+	return schemes, nil
+}
+
+// ListSchemes lists the schemes of the document in order
+func ListSchemes(input string, reader MultiAdapter) ([]SchemeEntry, error) {
+	names, _, err := reader.SplitSchemes(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split %s document: %w", reader.Name(), err)
+	}
+	entries := make([]SchemeEntry, len(names))
+	for i, name := range names {
+		entries[i] = SchemeEntry{Index: i, Name: name}
+	}
+	return entries, nil
+}
+
+// ExtractScheme returns the document of a single scheme, selected by name or
+// else by its index from 0, ready to be read by the reader or converted
+func ExtractScheme(input string, reader MultiAdapter, ref string) (string, error) {
+	names, docs, err := reader.SplitSchemes(input)
+	if err != nil {
+		return "", fmt.Errorf("failed to split %s document: %w", reader.Name(), err)
+	}
+
+	for i, name := range names {
+		if name == ref {
+			return docs[i], nil
+		}
+	}
+	if i, err := strconv.Atoi(ref); err == nil && i >= 0 && i < len(docs) {
+		return docs[i], nil
+	}
+	return "", fmt.Errorf("no scheme %q, available: %s", ref, describeSchemes(names))
+}
+
+// RenderBundle renders the schemes, which must all be of the same format, into
+// a single document
+func RenderBundle(schemes []Adapter) (string, error) {
+	return RenderBundleWith(schemes, ConvertOptions{})
+}
+
+// RenderBundleWith is RenderBundle with the templates and missing color policy
+// of the options
+func RenderBundleWith(schemes []Adapter, opts ConvertOptions) (string, error) {
+	if len(schemes) == 0 {
+		return "", fmt.Errorf("no schemes to bundle")
+	}
+	multi, err := GetMultiAdapter(schemes[0])
+	if err != nil {
+		return "", err
+	}
+
+	bundle := Bundle{Schemes: make([]string, len(schemes))}
+	for i, scheme := range schemes {
+		if scheme.Name() != multi.Name() {
+			return "", fmt.Errorf("cannot bundle %s scheme with %s schemes", scheme.Name(), multi.Name())
+		}
+		rendered, err := RenderAdapterWith(scheme, opts)
+		if err != nil {
+			return "", err
+		}
+		bundle.Schemes[i] = strings.TrimRight(rendered, "\n")
+	}
+	return renderTemplate(opts, multi.BundleTemplateName(), bundle)
+}
+
+// ConvertBundleWith converts every scheme of the input document and bundles
+// the results into a single document of the writer's format. It returns a
+// report for each scheme, in order.
+func ConvertBundleWith(input string, reader MultiAdapter, writer MultiAdapter, opts ConvertOptions) (string, []*ConversionReport, error) {
+	names, docs, err := reader.SplitSchemes(input)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to split %s document: %w", reader.Name(), err)
+	}
+
+	converted := make([]Adapter, len(docs))
+	reports := make([]*ConversionReport, len(docs))
+	for i, doc := range docs {
+		source := newAdapterInstance(reader)
+		if err := source.FromString(doc); err != nil {
+			return "", nil, fmt.Errorf("failed to parse scheme %d: %w", i, err)
+		}
+		converted[i] = newAdapterInstance(writer)
+		reports[i], err = adaptSchemeWith(source, converted[i], opts)
+		if err != nil {
+			return "", nil, fmt.Errorf("scheme %d: %w", i, err)
+		}
+		reports[i].Scheme = names[i]
+	}
+
+	output, err := RenderBundleWith(converted, opts)
+	if err != nil {
+		return "", nil, err
+	}
+	return output, reports, nil
+}
+
+// describeSchemes lists schemes by index and name, for errors
+func describeSchemes(names []string) string {
+	described := make([]string, len(names))
+	for i, name := range names {
+		described[i] = fmt.Sprintf("%d %q", i, name)
+	}
+	return strings.Join(described, ", ")
+}
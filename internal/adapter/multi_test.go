@@ -0,0 +1,146 @@
+package adapter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter/gogh"
+	"github.com/da-luce/paletteport/internal/adapter/terminator"
+	"github.com/da-luce/paletteport/internal/adapter/windows_terminal"
+	"github.com/da-luce/paletteport/templates"
+)
+
+// A Windows Terminal settings.json, comments and all
+const wtSettings = `{
+    "$schema": "https://aka.ms/terminal-profiles-schema",
+    "defaultProfile": "{61c54bbd-c053-5c4a-b130-52c4a3d5e7a9}",
+    "profiles": { "list": [] },
+    // Color schemes
+    "schemes": [
+        {
+            "name": "Campbell",
+            "background": "#0C0C0C",
+            "foreground": "#CCCCCC",
+            "black": "#0C0C0C",
+            "red": "#C50F1F",
+        },
+        {
+            "name": "One Half Light",
+            "background": "#FAFAFA",
+            "foreground": "#383A42",
+            "red": "#E45649"
+        }
+    ]
+}`
+
+// Gogh's themes.json holds a sequence of schemes
+const goghCollection = `[
+  {"name": "Afterglow", "background": "#212121", "foreground": "#D0D0D0", "color_02": "#AC4142"},
+  {"name": "Atom", "background": "#161719", "foreground": "#C5C8C6", "color_02": "#FD5FF1"}
+]`
+
+func TestListSchemes(t *testing.T) {
+	tests := []struct {
+		reader MultiAdapter
+		input  string
+		want   []SchemeEntry
+	}{
+		{&windows_terminal.WindowsTerminalScheme{}, wtSettings, []SchemeEntry{{0, "Campbell"}, {1, "One Half Light"}}},
+		{&windows_terminal.WindowsTerminalScheme{}, `{"name": "Solo"}`, []SchemeEntry{{0, "Solo"}}},
+		{&gogh.GoghScheme{}, goghCollection, []SchemeEntry{{0, "Afterglow"}, {1, "Atom"}}},
+		{&gogh.GoghScheme{}, "---\nname: One\n---\nname: Two\n", []SchemeEntry{{0, "One"}, {1, "Two"}}},
+		{&terminator.TerminatorScheme{}, "[profiles]\n  [[default]]\n  [[Dark]]\n", []SchemeEntry{{0, "default"}, {1, "Dark"}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.reader.Name(), func(t *testing.T) {
+			got, err := ListSchemes(tc.input, tc.reader)
+			if err != nil {
+				t.Fatalf("ListSchemes failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestExtractScheme(t *testing.T) {
+	reader := &windows_terminal.WindowsTerminalScheme{}
+	for _, ref := range []string{"One Half Light", "1"} {
+		doc, err := ExtractScheme(wtSettings, reader, ref)
+		if err != nil {
+			t.Fatalf("ExtractScheme(%q) failed: %v", ref, err)
+		}
+		var scheme windows_terminal.WindowsTerminalScheme
+		if err := scheme.FromString(doc); err != nil {
+			t.Fatalf("FromString failed: %v", err)
+		}
+		if scheme.Red.Hex() != "#e45649" {
+			t.Errorf("ExtractScheme(%q) picked the wrong scheme: %s", ref, doc)
+		}
+	}
+
+	_, err := ExtractScheme(wtSettings, reader, "2")
+	if err == nil || !strings.Contains(err.Error(), `available: 0 "Campbell", 1 "One Half Light"`) {
+		t.Errorf("expected an error listing the schemes, got %v", err)
+	}
+}
+
+// Every bundle must read back as the schemes it was made of
+func TestRenderBundle_RoundTrip(t *testing.T) {
+	for _, ad := range Adapters {
+		multi, ok := ad.(MultiAdapter)
+		if !ok {
+			continue
+		}
+		t.Run(ad.Name(), func(t *testing.T) {
+			schemes := make([]Adapter, 3)
+			for i := range schemes {
+				schemes[i] = newAdapterInstance(multi)
+				fillDummyScheme(schemes[i])
+			}
+
+			bundle, err := RenderBundle(schemes)
+			if err != nil {
+				t.Fatalf("RenderBundle failed: %v", err)
+			}
+			read, err := ReadSchemes(bundle, multi)
+			if err != nil {
+				t.Fatalf("ReadSchemes failed: %v\n%s", err, bundle)
+			}
+			if len(read) != len(schemes) {
+				t.Fatalf("expected %d schemes, got %d:\n%s", len(schemes), len(read), bundle)
+			}
+			for i := range schemes {
+				if sim := FieldSimilarity(schemes[i], read[i]); sim < 1.0 {
+					t.Errorf("scheme %d changed in the bundle: similarity %.2f", i, sim)
+				}
+			}
+		})
+	}
+
+	if _, err := RenderBundle([]Adapter{&windows_terminal.WindowsTerminalScheme{}, &gogh.GoghScheme{}}); err == nil {
+		t.Errorf("expected an error bundling schemes of different formats")
+	}
+}
+
+func TestConvertBundleWith(t *testing.T) {
+	output, reports, err := ConvertBundleWith(wtSettings, &windows_terminal.WindowsTerminalScheme{}, &terminator.TerminatorScheme{}, ConvertOptions{
+		Missing: templates.Missing{Policy: templates.MissingPlaceholder},
+	})
+	if err != nil {
+		t.Fatalf("ConvertBundleWith failed: %v", err)
+	}
+	if len(reports) != 2 || reports[1].Scheme != "One Half Light" {
+		t.Errorf("expected a report per scheme, got %d", len(reports))
+	}
+
+	names, _, err := (&terminator.TerminatorScheme{}).SplitSchemes(output)
+	if err != nil {
+		t.Fatalf("failed to read the bundle: %v\n%s", err, output)
+	}
+	if !reflect.DeepEqual(names, []string{"Campbell", "One Half Light"}) {
+		t.Errorf("expected a profile per scheme, got %v:\n%s", names, output)
+	}
+}
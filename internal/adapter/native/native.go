@@ -0,0 +1,177 @@
+// Package native implements paletteport's own theme format: the abstract
+// scheme written out field for field, in YAML, JSON or TOML. Unlike the formats
+// of other applications it holds every abstract field, so nothing is lost
+// converting to and from it. Documents are keyed by the field names and hold
+// a version, e.g.
+//
+//	version: 1
+//	Metadata:
+//	  Name: "Tokyo Night"
+//	AnsiColors:
+//	  Red: "#f7768e"
+//
+// The types of the format are generated from the abstract scheme, see
+// scheme_gen.go.
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Version of the format written, and the only one read
+const Version = 1
+
+type Color = color.Color
+
+// NativeJSONScheme is a NativeScheme written as JSON
+type NativeJSONScheme NativeScheme
+
+// NativeTOMLScheme is a NativeScheme written as TOML
+type NativeTOMLScheme NativeScheme
+
+func (s *NativeScheme) Name() string {
+	return "paletteport"
+}
+
+func (s *NativeScheme) TemplateName() string {
+	return "paletteport.yml.tmpl"
+}
+
+func (s *NativeScheme) FromString(input string) error {
+	return s.decode(input, yaml.Unmarshal)
+}
+
+func (s *NativeJSONScheme) Name() string {
+	return "paletteport-json"
+}
+
+func (s *NativeJSONScheme) TemplateName() string {
+	return "paletteport.json.tmpl"
+}
+
+func (s *NativeJSONScheme) FromString(input string) error {
+	return (*NativeScheme)(s).decode(input, json.Unmarshal)
+}
+
+func (s *NativeTOMLScheme) Name() string {
+	return "paletteport-toml"
+}
+
+func (s *NativeTOMLScheme) TemplateName() string {
+	return "paletteport.toml.tmpl"
+}
+
+func (s *NativeTOMLScheme) FromString(input string) error {
+	return (*NativeScheme)(s).decode(input, toml.Unmarshal)
+}
+
+// document is a scheme as written, along with the version of the format and
+// the JSON Schema editors validate it against, if any
+type document struct {
+	Schema  string `json:"$schema"`
+	Version int
+	NativeScheme
+}
+
+// decode reads a document with the given unmarshaler. The formats are read
+// into plain values first and then decoded as JSON, so all three accept the
+// same keys and reject unknown ones alike.
+func (s *NativeScheme) decode(input string, unmarshal func([]byte, any) error) error {
+	var raw map[string]any
+	if err := unmarshal([]byte(input), &raw); err != nil {
+		return err
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var doc document
+	if err := decoder.Decode(&doc); err != nil {
+		return err
+	}
+
+	switch doc.Version {
+	case Version:
+	case 0:
+		return fmt.Errorf("missing version, expected version: %d", Version)
+	default:
+		return fmt.Errorf("unsupported version %d, expected %d", doc.Version, Version)
+	}
+	*s = doc.NativeScheme
+	return nil
+}
+
+// Group is a table of the document: the set fields of one of the scheme's
+// structs, along with the groups nested in it
+type Group struct {
+	Key     string
+	Entries []Entry
+	Groups  []Group
+}
+
+// Entry is a field of a group: text, or a color templates write with
+// optional, so missing colors are resolved by the policy
+type Entry struct {
+	Key   string
+	Path  string  // Path of the field, which missing colors are reported by
+	Text  *string // Nil for colors
+	Color *Color  // Nil for text and missing colors
+}
+
+// Lossless marks the formats as holding the abstract scheme as is, so no
+// fallbacks are saved in them
+func (s *NativeScheme) Lossless()     {}
+func (s *NativeJSONScheme) Lossless() {}
+func (s *NativeTOMLScheme) Lossless() {}
+
+// Version returns the version of the format, for templates
+func (s *NativeScheme) Version() int     { return Version }
+func (s *NativeJSONScheme) Version() int { return Version }
+func (s *NativeTOMLScheme) Version() int { return Version }
+
+// Groups returns the groups of the scheme in order, for templates. Every group
+// and color is listed, but unset text is left out.
+func (s *NativeScheme) Groups() []Group     { return groups(reflect.ValueOf(s).Elem(), "") }
+func (s *NativeJSONScheme) Groups() []Group { return groups(reflect.ValueOf(s).Elem(), "") }
+func (s *NativeTOMLScheme) Groups() []Group { return groups(reflect.ValueOf(s).Elem(), "") }
+
+// groups lists the struct fields of v as groups and the values inside them
+// as entries, their paths under the prefix
+func groups(v reflect.Value, prefix string) []Group {
+	var result []Group
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Struct {
+			continue
+		}
+		path := prefix + v.Type().Field(i).Name
+		group := Group{Key: v.Type().Field(i).Name, Groups: groups(field, path+".")}
+		for j := 0; j < field.NumField(); j++ {
+			entry := Entry{Key: field.Type().Field(j).Name, Path: path + "." + field.Type().Field(j).Name}
+			switch p := field.Field(j).Interface().(type) {
+			case *Color:
+				entry.Color = p
+			case *string:
+				if p == nil {
+					continue
+				}
+				entry.Text = p
+			default:
+				continue
+			}
+			group.Entries = append(group.Entries, entry)
+		}
+		result = append(result, group)
+	}
+	return result
+}
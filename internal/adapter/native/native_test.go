@@ -0,0 +1,172 @@
+package native_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/adapter/adaptertest"
+	"github.com/da-luce/paletteport/internal/adapter/native"
+	"github.com/da-luce/paletteport/internal/adapter/windows_terminal"
+	"github.com/da-luce/paletteport/internal/structutil"
+	"github.com/da-luce/paletteport/templates"
+)
+
+func loadTheme(t *testing.T) (*native.NativeScheme, string) {
+	t.Helper()
+	var scheme native.NativeScheme
+	input := adaptertest.Load(t, adaptertest.Theme("paletteport.yml"), &scheme)
+	return &scheme, input
+}
+
+// The native scheme must hold exactly the fields of the abstract scheme
+func TestMirrorsAbstract(t *testing.T) {
+	fields := func(v any) map[string]reflect.Type {
+		found := make(map[string]reflect.Type)
+		structutil.TraverseStructDFS(v, func(path []string, field reflect.StructField, _ reflect.Value) bool {
+			found[strings.Join(path, ".")] = field.Type
+			return true
+		})
+		return found
+	}
+
+	abstract := fields(&adapter.AbstractScheme{})
+	mirror := fields(&native.NativeScheme{})
+	for path, typ := range abstract {
+		if typ.Kind() == reflect.Struct {
+			continue
+		}
+		if mirror[path] != typ {
+			t.Errorf("abstract field %s (%s) is missing from the native scheme", path, typ)
+		}
+	}
+	for path := range mirror {
+		if _, ok := abstract[path]; !ok {
+			t.Errorf("native field %s is not in the abstract scheme", path)
+		}
+	}
+}
+
+// Every format must read back what it wrote, alpha included
+func TestRoundTrip(t *testing.T) {
+	scheme, _ := loadTheme(t)
+	if scheme.SpecialColors.Selection.HexAlpha() != "#515c7e4d" {
+		t.Fatalf("expected the selection to keep its alpha, got %s", scheme.SpecialColors.Selection.HexAlpha())
+	}
+
+	writers := []adapter.Adapter{
+		(*native.NativeScheme)(scheme),
+		(*native.NativeJSONScheme)(scheme),
+		(*native.NativeTOMLScheme)(scheme),
+	}
+	for _, writer := range writers {
+		t.Run(writer.Name(), func(t *testing.T) {
+			adaptertest.AssertRoundTrip(t, writer)
+		})
+	}
+}
+
+// Converting between native documents loses nothing
+func TestConvert_Lossless(t *testing.T) {
+	_, input := loadTheme(t)
+	_, report, err := adapter.ConvertTheme(input, &native.NativeScheme{}, &native.NativeTOMLScheme{})
+	if err != nil {
+		t.Fatalf("ConvertTheme failed: %v", err)
+	}
+	if len(report.Dropped) != 0 || len(report.Unused) != 0 || len(report.Filled) != 0 {
+		t.Errorf("expected a lossless conversion, got:\n%s", report)
+	}
+}
+
+// Colors the input lacks are left unset, not saved as made up fallbacks
+func TestConvert_NoFallbacks(t *testing.T) {
+	var wt windows_terminal.WindowsTerminalScheme
+	input := adaptertest.Load(t, adaptertest.Theme("wt.json"), &wt)
+
+	output, report, err := adapter.ConvertTheme(input, &wt, &native.NativeScheme{})
+	if err != nil {
+		t.Fatalf("ConvertTheme failed: %v", err)
+	}
+	if len(report.Filled) != 0 {
+		t.Errorf("expected no fallbacks, got:\n%s", report)
+	}
+	for _, key := range []string{"Comment:", "CursorLine:"} {
+		if strings.Contains(output, key) {
+			t.Errorf("expected %s to be left out, got:\n%s", key, output)
+		}
+	}
+	if !strings.Contains(output, "BrightWhite:") {
+		t.Errorf("expected the colors of the input, got:\n%s", output)
+	}
+}
+
+func TestFromString_Unset(t *testing.T) {
+	var scheme native.NativeScheme
+	if err := scheme.FromString("version: 1\nAnsiColors:\n  Red: \"#ff000080\"\n"); err != nil {
+		t.Fatalf("FromString failed: %v", err)
+	}
+	if scheme.AnsiColors.Red.HexAlpha() != "#ff000080" || scheme.AnsiColors.Blue != nil || scheme.Metadata.Name != nil {
+		t.Errorf("expected only the red to be set")
+	}
+
+	_, rendered := adaptertest.RoundTrip(t, &scheme)
+	if strings.Contains(rendered, "Blue") || !strings.Contains(rendered, "Metadata: {}") {
+		t.Errorf("expected unset fields to be left out, got:\n%s", rendered)
+	}
+}
+
+// Missing colors follow the policy like in any other format
+func TestRender_MissingPolicy(t *testing.T) {
+	red := "#ff000080"
+	input := "version: 1\nAnsiColors:\n  Red: \"" + red + "\"\n"
+	for _, writer := range []adapter.Adapter{&native.NativeScheme{}, &native.NativeJSONScheme{}, &native.NativeTOMLScheme{}} {
+		t.Run(writer.Name(), func(t *testing.T) {
+			opts := adapter.ConvertOptions{Missing: templates.Missing{Policy: templates.MissingFail}}
+			var missing *templates.MissingError
+			if _, _, err := adapter.ConvertThemeWith(input, &native.NativeScheme{}, writer, opts); !errors.As(err, &missing) || missing.Keys[0] != "ScopeColors.Basic.Comment" {
+				t.Errorf("expected the missing colors to fail, got %v", err)
+			}
+
+			opts.Missing.Policy = templates.MissingPlaceholder
+			output, _, err := adapter.ConvertThemeWith(input, &native.NativeScheme{}, writer, opts)
+			if err != nil {
+				t.Fatalf("ConvertThemeWith failed: %v", err)
+			}
+			reader, err := adapter.GetAdapter(writer.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			scheme, _, err := adapter.ParseAbstract(output, reader)
+			if err != nil {
+				t.Fatalf("Failed to parse the rendered theme: %v\n%s", err, output)
+			}
+			if scheme.AnsiColors.Red.HexAlpha() != red || scheme.AnsiColors.Blue.Hex() != templates.DefaultPlaceholder.Hex() {
+				t.Errorf("expected placeholders for the missing colors only, got:\n%s", output)
+			}
+		})
+	}
+}
+
+func TestFromString_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme adapter.Adapter
+		input  string
+		want   string
+	}{
+		{"missing version", &native.NativeScheme{}, "AnsiColors:\n  Red: \"#ff0000\"\n", "missing version"},
+		{"newer version", &native.NativeJSONScheme{}, `{"version": 2}`, "unsupported version 2"},
+		{"unknown field", &native.NativeTOMLScheme{}, "version = 1\n[AnsiColors]\nPurple = \"#ff00ff\"\n", `unknown field "Purple"`},
+		{"invalid color", &native.NativeScheme{}, "version: 1\nAnsiColors:\n  Red: red\n", "red"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.scheme.FromString(tc.input)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected an error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package native
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/structutil"
+)
+
+// Hex colors as read by color.FromHex: RGB, RGBA, RRGGBB or RRGGBBAA with an
+// optional leading '#'
+const colorPattern = `^#?([0-9A-Fa-f]{3,4}|[0-9A-Fa-f]{6}|[0-9A-Fa-f]{8})$`
+
+// Schema is a node of a JSON Schema, holding only the keywords used to
+// describe native documents
+type Schema struct {
+	Dialect              string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// JSONSchema describes native documents, in any of their formats, by walking
+// the fields of NativeScheme. Every field is titled with its path, e.g.
+// ScopeColors.Markup.Heading.
+func JSONSchema() *Schema {
+	closed := false
+	root := &Schema{
+		Dialect:     "https://json-schema.org/draft/2020-12/schema",
+		Title:       "paletteport scheme",
+		Description: "A color scheme in paletteport's own format",
+		Type:        "object",
+		Properties: map[string]*Schema{
+			"$schema": {Type: "string", Description: "Schema the document is validated against"},
+			"version": {Const: Version, Description: "Version of the format"},
+		},
+		Required:             []string{"version"},
+		AdditionalProperties: &closed,
+		Defs: map[string]*Schema{
+			"color": {Type: "string", Pattern: colorPattern, Description: "Hex color, with alpha as #rrggbbaa"},
+		},
+	}
+
+	// Every field is visited after its parent, so the parent's node exists
+	nodes := map[string]*Schema{"": root}
+	colorType := reflect.TypeOf((*Color)(nil))
+	stringType := reflect.TypeOf((*string)(nil))
+	structutil.TraverseStructDFS(&NativeScheme{}, func(path []string, field reflect.StructField, _ reflect.Value) bool {
+		var node *Schema
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			node = &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: &closed}
+		case field.Type == colorType:
+			node = &Schema{Ref: "#/$defs/color"}
+		case field.Type == stringType:
+			node = &Schema{Type: "string"}
+		default:
+			return false
+		}
+		node.Title = strings.Join(path, ".")
+		nodes[node.Title] = node
+		nodes[strings.Join(path[:len(path)-1], ".")].Properties[field.Name] = node
+		return field.Type.Kind() == reflect.Struct
+	})
+	return root
+}
+
+// JSONSchemaString returns the schema as indented JSON
+func JSONSchemaString() (string, error) {
+	data, err := json.MarshalIndent(JSONSchema(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,120 @@
+package native_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter/native"
+	"gopkg.in/yaml.v3"
+)
+
+const schemaFile = "../../../schema/paletteport.schema.json"
+
+// The published schema must be the one generated from the code
+func TestJSONSchema_Published(t *testing.T) {
+	want, err := native.JSONSchemaString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(schemaFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("schema/paletteport.schema.json is out of date, regenerate it with: paletteport schema -o schema/paletteport.schema.json")
+	}
+}
+
+// validate checks a decoded document against the keywords the schema uses
+func validate(schema, root *native.Schema, value any, path string) error {
+	if schema.Ref != "" {
+		schema = root.Defs[strings.TrimPrefix(schema.Ref, "#/$defs/")]
+	}
+	if schema.Const != nil && fmt.Sprint(schema.Const) != fmt.Sprint(value) {
+		return fmt.Errorf("%s: expected %v, got %v", path, schema.Const, value)
+	}
+	switch schema.Type {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected a string, got %v", path, value)
+		}
+		if schema.Pattern != "" && !regexp.MustCompile(schema.Pattern).MatchString(s) {
+			return fmt.Errorf("%s: %q does not match %s", path, s, schema.Pattern)
+		}
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an object, got %v", path, value)
+		}
+		for _, key := range schema.Required {
+			if _, ok := object[key]; !ok {
+				return fmt.Errorf("%s: missing %s", path, key)
+			}
+		}
+		for key, child := range object {
+			property, ok := schema.Properties[key]
+			if !ok {
+				return fmt.Errorf("%s: unknown property %s", path, key)
+			}
+			if err := validate(property, root, child, path+"/"+key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func TestJSONSchema_Validates(t *testing.T) {
+	// Round trip through JSON, so the schema is read as editors would
+	data, err := os.ReadFile(schemaFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema native.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	if schema.Properties["ScopeColors"].Properties["Markup"].Properties["Heading"].Title != "ScopeColors.Markup.Heading" {
+		t.Errorf("expected fields to be titled with their path")
+	}
+
+	theme, err := os.ReadFile("../../../themes/paletteport.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"theme", string(theme), true},
+		{"with schema", "$schema: ../schema/paletteport.schema.json\nversion: 1\n", true},
+		{"missing version", "AnsiColors: {}\n", false},
+		{"newer version", "version: 2\n", false},
+		{"unknown field", "version: 1\nAnsiColors:\n  Purple: \"#ff00ff\"\n", false},
+		{"invalid color", "version: 1\nAnsiColors:\n  Red: red\n", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var doc any
+			if err := yaml.Unmarshal([]byte(tc.input), &doc); err != nil {
+				t.Fatal(err)
+			}
+			err := validate(&schema, &schema, doc, "")
+			if (err == nil) != tc.valid {
+				t.Errorf("expected valid %v, got %v", tc.valid, err)
+			}
+
+			// The schema must agree with the reader
+			var scheme native.NativeScheme
+			if readErr := scheme.FromString(tc.input); (readErr == nil) != tc.valid {
+				t.Errorf("the reader disagrees with the schema: %v", readErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package adapter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+// FieldReport describes a single field that was lost or filled in during a
+// conversion. Paths are dot-separated Go field paths, e.g. "Colors.Primary.Background".
+type FieldReport struct {
+	Path   string `json:"path"`
+	Value  string `json:"value,omitempty"`  // Value of the field, if it had one
+	Source string `json:"source,omitempty"` // Abstract field a filled value was taken from
+}
+
+// ConversionReport lists everything that did not make it through a conversion
+// one-to-one, so callers can judge how lossy it was.
+//
+//	Dropped: fields of the source with a value that has no place in the abstract scheme
+//	Unused:  abstract fields with a value the writer has no place for
+//	Filled:  destination fields whose value came from a fallback rather than the source,
+//	         or from an abstract field other than the first their tag lists
+//	Empty:   destination fields left without a value
+type ConversionReport struct {
+	Reader  string        `json:"reader"`
+	Writer  string        `json:"writer"`
+	Scheme  string        `json:"scheme,omitempty"` // Name of the scheme, when converting several
+	Dropped []FieldReport `json:"dropped"`
+	Unused  []FieldReport `json:"unused"`
+	Filled  []FieldReport `json:"filled"`
+	Empty   []FieldReport `json:"empty"`
+
+	// Abstract fields filled by a fallback, mapped to the field they were filled from
+	fallbacks map[string]string
+}
+
+// newConversionReport creates an empty report for a conversion between the
+// named reader and writer. The writer is empty when only reading.
+func newConversionReport(reader string, writer string) *ConversionReport {
+	return &ConversionReport{
+		Reader:    reader,
+		Writer:    writer,
+		Dropped:   []FieldReport{},
+		Unused:    []FieldReport{},
+		Filled:    []FieldReport{},
+		Empty:     []FieldReport{},
+		fallbacks: make(map[string]string),
+	}
+}
+
+// Loss is the number of fields that were dropped, unused, or left empty
+func (r *ConversionReport) Loss() int {
+	return len(r.Dropped) + len(r.Unused) + len(r.Empty)
+}
+
+// JSON returns the report as indented JSON
+func (r *ConversionReport) JSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// String returns the report as human-readable text
+func (r *ConversionReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Conversion report: %s -> %s", r.Reader, r.Writer)
+	if r.Scheme != "" {
+		fmt.Fprintf(&b, " (%s)", r.Scheme)
+	}
+	b.WriteString("\n")
+
+	sections := []struct {
+		title  string
+		fields []FieldReport
+	}{
+		{"Dropped from source", r.Dropped},
+		{"Unused by writer", r.Unused},
+		{"Filled by fallback", r.Filled},
+		{"Left empty", r.Empty},
+	}
+	for _, section := range sections {
+		fmt.Fprintf(&b, "%s (%d)\n", section.title, len(section.fields))
+		for _, f := range section.fields {
+			line := "  " + f.Path
+			if f.Value != "" {
+				line += " = " + f.Value
+			}
+			if f.Source != "" {
+				line += " (from " + f.Source + ")"
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// isLeafField reports whether the value is a single setting rather than a
+// group of settings. Groups are covered by reporting their members.
+func isLeafField(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+	t := v.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() != reflect.Struct || isColor(t)
+}
+
+// formatFieldValue formats a leaf value for a report, returning false if unset
+func formatFieldValue(v reflect.Value) (string, bool) {
+	if isZeroValue(v) {
+		return "", false
+	}
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if c, ok := v.Interface().(color.Color); ok {
+		return c.ToHex(true), true
+	}
+	return fmt.Sprintf("%v", v.Interface()), true
+}
+
+// recordSet returns a mapping callback that appends fields holding a value to
+// list. Unused fields are reported depth first, so the components of a color
+// follow the color itself; those are skipped.
+func recordSet(list *[]FieldReport) func(fieldPath []string, val reflect.Value) {
+	var lastLeaf string
+	return func(fieldPath []string, val reflect.Value) {
+		pathStr := strings.Join(fieldPath, ".")
+		if lastLeaf != "" && strings.HasPrefix(pathStr, lastLeaf+".") {
+			return
+		}
+		if !isLeafField(val) {
+			return
+		}
+		lastLeaf = pathStr
+		recordValue(list, pathStr, val)
+	}
+}
+
+// recordValue appends the leaf field at path to list if it holds a value
+func recordValue(list *[]FieldReport, path string, val reflect.Value) {
+	if val.Kind() == reflect.Map {
+		// Catch-all maps of settings are reported per entry
+		keys := val.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
+			if value, ok := formatFieldValue(val.MapIndex(key)); ok {
+				*list = append(*list, FieldReport{Path: path + "." + key.String(), Value: value})
+			}
+		}
+		return
+	}
+	if value, ok := formatFieldValue(val); ok {
+		*list = append(*list, FieldReport{Path: path, Value: value})
+	}
+}
+
+// The record methods below are called by the generated mappings, which fill
+// the report alongside the fields rather than walking them again
+
+// recordDropped records a reader field that maps to no abstract field
+func (r *ConversionReport) recordDropped(path string, v any) {
+	recordValue(&r.Dropped, path, reflect.ValueOf(v))
+}
+
+// recordUnused records an abstract field no writer field was filled from
+func (r *ConversionReport) recordUnused(path string, v any) {
+	recordValue(&r.Unused, path, reflect.ValueOf(v))
+}
+
+// recordWritten records a writer field filled from the abstract field at
+// source if it was left empty, or if its value came from a fallback or from an
+// alternate, i.e. an abstract field other than the first its tag lists
+func (r *ConversionReport) recordWritten(path string, v any, source string, alternate bool) {
+	val := reflect.ValueOf(v)
+	if isZeroValue(val) {
+		r.Empty = append(r.Empty, FieldReport{Path: path})
+		return
+	}
+	value, _ := formatFieldValue(val)
+	if fallback, filled := r.fallbacks[source]; filled {
+		r.Filled = append(r.Filled, FieldReport{Path: path, Value: value, Source: fallback})
+	} else if alternate {
+		r.Filled = append(r.Filled, FieldReport{Path: path, Value: value, Source: source})
+	}
+}
+
+// recordUnwritten records a writer field no abstract field maps to if it was
+// left empty
+func (r *ConversionReport) recordUnwritten(path string, v any) {
+	if isZeroValue(reflect.ValueOf(v)) {
+		r.Empty = append(r.Empty, FieldReport{Path: path})
+	}
+}
@@ -0,0 +1,111 @@
+package adapter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter/windows_terminal"
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+type reportScheme struct {
+	Background *Color `abstract:"SpecialColors.Background"`
+	CursorText *Color `abstract:"SpecialColors.CursorText"`
+	Shadow     *Color // No abstract counterpart
+}
+
+func (s *reportScheme) Name() string                  { return "report" }
+func (s *reportScheme) TemplateName() string          { return "" }
+func (s *reportScheme) FromString(input string) error { return nil }
+
+func mustHex(t *testing.T, hex string) *Color {
+	t.Helper()
+	c, err := color.FromHex(hex)
+	if err != nil {
+		t.Fatalf("invalid test color %q: %v", hex, err)
+	}
+	return &c
+}
+
+func findField(fields []FieldReport, path string) (FieldReport, bool) {
+	for _, f := range fields {
+		if f.Path == path {
+			return f, true
+		}
+	}
+	return FieldReport{}, false
+}
+
+func TestConversionReport(t *testing.T) {
+	reader := &reportScheme{
+		Background: mustHex(t, "#101010"),
+		CursorText: mustHex(t, "#202020"),
+		Shadow:     mustHex(t, "#303030"),
+	}
+	writer := &windows_terminal.WindowsTerminalScheme{}
+
+	report, err := adaptScheme(reader, writer)
+	if err != nil {
+		t.Fatalf("adaptScheme failed: %v", err)
+	}
+
+	if f, ok := findField(report.Dropped, "Shadow"); !ok || f.Value != "#303030" {
+		t.Errorf("expected Shadow to be dropped with its value, got %+v", report.Dropped)
+	}
+	if len(report.Dropped) != 1 {
+		t.Errorf("expected exactly one dropped field, got %+v", report.Dropped)
+	}
+
+	if _, ok := findField(report.Unused, "SpecialColors.CursorText"); !ok {
+		t.Errorf("expected SpecialColors.CursorText to be unused, got %+v", report.Unused)
+	}
+	if _, ok := findField(report.Unused, "SpecialColors.Background"); ok {
+		t.Errorf("SpecialColors.Background is used by the writer, got %+v", report.Unused)
+	}
+
+	// Black falls back to the background
+	if f, ok := findField(report.Filled, "Black"); !ok || f.Source != "SpecialColors.Background" {
+		t.Errorf("expected Black to be filled from SpecialColors.Background, got %+v", report.Filled)
+	}
+
+	for _, path := range []string{"SchemeName", "Red", "Foreground"} {
+		if _, ok := findField(report.Empty, path); !ok {
+			t.Errorf("expected %s to be left empty, got %+v", path, report.Empty)
+		}
+	}
+	if _, ok := findField(report.Empty, "Background"); ok {
+		t.Errorf("Background was set, but reported empty")
+	}
+
+	if report.Loss() != len(report.Dropped)+len(report.Unused)+len(report.Empty) {
+		t.Errorf("unexpected loss count %d", report.Loss())
+	}
+}
+
+func TestConversionReport_Formats(t *testing.T) {
+	reader := &reportScheme{Shadow: mustHex(t, "#303030")}
+	report, err := adaptScheme(reader, &windows_terminal.WindowsTerminalScheme{})
+	if err != nil {
+		t.Fatalf("adaptScheme failed: %v", err)
+	}
+
+	out, err := report.JSON()
+	if err != nil {
+		t.Fatalf("JSON failed: %v", err)
+	}
+	var decoded ConversionReport
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("report JSON does not parse: %v", err)
+	}
+	if len(decoded.Dropped) != 1 || decoded.Dropped[0].Path != "Shadow" {
+		t.Errorf("unexpected dropped fields after JSON round trip: %+v", decoded.Dropped)
+	}
+
+	text := report.String()
+	for _, want := range []string{"report -> wt", "Dropped from source (1)", "Shadow = #303030"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected text report to contain %q, got:\n%s", want, text)
+		}
+	}
+}
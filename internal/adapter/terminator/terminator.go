@@ -0,0 +1,264 @@
+package terminator
+
+import (
+	"bufio"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/templates"
+)
+
+type Color = color.Color
+
+// TerminatorScheme holds the colors of a single Terminator profile. The
+// terminator tag lists the keys a field is read from, the first of which it
+// is written to.
+type TerminatorScheme struct {
+	ProfileName *string `abstract:"Metadata.Name"` // Written as "default" if unset
+
+	Background *Color `terminator:"background_color" abstract:"SpecialColors.Background"`
+	Foreground *Color `terminator:"foreground_color" abstract:"SpecialColors.Foreground"`
+	Cursor     *Color `terminator:"cursor_color,cursor_bg_color" abstract:"SpecialColors.Cursor"`
+	CursorText *Color `terminator:"cursor_fg_color" abstract:"SpecialColors.CursorText"`
+
+	Palette Palette // Written as a single "palette" key
+}
+
+// Palette holds the 16 colors of the palette key, in order
+type Palette struct {
+	Black         *Color `abstract:"AnsiColors.Black"`
+	Red           *Color `abstract:"AnsiColors.Red"`
+	Green         *Color `abstract:"AnsiColors.Green"`
+	Yellow        *Color `abstract:"AnsiColors.Yellow"`
+	Blue          *Color `abstract:"AnsiColors.Blue"`
+	Magenta       *Color `abstract:"AnsiColors.Magenta"`
+	Cyan          *Color `abstract:"AnsiColors.Cyan"`
+	White         *Color `abstract:"AnsiColors.White"`
+	BrightBlack   *Color `abstract:"AnsiColors.BrightBlack"`
+	BrightRed     *Color `abstract:"AnsiColors.BrightRed"`
+	BrightGreen   *Color `abstract:"AnsiColors.BrightGreen"`
+	BrightYellow  *Color `abstract:"AnsiColors.BrightYellow"`
+	BrightBlue    *Color `abstract:"AnsiColors.BrightBlue"`
+	BrightMagenta *Color `abstract:"AnsiColors.BrightMagenta"`
+	BrightCyan    *Color `abstract:"AnsiColors.BrightCyan"`
+	BrightWhite   *Color `abstract:"AnsiColors.BrightWhite"`
+}
+
+// Profile picked when a config holds several and none was selected
+const defaultProfile = "default"
+
+func (rw *TerminatorScheme) Name() string {
+	return "terminator"
+}
+
+func (rw *TerminatorScheme) TemplateName() string {
+	return "terminator.config.tmpl"
+}
+
+func (rw *TerminatorScheme) BundleTemplateName() string {
+	return "terminator.bundle.config.tmpl"
+}
+
+// FromString reads a single profile, either from a complete Terminator config,
+// where profiles are the subsections of [profiles], or from a bare [[profile]]
+// section as shared by theme collections. Of several profiles, the default
+// one is read; use SplitSchemes to read the others.
+func (rw *TerminatorScheme) FromString(input string) error {
+	profiles, names, err := parseProfiles(input)
+	if err != nil {
+		return err
+	}
+
+	var name string
+	switch {
+	case len(names) == 0:
+		return fmt.Errorf("no profiles found")
+	case len(names) == 1:
+		name = names[0]
+	default:
+		if _, ok := profiles[defaultProfile]; !ok {
+			return fmt.Errorf("config holds several profiles and none is %q: %s", defaultProfile, strings.Join(names, ", "))
+		}
+		name = defaultProfile
+	}
+
+	// The default profile is Terminator's own, and its name says nothing
+	// about the scheme
+	if name != defaultProfile {
+		rw.ProfileName = &name
+	}
+	return rw.read(profiles[name])
+}
+
+// SplitSchemes returns a bare [[profile]] section for every profile in the
+// config
+func (rw *TerminatorScheme) SplitSchemes(input string) ([]string, []string, error) {
+	profiles, names, err := parseProfiles(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	docs := make([]string, len(names))
+	for i, name := range names {
+		var b strings.Builder
+		fmt.Fprintf(&b, "[[%s]]\n", templates.ConfigObjString(name))
+		settings := profiles[name]
+		keys := make([]string, 0, len(settings))
+		for key := range settings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(&b, "    %s = %s\n", key, templates.ConfigObjString(settings[key]))
+		}
+		docs[i] = b.String()
+	}
+	return names, docs, nil
+}
+
+// read sets the fields from the settings of a profile
+func (rw *TerminatorScheme) read(settings map[string]string) error {
+	v := reflect.ValueOf(rw).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		for _, key := range keys(v.Type().Field(i)) {
+			value, ok := settings[key]
+			if !ok {
+				continue
+			}
+			c, err := color.FromHex(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", key, err)
+			}
+			v.Field(i).Set(reflect.ValueOf(&c))
+			break
+		}
+	}
+
+	palette, ok := settings["palette"]
+	if !ok {
+		return nil
+	}
+	entries := strings.Split(palette, ":")
+	p := reflect.ValueOf(&rw.Palette).Elem()
+	if len(entries) != p.NumField() {
+		return fmt.Errorf("palette must hold %d colors, got %d", p.NumField(), len(entries))
+	}
+	for i, entry := range entries {
+		c, err := color.FromHex(strings.TrimSpace(entry))
+		if err != nil {
+			return fmt.Errorf("invalid palette color %d: %w", i, err)
+		}
+		p.Field(i).Set(reflect.ValueOf(&c))
+	}
+	return nil
+}
+
+// keys returns the settings a field is read from
+func keys(field reflect.StructField) []string {
+	tag := field.Tag.Get("terminator")
+	if tag == "" {
+		return nil
+	}
+	return strings.Split(tag, ",")
+}
+
+// parseProfiles returns the settings of each profile in the config, and the
+// profile names in the order they appear. The format is that of ConfigObj,
+// where sections are nested by the number of brackets around their name.
+func parseProfiles(input string) (map[string]map[string]string, []string, error) {
+	profiles := make(map[string]map[string]string)
+	var names []string
+
+	var section string            // Enclosing [section], if any
+	var current map[string]string // Settings of the profile being read, if any
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			depth := len(line) - len(strings.TrimLeft(line, "["))
+			if !strings.HasSuffix(line, strings.Repeat("]", depth)) {
+				return nil, nil, fmt.Errorf("line %d: malformed section %q", lineNo, line)
+			}
+			name := unquote(strings.TrimSpace(line[depth : len(line)-depth]))
+
+			current = nil
+			switch {
+			case depth == 1:
+				section = name
+			case depth == 2 && (section == "" || section == "profiles"):
+				if _, ok := profiles[name]; !ok {
+					profiles[name] = make(map[string]string)
+					names = append(names, name)
+				}
+				current = profiles[name]
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if ok && current != nil {
+			current[strings.TrimSpace(key)] = unquote(strings.TrimSpace(value))
+		}
+	}
+	return profiles, names, scanner.Err()
+}
+
+// unquote returns the contents of a quoted value or section name, or an
+// unquoted value up to an inline comment
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(value)
+}
+
+// Setting is a single color key of a profile
+type Setting struct {
+	Key   string
+	Color *Color
+}
+
+// Settings returns the colors in field order, unset ones included so the
+// template decides what to do with them
+func (rw *TerminatorScheme) Settings() []Setting {
+	var settings []Setting
+	v := reflect.ValueOf(rw).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		keys := keys(v.Type().Field(i))
+		if len(keys) == 0 {
+			continue
+		}
+		settings = append(settings, Setting{keys[0], v.Field(i).Interface().(*Color)})
+	}
+	return settings
+}
+
+// PaletteColors returns the 16 colors of the palette key in order, or nil if
+// none is set and the key is left out
+func (rw *TerminatorScheme) PaletteColors() []*Color {
+	var colors []*Color
+	empty := true
+	p := reflect.ValueOf(rw.Palette)
+	for i := 0; i < p.NumField(); i++ {
+		c := p.Field(i).Interface().(*Color)
+		empty = empty && c == nil
+		colors = append(colors, c)
+	}
+	if empty {
+		return nil
+	}
+	return colors
+}
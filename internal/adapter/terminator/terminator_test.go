@@ -0,0 +1,132 @@
+package terminator_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter/adaptertest"
+	"github.com/da-luce/paletteport/internal/adapter/terminator"
+)
+
+func loadTheme(t *testing.T) *terminator.TerminatorScheme {
+	t.Helper()
+	var scheme terminator.TerminatorScheme
+	adaptertest.Load(t, adaptertest.Theme("terminator.config"), &scheme)
+	return &scheme
+}
+
+func TestFromString_Theme(t *testing.T) {
+	scheme := loadTheme(t)
+
+	if scheme.ProfileName == nil || *scheme.ProfileName != "Afterglow" {
+		t.Errorf("expected the profile name, got %v", scheme.ProfileName)
+	}
+	checks := map[string]*terminator.Color{
+		"#212121": scheme.Background,
+		"#d0d0d0": scheme.Foreground,
+		"#151515": scheme.Palette.Black,
+		"#ac4142": scheme.Palette.Red,
+		"#505050": scheme.Palette.BrightBlack,
+		"#f5f5f5": scheme.Palette.BrightWhite,
+	}
+	for want, c := range checks {
+		if c.Hex() != want {
+			t.Errorf("expected %s, got %s", want, c.Hex())
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	rendered := adaptertest.AssertRoundTrip(t, loadTheme(t))
+	if !strings.Contains(rendered, `palette = "#151515:#ac4142:`) {
+		t.Errorf("expected the palette to be written as a single key")
+	}
+}
+
+// Names holding characters of the format's syntax are quoted, and line breaks
+// kept out
+func TestRoundTrip_ProfileNames(t *testing.T) {
+	for _, name := range []string{`Night "Owl" [2]`, "C# # dark", `"Night" O'Brien`, "two\nlines"} {
+		scheme := terminator.TerminatorScheme{ProfileName: &name, Background: loadTheme(t).Background}
+		reparsed, rendered := adaptertest.RoundTrip(t, &scheme)
+		want := strings.NewReplacer("\n", " ", `"Night"`, "'Night'").Replace(name)
+		if reparsed.ProfileName == nil || *reparsed.ProfileName != want || reparsed.Background == nil {
+			t.Errorf("%q: expected profile %q, got:\n%s", name, want, rendered)
+		}
+	}
+}
+
+const config = `[global_config]
+  title_transmit_bg_color = "#d30102"
+[keybindings]
+[profiles]
+  [[default]]
+    cursor_color = "#aaaaaa"
+    palette = "#000000:#cd0000:#00cd00:#cdcd00:#0000ee:#cd00cd:#00cdcd:#e5e5e5:#7f7f7f:#ff0000:#00ff00:#ffff00:#5c5cff:#ff00ff:#00ffff:#ffffff"
+  [[Light]]
+    background_color = '#fdf6e3' # Solarized
+    cursor_bg_color = "#586e75"
+[layouts]
+  [[Light]]
+    [[[child1]]]
+      type = Terminal
+      profile = Light
+`
+
+func TestFromString_Profiles(t *testing.T) {
+	var def terminator.TerminatorScheme
+	if err := def.FromString(config); err != nil {
+		t.Fatalf("FromString failed: %v", err)
+	}
+	if def.ProfileName != nil {
+		t.Errorf("expected the default profile to leave the name unset, got %q", *def.ProfileName)
+	}
+	if def.Cursor.Hex() != "#aaaaaa" || def.Palette.Blue.Hex() != "#0000ee" {
+		t.Errorf("expected the default profile to be read")
+	}
+}
+
+func TestSplitSchemes(t *testing.T) {
+	var scheme terminator.TerminatorScheme
+	names, docs, err := scheme.SplitSchemes(config)
+	if err != nil {
+		t.Fatalf("SplitSchemes failed: %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"default", "Light"}) || len(docs) != 2 {
+		t.Fatalf("expected the profiles but not the layouts, got %v", names)
+	}
+
+	var light terminator.TerminatorScheme
+	if err := light.FromString(docs[1]); err != nil {
+		t.Fatalf("FromString failed: %v", err)
+	}
+	if light.Background.Hex() != "#fdf6e3" || light.Cursor.Hex() != "#586e75" {
+		t.Errorf("expected the second profile to be read, got %s and %s", light.Background.Hex(), light.Cursor.Hex())
+	}
+	if light.Palette.Black != nil {
+		t.Errorf("expected the layout of the same name to be ignored")
+	}
+}
+
+func TestFromString_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"no profiles", "[global_config]\n", "no profiles"},
+		{"no default", "[[One]]\n[[Two]]\n", `none is "default": One, Two`},
+		{"short palette", "[[One]]\n  palette = \"#000000:#ffffff\"\n", "palette must hold 16 colors, got 2"},
+		{"invalid color", "[[One]]\n  background_color = \"black\"\n", "invalid background_color"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var scheme terminator.TerminatorScheme
+			err := scheme.FromString(tc.input)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected an error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -2,30 +2,132 @@ package vscode_test
 
 import (
 	"encoding/json"
-	"os"
+	"reflect"
 	"testing"
 
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/adapter/adaptertest"
 	"github.com/da-luce/paletteport/internal/adapter/vscode"
 )
 
+func loadTheme(t *testing.T) (*vscode.VSCodeTheme, string) {
+	t.Helper()
+	var theme vscode.VSCodeTheme
+	input := adaptertest.Load(t, "./tokyo-night-color-theme.json", &theme)
+	return &theme, input
+}
+
 func TestLoadThemeFromFile(t *testing.T) {
-	filePath := "./tokyo-night-color-theme.json" // replace with your actual file path
+	theme, _ := loadTheme(t)
 
-	data, err := os.ReadFile(filePath)
+	prettyJSON, err := json.MarshalIndent(theme, "", "  ")
 	if err != nil {
-		t.Fatalf("Failed to read theme file: %v", err)
+		t.Fatalf("Failed to marshal theme for pretty print: %v", err)
 	}
+	t.Logf("Parsed theme struct:\n%s", string(prettyJSON))
 
-	var theme vscode.VSCodeTheme
-	err = theme.FromString(string(data))
+	if theme.ThemeName == nil || *theme.ThemeName != "Tokyo Night" {
+		t.Errorf("expected the theme name, got %v", theme.ThemeName)
+	}
+	checks := map[string]*vscode.Color{
+		"#1A1B26":   theme.Colors.Background,
+		"#C0CAF5":   theme.Colors.Cursor,
+		"#515C7E4D": theme.Colors.SelectionBackground,
+		"#F7768E":   theme.Colors.AnsiRed,
+		"#ACB0D0":   theme.Colors.AnsiBrightWhite,
+		"#51597D":   theme.TokenColors.Comment,
+		"#9ECE6A":   theme.TokenColors.String,
+		"#FF9E64":   theme.TokenColors.Number,
+	}
+	for want, c := range checks {
+		if c == nil || c.ToHexAlpha(true) != want {
+			t.Errorf("expected %s, got %v", want, c)
+		}
+	}
+	if got := theme.Colors.Other["activityBar.background"]; got != "#16161e" {
+		t.Errorf("expected unmapped colors to be kept, got %q", got)
+	}
+}
+
+// Rendering the fixture and parsing it back must give the same theme
+func TestRoundTrip(t *testing.T) {
+	theme, _ := loadTheme(t)
+
+	parsed, rendered := adaptertest.RoundTrip(t, theme)
+	if !json.Valid([]byte(rendered)) {
+		t.Fatalf("rendered theme is not valid JSON:\n%s", rendered)
+	}
+
+	if !reflect.DeepEqual(parsed.Colors, theme.Colors) {
+		t.Errorf("colors changed in the round trip")
+	}
+	if !reflect.DeepEqual(parsed.TokenColors, theme.TokenColors) {
+		t.Errorf("token colors changed in the round trip:\n%+v\n%+v", theme.TokenColors, parsed.TokenColors)
+	}
+	if *parsed.ThemeName != *theme.ThemeName || parsed.ThemeType() != "dark" {
+		t.Errorf("metadata changed in the round trip: %q, %q", *parsed.ThemeName, parsed.ThemeType())
+	}
+}
+
+// Converting the fixture to VS Code through the abstract scheme keeps every
+// color that has an abstract counterpart
+func TestRoundTrip_Abstract(t *testing.T) {
+	_, input := loadTheme(t)
+
+	output, _, err := adapter.ConvertTheme(input, &vscode.VSCodeTheme{}, &vscode.VSCodeTheme{})
 	if err != nil {
-		t.Fatalf("Failed to unmarshal theme JSON: %v", err)
+		t.Fatalf("ConvertTheme failed: %v", err)
 	}
 
-	prettyJSON, err := json.MarshalIndent(theme, "", "  ")
+	first, _, err := adapter.ParseAbstract(input, &vscode.VSCodeTheme{})
 	if err != nil {
-		t.Fatalf("Failed to marshal theme for pretty print: %v", err)
+		t.Fatal(err)
 	}
-	t.Logf("Parsed theme struct:\n%s", string(prettyJSON))
+	second, _, err := adapter.ParseAbstract(output, &vscode.VSCodeTheme{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sim := adapter.FieldSimilarity(first, second); sim < 1 {
+		t.Errorf("abstract scheme changed in the round trip, similarity %.2f", sim)
+	}
+}
 
+func TestFromString_JSONC(t *testing.T) {
+	input := `{
+		// Comments and trailing commas are allowed
+		"name": "Test // not a comment",
+		"colors": {
+			"editor.background": "#fff", /* short hex */
+			"terminal.ansiRed": "#ff000080",
+		},
+		"tokenColors": [
+			{"scope": "string, comment", "settings": {"foreground": "#00ff00"}},
+			{"scope": "meta.var.expr string", "settings": {"foreground": "#0000ff"}},
+			{"scope": ["comment"], "settings": {"foreground": "#888888", "fontStyle": "italic"}},
+		],
+	}`
+
+	var theme vscode.VSCodeTheme
+	if err := theme.FromString(input); err != nil {
+		t.Fatalf("FromString failed: %v", err)
+	}
+	if *theme.ThemeName != "Test // not a comment" {
+		t.Errorf("expected comment markers in strings to be kept, got %q", *theme.ThemeName)
+	}
+	if got := theme.Colors.Background.ToHexAlpha(true); got != "#FFFFFF" {
+		t.Errorf("expected short hex to expand, got %s", got)
+	}
+	if got := theme.Colors.AnsiRed.ToHexAlpha(true); got != "#FF000080" {
+		t.Errorf("expected alpha to be kept, got %s", got)
+	}
+	// Context selectors are ignored, and later entries override earlier ones
+	if got := theme.TokenColors.String.ToHex(true); got != "#00FF00" {
+		t.Errorf("expected string from the plain selector, got %s", got)
+	}
+	if got := theme.TokenColors.Comment.ToHex(true); got != "#888888" {
+		t.Errorf("expected the last comment color, got %s", got)
+	}
+	if theme.ThemeType() != "light" {
+		t.Errorf("expected a white background to make a light theme")
+	}
 }
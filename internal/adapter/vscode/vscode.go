@@ -2,119 +2,287 @@ package vscode
 
 import (
 	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/jsonc"
 )
 
 type VSCodeTheme struct {
-	Name          string   `json:"name"`
-	Author        string   `json:"author"`
+	ThemeName     *string  `json:"name" abstract:"Metadata.Name"`
+	Author        *string  `json:"author" abstract:"Metadata.Author"`
 	Maintainers   []string `json:"maintainers"`
-	Type          string   `json:"type"`
 	SemanticClass string   `json:"semanticClass"`
 	Colors        Colors   `json:"colors"`
+	TokenColors   Scopes   `json:"-"` // Read from and written to tokenColors
 }
 
 type Color = color.Color
+
+// Colors are the workbench colors of a theme. VS Code has hundreds of them;
+// those without an abstract counterpart are kept in Other as written.
 type Colors struct {
-	// Top‑level flat keys
-	Foreground            *Color `json:"foreground"`
-	DescriptionForeground *Color `json:"descriptionForeground"`
-	DisabledForeground    *Color `json:"disabledForeground"`
-	FocusBorder           *Color `json:"focusBorder"`
-	ErrorForeground       *Color `json:"errorForeground"`
-	WidgetShadow          *Color `json:"widget.shadow"`
-	ScrollbarShadow       *Color `json:"scrollbar.shadow"`
-	BadgeBackground       *Color `json:"badge.background"`
-	BadgeForeground       *Color `json:"badge.foreground"`
-	IconForeground        *Color `json:"icon.foreground"`
-	SettingsHeader        *Color `json:"settings.headerForeground"`
-	WindowActiveBorder    *Color `json:"window.activeBorder"`
-	WindowInactiveBorder  *Color `json:"window.inactiveBorder"`
-	SashHoverBorder       *Color `json:"sash.hoverBorder"`
-	ToolbarActiveBG       *Color `json:"toolbar.activeBackground"`
-	ToolbarHoverBG        *Color `json:"toolbar.hoverBackground"`
-
-	ExtensionButtonProminentBackground *Color `json:"extensionButton.prominentBackground"`
-	ExtensionButtonProminentHover      *Color `json:"extensionButton.prominentHoverBackground"`
-	ExtensionButtonProminentForeground *Color `json:"extensionButton.prominentForeground"`
-	ExtensionBadgeRemoteBackground     *Color `json:"extensionBadge.remoteBackground"`
-	ExtensionBadgeRemoteForeground     *Color `json:"extensionBadge.remoteForeground"`
-
-	ButtonBackground          *Color `json:"button.background"`
-	ButtonHoverBackground     *Color `json:"button.hoverBackground"`
-	ButtonSecondaryBackground *Color `json:"button.secondaryBackground"`
-	ButtonForeground          *Color `json:"button.foreground"`
-	ProgressBarBackground     *Color `json:"progressBar.background"`
-
-	InputBackground             *Color `json:"input.background"`
-	InputForeground             *Color `json:"input.foreground"`
-	InputBorder                 *Color `json:"input.border"`
-	InputPlaceholderForeground  *Color `json:"input.placeholderForeground"`
-	InputOptionActiveForeground *Color `json:"inputOption.activeForeground"`
-	InputOptionActiveBackground *Color `json:"inputOption.activeBackground"`
-
-	InputValidationInfoForeground    *Color `json:"inputValidation.infoForeground"`
-	InputValidationInfoBackground    *Color `json:"inputValidation.infoBackground"`
-	InputValidationInfoBorder        *Color `json:"inputValidation.infoBorder"`
-	InputValidationWarningForeground *Color `json:"inputValidation.warningForeground"`
-	InputValidationWarningBackground *Color `json:"inputValidation.warningBackground"`
-	InputValidationWarningBorder     *Color `json:"inputValidation.warningBorder"`
-	InputValidationErrorForeground   *Color `json:"inputValidation.errorForeground"`
-	InputValidationErrorBackground   *Color `json:"inputValidation.errorBackground"`
-	InputValidationErrorBorder       *Color `json:"inputValidation.errorBorder"`
-
-	DropdownForeground     *Color `json:"dropdown.foreground"`
-	DropdownBackground     *Color `json:"dropdown.background"`
-	DropdownListBackground *Color `json:"dropdown.listBackground"`
-
-	TreeIndentGuidesStroke *Color `json:"tree.indentGuidesStroke"`
-
-	// Nested objects
-	ActivityBar      ActivityBarColors    `json:"activityBar"`
-	ActivityBarBadge BadgeColors          `json:"activityBarBadge"`
-	ActivityBarTop   ActivityBarTopColors `json:"activityBarTop"`
-
-	SideBar                SideBarColors       `json:"sideBar"`
-	SideBarTitleForeground *Color              `json:"sideBarTitle.foreground"`
-	SideBarSectionHeader   SideBarHeaderColors `json:"sideBarSectionHeader"`
-	SideBarDropBackground  *Color              `json:"sideBar.dropBackground"`
-}
-
-// Example nested structs
-type ActivityBarColors struct {
-	Background         *Color `json:"background"`
-	Foreground         *Color `json:"foreground"`
-	InactiveForeground *Color `json:"inactiveForeground"`
-	Border             *Color `json:"border"`
-}
-
-type BadgeColors struct {
-	Background *Color `json:"background"`
-	Foreground *Color `json:"foreground"`
-}
-
-type ActivityBarTopColors struct {
-	Foreground         *Color `json:"foreground"`
-	InactiveForeground *Color `json:"inactiveForeground"`
-}
-
-type SideBarColors struct {
-	Background *Color `json:"background"`
-	Foreground *Color `json:"foreground"`
-	Border     *Color `json:"border"`
-}
-
-type SideBarHeaderColors struct {
-	Background *Color `json:"background"`
-	Foreground *Color `json:"foreground"`
-	Border     *Color `json:"border"`
+	Background          *Color `json:"editor.background" abstract:"SpecialColors.Background"`
+	Foreground          *Color `json:"editor.foreground" abstract:"SpecialColors.Foreground"`
+	Cursor              *Color `json:"editorCursor.foreground" abstract:"SpecialColors.Cursor"`
+	CursorText          *Color `json:"editorCursor.background" abstract:"SpecialColors.CursorText"`
+	SelectionBackground *Color `json:"editor.selectionBackground" abstract:"SpecialColors.Selection"`
+	SelectionForeground *Color `json:"editor.selectionForeground" abstract:"SpecialColors.SelectedText"`
+	FindMatch           *Color `json:"editor.findMatchBackground" abstract:"SpecialColors.FindMatch"`
+	TextLink            *Color `json:"textLink.foreground" abstract:"SpecialColors.Links"`
+	LineHighlight       *Color `json:"editor.lineHighlightBackground" abstract:"ScopeColors.Editor.CursorLine"`
+	LineNumber          *Color `json:"editorLineNumber.foreground" abstract:"ScopeColors.Editor.LineNumbers"`
+	WordHighlight       *Color `json:"editor.wordHighlightBackground" abstract:"ScopeColors.Editor.Highlight"`
+
+	AnsiBlack         *Color `json:"terminal.ansiBlack" abstract:"AnsiColors.Black"`
+	AnsiRed           *Color `json:"terminal.ansiRed" abstract:"AnsiColors.Red"`
+	AnsiGreen         *Color `json:"terminal.ansiGreen" abstract:"AnsiColors.Green"`
+	AnsiYellow        *Color `json:"terminal.ansiYellow" abstract:"AnsiColors.Yellow"`
+	AnsiBlue          *Color `json:"terminal.ansiBlue" abstract:"AnsiColors.Blue"`
+	AnsiMagenta       *Color `json:"terminal.ansiMagenta" abstract:"AnsiColors.Magenta"`
+	AnsiCyan          *Color `json:"terminal.ansiCyan" abstract:"AnsiColors.Cyan"`
+	AnsiWhite         *Color `json:"terminal.ansiWhite" abstract:"AnsiColors.White"`
+	AnsiBrightBlack   *Color `json:"terminal.ansiBrightBlack" abstract:"AnsiColors.BrightBlack"`
+	AnsiBrightRed     *Color `json:"terminal.ansiBrightRed" abstract:"AnsiColors.BrightRed"`
+	AnsiBrightGreen   *Color `json:"terminal.ansiBrightGreen" abstract:"AnsiColors.BrightGreen"`
+	AnsiBrightYellow  *Color `json:"terminal.ansiBrightYellow" abstract:"AnsiColors.BrightYellow"`
+	AnsiBrightBlue    *Color `json:"terminal.ansiBrightBlue" abstract:"AnsiColors.BrightBlue"`
+	AnsiBrightMagenta *Color `json:"terminal.ansiBrightMagenta" abstract:"AnsiColors.BrightMagenta"`
+	AnsiBrightCyan    *Color `json:"terminal.ansiBrightCyan" abstract:"AnsiColors.BrightCyan"`
+	AnsiBrightWhite   *Color `json:"terminal.ansiBrightWhite" abstract:"AnsiColors.BrightWhite"`
+
+	Other map[string]string `json:"-"`
 }
 
+// Scopes are the token colors with an abstract counterpart. The scope tag
+// lists the TextMate scopes of each, most specific to the abstract field
+// first; a field takes the color of the first scope the theme sets.
+type Scopes struct {
+	Comment  *Color `scope:"comment" abstract:"ScopeColors.Basic.Comment"`
+	Keyword  *Color `scope:"keyword,keyword.control,storage" abstract:"ScopeColors.Basic.Keyword"`
+	Constant *Color `scope:"constant,constant.language" abstract:"ScopeColors.Basic.Constant"`
+	String   *Color `scope:"string" abstract:"ScopeColors.Basic.String"`
+	Number   *Color `scope:"constant.numeric" abstract:"ScopeColors.Basic.Number"`
+	Function *Color `scope:"entity.name.function,support.function" abstract:"ScopeColors.Basic.Function"`
+	Variable *Color `scope:"variable,variable.other" abstract:"ScopeColors.Basic.Variable"`
+	Operator *Color `scope:"keyword.operator" abstract:"ScopeColors.Basic.Operator"`
+
+	Class     *Color `scope:"entity.name.class,entity.name.type.class" abstract:"ScopeColors.Advanced.Class"`
+	Type      *Color `scope:"entity.name.type,storage.type,support.type" abstract:"ScopeColors.Advanced.Type"`
+	Property  *Color `scope:"variable.other.property,support.type.property-name,meta.property-name" abstract:"ScopeColors.Advanced.Property"`
+	Attribute *Color `scope:"entity.other.attribute-name" abstract:"ScopeColors.Advanced.Attribute"`
+	Tag       *Color `scope:"entity.name.tag" abstract:"ScopeColors.Advanced.Tag"`
+	Namespace *Color `scope:"entity.name.namespace,entity.name.type.namespace" abstract:"ScopeColors.Advanced.Namespace"`
+	Parameter *Color `scope:"variable.parameter" abstract:"ScopeColors.Advanced.Parameter"`
+	Selector  *Color `scope:"meta.selector,entity.other.attribute-name.class.css" abstract:"ScopeColors.Advanced.Selector"`
+
+	Heading     *Color `scope:"markup.heading,entity.name.section" abstract:"ScopeColors.Markup.Heading"`
+	Bold        *Color `scope:"markup.bold" abstract:"ScopeColors.Markup.Bold"`
+	Italic      *Color `scope:"markup.italic" abstract:"ScopeColors.Markup.Italic"`
+	Underline   *Color `scope:"markup.underline" abstract:"ScopeColors.Markup.Underline"`
+	Link        *Color `scope:"markup.underline.link" abstract:"ScopeColors.Markup.Link"`
+	Quote       *Color `scope:"markup.quote" abstract:"ScopeColors.Markup.Quote"`
+	List        *Color `scope:"markup.list,punctuation.definition.list" abstract:"ScopeColors.Markup.List"`
+	CodeBlock   *Color `scope:"markup.fenced_code,markup.raw.block" abstract:"ScopeColors.Markup.CodeBlock"`
+	RawText     *Color `scope:"markup.raw,markup.inline.raw" abstract:"ScopeColors.Markup.RawText"`
+	TemplateTag *Color `scope:"punctuation.definition.template-expression,punctuation.section.embedded" abstract:"ScopeColors.Markup.TemplateTag"`
+
+	Invalid    *Color `scope:"invalid,invalid.illegal" abstract:"ScopeColors.Diagnostics.Invalid"`
+	Deprecated *Color `scope:"invalid.deprecated" abstract:"ScopeColors.Diagnostics.Deprecated"`
+
+	Meta       *Color `scope:"meta" abstract:"ScopeColors.Miscellaneous.Meta"`
+	Annotation *Color `scope:"meta.decorator,storage.type.annotation" abstract:"ScopeColors.Miscellaneous.Annotation"`
+	Regex      *Color `scope:"string.regexp" abstract:"ScopeColors.Miscellaneous.Regex"`
+}
+
+func (rw *VSCodeTheme) Name() string {
+	return "vscode"
+}
+
+func (rw *VSCodeTheme) TemplateName() string {
+	return "vscode.json.tmpl"
+}
+
+// FromString parses a color theme. Comments and trailing commas, which VS Code
+// allows in theme files, are accepted.
 func (rw *VSCodeTheme) FromString(input string) error {
-	err := json.Unmarshal([]byte(input), rw)
-	if err != nil {
+	data := []byte(jsonc.Strip(input))
+
+	var doc struct {
+		*VSCodeTheme
+		TokenColors []tokenColor `json:"tokenColors"`
+	}
+	doc.VSCodeTheme = rw
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	rw.TokenColors.fill(doc.TokenColors)
+	return nil
+}
+
+// tokenColor is a single entry of tokenColors
+type tokenColor struct {
+	Name     string    `json:"name"`
+	Scope    scopeList `json:"scope"`
+	Settings struct {
+		Foreground *Color `json:"foreground"`
+		FontStyle  string `json:"fontStyle"`
+	} `json:"settings"`
+}
+
+// scopeList holds the scopes of a token color, given either as an array or
+// as a comma separated string
+type scopeList []string
+
+func (l *scopeList) UnmarshalJSON(data []byte) error {
+	var scopes []string
+	if err := json.Unmarshal(data, &scopes); err != nil {
+		var joined string
+		if err := json.Unmarshal(data, &joined); err != nil {
+			return fmt.Errorf("scope must be a string or an array of strings")
+		}
+		scopes = strings.Split(joined, ",")
+	}
+	for i := range scopes {
+		scopes[i] = strings.TrimSpace(scopes[i])
+	}
+	*l = scopes
+	return nil
+}
+
+// fill sets each scope field from the token colors. Later token colors
+// override earlier ones, as they do in VS Code. Selectors with more than one
+// scope, like "meta.var.expr storage.type", only apply in context and are
+// ignored.
+func (s *Scopes) fill(tokenColors []tokenColor) {
+	colors := make(map[string]*Color)
+	for _, tc := range tokenColors {
+		if tc.Settings.Foreground == nil {
+			continue
+		}
+		for _, scope := range tc.Scope {
+			colors[scope] = tc.Settings.Foreground
+		}
+	}
+
+	v := reflect.ValueOf(s).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		for _, scope := range strings.Split(v.Type().Field(i).Tag.Get("scope"), ",") {
+			if c, ok := colors[scope]; ok {
+				value := *c
+				v.Field(i).Set(reflect.ValueOf(&value))
+				break
+			}
+		}
+	}
+}
+
+// UnmarshalJSON reads the known colors into their fields and keeps the rest in
+// Other. Values that aren't strings, like null, are skipped.
+func (c *Colors) UnmarshalJSON(data []byte) error {
+	type plain Colors
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
 		return err
 	}
+
+	var all map[string]any
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	known := colorKeys()
+	for key, value := range all {
+		str, ok := value.(string)
+		if !ok || known[key] {
+			continue
+		}
+		if c.Other == nil {
+			c.Other = make(map[string]string)
+		}
+		c.Other[key] = str
+	}
 	return nil
 }
+
+// colorKeys returns the JSON keys of the fields of Colors
+func colorKeys() map[string]bool {
+	keys := make(map[string]bool)
+	t := reflect.TypeOf(Colors{})
+	for i := 0; i < t.NumField(); i++ {
+		if key := t.Field(i).Tag.Get("json"); key != "-" {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// ColorEntry is a single key of the colors object: a color, or for the other
+// colors a value kept as written
+type ColorEntry struct {
+	Key   string
+	Color *Color
+	Value string
+}
+
+// ColorEntries returns the colors in field order, unset ones included so the
+// template decides what to do with them
+func (rw *VSCodeTheme) ColorEntries() []ColorEntry {
+	var entries []ColorEntry
+	v := reflect.ValueOf(rw.Colors)
+	for i := 0; i < v.NumField(); i++ {
+		if c, ok := v.Field(i).Interface().(*Color); ok {
+			entries = append(entries, ColorEntry{Key: v.Type().Field(i).Tag.Get("json"), Color: c})
+		}
+	}
+	return entries
+}
+
+// OtherColorEntries returns the other colors sorted by key
+func (rw *VSCodeTheme) OtherColorEntries() []ColorEntry {
+	keys := make([]string, 0, len(rw.Colors.Other))
+	for key := range rw.Colors.Other {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]ColorEntry, len(keys))
+	for i, key := range keys {
+		entries[i] = ColorEntry{Key: key, Value: rw.Colors.Other[key]}
+	}
+	return entries
+}
+
+// TokenColorEntry is a single entry of tokenColors
+type TokenColorEntry struct {
+	Name       string
+	Scopes     []string
+	Foreground *Color
+}
+
+// TokenColorEntries returns an entry for every scope field in field order,
+// unset ones included
+func (rw *VSCodeTheme) TokenColorEntries() []TokenColorEntry {
+	var entries []TokenColorEntry
+	v := reflect.ValueOf(rw.TokenColors)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		entries = append(entries, TokenColorEntry{
+			Name:       field.Name,
+			Scopes:     strings.Split(field.Tag.Get("scope"), ","),
+			Foreground: v.Field(i).Interface().(*Color),
+		})
+	}
+	return entries
+}
+
+// ThemeType returns the theme's type, dark or light, which decides the
+// defaults VS Code uses for unset colors. It follows from the background, so
+// the type a theme was read with isn't kept.
+func (rw *VSCodeTheme) ThemeType() string {
+	if bg := rw.Colors.Background; bg != nil && bg.ToOKLCH().L > 0.5 {
+		return "light"
+	}
+	return "dark"
+}
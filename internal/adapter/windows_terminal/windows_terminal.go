@@ -1,9 +1,12 @@
 package windows_terminal
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 
 	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/jsonc"
 )
 
 type Color = color.Color
@@ -39,10 +42,51 @@ func (rw *WindowsTerminalScheme) TemplateName() string {
 	return "wt.json.tmpl"
 }
 
+func (rw *WindowsTerminalScheme) BundleTemplateName() string {
+	return "wt.bundle.json.tmpl"
+}
+
 func (rw *WindowsTerminalScheme) FromString(input string) error {
-	err := json.Unmarshal([]byte(input), rw)
+	err := json.Unmarshal([]byte(jsonc.Strip(input)), rw)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// SplitSchemes accepts the schemes array of a settings.json, either within the
+// whole file or on its own, as well as a single scheme
+func (rw *WindowsTerminalScheme) SplitSchemes(input string) ([]string, []string, error) {
+	data := bytes.TrimSpace([]byte(jsonc.Strip(input)))
+
+	var schemes []json.RawMessage
+	if bytes.HasPrefix(data, []byte("[")) {
+		if err := json.Unmarshal(data, &schemes); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		var settings map[string]json.RawMessage
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return nil, nil, err
+		}
+		schemes = []json.RawMessage{data}
+		if raw, ok := settings["schemes"]; ok {
+			if err := json.Unmarshal(raw, &schemes); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	names := make([]string, len(schemes))
+	docs := make([]string, len(schemes))
+	for i, scheme := range schemes {
+		var named struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(scheme, &named); err != nil {
+			return nil, nil, fmt.Errorf("scheme %d: %w", i, err)
+		}
+		names[i], docs[i] = named.Name, string(scheme)
+	}
+	return names, docs, nil
+}
@@ -0,0 +1,124 @@
+package color
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Lighten returns the color with its OKLCH lightness raised by amount, where
+// lightness runs from 0 to 1, so 0.1 lightens by 10%. Hue is kept, and chroma
+// is kept as far as sRGB allows.
+func (c Color) Lighten(amount float64) Color {
+	lch := c.ToOKLCH()
+	lch.L += amount
+	return FromOKLCHInGamut(lch, c.Alpha)
+}
+
+// Darken returns the color with its OKLCH lightness lowered by amount
+func (c Color) Darken(amount float64) Color {
+	return c.Lighten(-amount)
+}
+
+// Saturate returns the color with its OKLCH chroma raised by amount, as far as
+// sRGB allows
+func (c Color) Saturate(amount float64) Color {
+	lch := c.ToOKLCH()
+	lch.C = max(0, lch.C+amount)
+	return FromOKLCHInGamut(lch, c.Alpha)
+}
+
+// Desaturate returns the color with its OKLCH chroma lowered by amount
+func (c Color) Desaturate(amount float64) Color {
+	return c.Saturate(-amount)
+}
+
+// RotateHue returns the color with its OKLCH hue rotated by the given degrees
+func (c Color) RotateHue(degrees float64) Color {
+	lch := c.ToOKLCH()
+	lch.H = normalizeHue(lch.H + degrees)
+	return FromOKLCHInGamut(lch, c.Alpha)
+}
+
+// WithAlpha returns the color with its alpha replaced
+func (c Color) WithAlpha(alpha float64) Color {
+	c.Alpha = clamp01(alpha)
+	return c
+}
+
+// Transform derives a new color from an existing one
+type Transform func(Color) Color
+
+// transforms are the functions a transform expression can call, each taking a
+// single numeric argument
+var transforms = map[string]func(Color, float64) Color{
+	"lighten":    Color.Lighten,
+	"darken":     Color.Darken,
+	"saturate":   Color.Saturate,
+	"desaturate": Color.Desaturate,
+	"rotate":     Color.RotateHue,
+	"alpha":      Color.WithAlpha,
+}
+
+// inverses undo the transforms as far as they can. Alpha can't be recovered,
+// so the inverse of alpha makes the color opaque.
+var inverses = map[string]func(Color, float64) Color{
+	"lighten":    Color.Darken,
+	"darken":     Color.Lighten,
+	"saturate":   Color.Desaturate,
+	"desaturate": Color.Saturate,
+	"rotate":     func(c Color, degrees float64) Color { return c.RotateHue(-degrees) },
+	"alpha":      func(c Color, _ float64) Color { return c.WithAlpha(1) },
+}
+
+// ParseTransform parses a pipeline of transforms separated by "|", such as
+// "lighten(0.1)|alpha(0.5)", applied from left to right
+func ParseTransform(expr string) (Transform, error) {
+	return parsePipeline(expr, transforms, false)
+}
+
+// ParseInverseTransform parses a pipeline like ParseTransform, returning the
+// transform that undoes it: the inverse of each step, from right to left
+func ParseInverseTransform(expr string) (Transform, error) {
+	return parsePipeline(expr, inverses, true)
+}
+
+// parsePipeline parses a pipeline with the given functions, its steps applied
+// from right to left if reverse is set
+func parsePipeline(expr string, fns map[string]func(Color, float64) Color, reverse bool) (Transform, error) {
+	var steps []Transform
+	for _, step := range strings.Split(expr, "|") {
+		t, err := parseTransformStep(strings.TrimSpace(step), fns)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transform %q: %w", expr, err)
+		}
+		if reverse {
+			steps = append([]Transform{t}, steps...)
+		} else {
+			steps = append(steps, t)
+		}
+	}
+	return func(c Color) Color {
+		for _, step := range steps {
+			c = step(c)
+		}
+		return c
+	}, nil
+}
+
+func parseTransformStep(step string, fns map[string]func(Color, float64) Color) (Transform, error) {
+	open := strings.IndexByte(step, '(')
+	if open < 0 || !strings.HasSuffix(step, ")") {
+		return nil, fmt.Errorf("expected name(argument), got %q", step)
+	}
+	name := strings.TrimSpace(step[:open])
+	fn, ok := fns[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q", name)
+	}
+	arg, err := strconv.ParseFloat(strings.TrimSpace(step[open+1:len(step)-1]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid argument to %s: %w", name, err)
+	}
+	return func(c Color) Color { return fn(c, arg) }, nil
+}
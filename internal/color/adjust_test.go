@@ -0,0 +1,78 @@
+package color_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+func TestAdjust(t *testing.T) {
+	red := color.NewColor(0.8, 0.1, 0.1, 1)
+	base := red.ToOKLCH()
+
+	lighter := red.Lighten(0.1).ToOKLCH()
+	if math.Abs(lighter.L-base.L-0.1) > 1e-6 {
+		t.Errorf("expected lightness to rise by 0.1, got %.4f -> %.4f", base.L, lighter.L)
+	}
+	if math.Abs(lighter.H-base.H) > 0.5 {
+		t.Errorf("expected hue to be kept, got %.2f -> %.2f", base.H, lighter.H)
+	}
+
+	if darker := red.Darken(0.1).ToOKLCH(); math.Abs(base.L-darker.L-0.1) > 1e-6 {
+		t.Errorf("expected lightness to drop by 0.1, got %.4f -> %.4f", base.L, darker.L)
+	}
+	if gray := red.Desaturate(1).ToOKLCH(); gray.C > 1e-6 {
+		t.Errorf("expected no chroma left, got %+v", gray)
+	}
+	if rotated := red.RotateHue(180).ToOKLCH(); math.Abs(math.Mod(rotated.H-base.H+360, 360)-180) > 0.5 {
+		t.Errorf("expected hue to rotate by 180, got %.2f -> %.2f", base.H, rotated.H)
+	}
+	if white := red.Lighten(2); white.ToHex(true) != "#FFFFFF" {
+		t.Errorf("expected lightening past white to clamp, got %s", white.AsString())
+	}
+}
+
+func TestParseTransform(t *testing.T) {
+	c := color.NewColor(0.2, 0.4, 0.6, 1)
+
+	transform, err := color.ParseTransform("lighten(0.1) | alpha(0.5)")
+	if err != nil {
+		t.Fatalf("ParseTransform failed: %v", err)
+	}
+	want := c.Lighten(0.1).WithAlpha(0.5)
+	assertColorsClose(t, "pipeline", transform(c), want, 1e-12)
+
+	for _, expr := range []string{"", "lighten", "lighten()", "brighten(0.1)", "lighten(0.1", "alpha(x)"} {
+		if _, err := color.ParseTransform(expr); err == nil {
+			t.Errorf("expected an error for %q", expr)
+		}
+		if _, err := color.ParseInverseTransform(expr); err == nil {
+			t.Errorf("expected an error inverting %q", expr)
+		}
+	}
+}
+
+func TestParseInverseTransform(t *testing.T) {
+	c := color.NewColor(0.2, 0.4, 0.6, 1)
+	for _, expr := range []string{"lighten(0.1)", "darken(0.1)", "saturate(0.02)", "desaturate(0.02)", "rotate(30)", "lighten(0.1) | rotate(-45)"} {
+		transform, err := color.ParseTransform(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inverse, err := color.ParseInverseTransform(expr)
+		if err != nil {
+			t.Fatalf("ParseInverseTransform failed: %v", err)
+		}
+		assertColorsClose(t, expr, inverse(transform(c)), c, 1e-9)
+	}
+
+	// Alpha can't be recovered, so its inverse makes the color opaque
+	inverse, err := color.ParseInverseTransform("alpha(0.3)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := inverse(c.WithAlpha(0.3)); got.Alpha != 1 {
+		t.Errorf("expected an opaque color, got alpha %g", got.Alpha)
+	}
+}
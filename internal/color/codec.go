@@ -3,7 +3,6 @@ package color
 import (
 	"encoding/json"
 	"fmt"
-	"html/template"
 )
 
 // Implements the plist.Marshaler and plist.Unmarshaler behavior via plist tags
@@ -33,13 +32,13 @@ func (c *Color) UnmarshalPlist(unmarshal func(interface{}) error) error {
 	return nil
 }
 
-func (c *Color) ToITermXML() template.HTML {
-	var r, g, b float64
+// ToITermXML returns the color as the dict of components used by iTerm2, or
+// "" for a nil color, like Hex
+func (c *Color) ToITermXML() string {
 	if c == nil {
-		r, g, b = 0, 0, 0 // or whatever default you want
-	} else {
-		r, g, b = c.Red, c.Green, c.Blue
+		return ""
 	}
+	r, g, b := c.Red, c.Green, c.Blue
 
 	dict := fmt.Sprintf(
 		`<dict>
@@ -50,7 +49,7 @@ func (c *Color) ToITermXML() template.HTML {
     <key>Red Component</key>
     <real>%f</real>
 </dict>`, b, g, r)
-	return template.HTML(dict)
+	return dict
 }
 
 // UnmarshalYAML allows YAML to deserialize directly into the Color type.
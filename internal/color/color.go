@@ -2,6 +2,7 @@ package color
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 	"strconv"
 	"sync"
@@ -31,9 +32,10 @@ func (c Color) AsString() string {
 	return fmt.Sprintf("RGBA(%.3f, %.3f, %.3f, %.3f)", c.Red, c.Green, c.Blue, c.Alpha)
 }
 
-// ToRGB converts the color to an RGB tuple.
+// ToRGB converts the color to an RGB tuple, rounding each component to the
+// nearest 8-bit value.
 func (c Color) ToRGB() (int, int, int) {
-	return int(c.Red * 255), int(c.Green * 255), int(c.Blue * 255)
+	return int(clampFloatToUint8(c.Red)), int(clampFloatToUint8(c.Green)), int(clampFloatToUint8(c.Blue))
 }
 
 // ToHex converts the color to a hexadecimal string.
@@ -46,13 +48,42 @@ func (c Color) ToHex(octothorpe bool) string {
 	return hex
 }
 
-// FromHex creates a Color instance from a hexadecimal string.
+// Quantized returns the color with each component rounded to the nearest
+// 8-bit value, so that it is unchanged by a round trip through hex
+func (c Color) Quantized() Color {
+	q := func(f float64) float64 {
+		return float64(clampFloatToUint8(f)) / 255
+	}
+	return Color{Red: q(c.Red), Green: q(c.Green), Blue: q(c.Blue), Alpha: q(c.Alpha)}
+}
+
+// ToHexAlpha converts the color to a hexadecimal string, appending the alpha
+// component only if the color is not opaque.
+func (c Color) ToHexAlpha(octothorpe bool) string {
+	hex := c.ToHex(octothorpe)
+	if a := clampFloatToUint8(c.Alpha); a != 255 {
+		hex += fmt.Sprintf("%02X", a)
+	}
+	return hex
+}
+
+// FromHex creates a Color instance from a hexadecimal string, in the form
+// RGB, RGBA, RRGGBB or RRGGBBAA with an optional leading '#'.
 func FromHex(hex string) (Color, error) {
 
 	hex = trimOctothorpe(hex)
 
+	// Expand the short forms #RGB and #RGBA
+	if len(hex) == 3 || len(hex) == 4 {
+		long := make([]byte, 0, 2*len(hex))
+		for i := 0; i < len(hex); i++ {
+			long = append(long, hex[i], hex[i])
+		}
+		hex = string(long)
+	}
+
 	if len(hex) != 6 && len(hex) != 8 {
-		return Color{}, fmt.Errorf("hex color must be 6 or 8 characters long: %s", hex)
+		return Color{}, fmt.Errorf("hex color must be 3, 4, 6 or 8 characters long: %s", hex)
 	}
 
 	r, err := strconv.ParseInt(hex[0:2], 16, 64)
@@ -138,12 +169,15 @@ func clampFloatToUint8(f float64) uint8 {
 	if f > 1 {
 		return 255
 	}
-	return uint8(f * 255)
+	return uint8(math.Round(f * 255))
 }
 
+// Hex returns the color as #rrggbb, or "" for a nil color: templates resolve
+// missing colors through the missing color policy, never through a default
+// here
 func (c *Color) Hex() string {
 	if c == nil {
-		return "#ff0000" // default 100% red
+		return ""
 	}
 	r := clampFloatToUint8(c.Red)
 	g := clampFloatToUint8(c.Green)
@@ -151,12 +185,13 @@ func (c *Color) Hex() string {
 	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
 }
 
-func ColorsSimilar(c1, c2 Color, tol float64) bool {
-	dAlpha := c1.Alpha - c2.Alpha
-	dRed := c1.Red - c2.Red
-	dGreen := c1.Green - c2.Green
-	dBlue := c1.Blue - c2.Blue
-
-	distSq := dAlpha*dAlpha + dRed*dRed + dGreen*dGreen + dBlue*dBlue
-	return distSq <= tol*tol
+// HexAlpha is Hex with the alpha component appended if the color is not opaque
+func (c *Color) HexAlpha() string {
+	if c == nil {
+		return ""
+	}
+	if a := clampFloatToUint8(c.Alpha); a != 255 {
+		return fmt.Sprintf("%s%02x", c.Hex(), a)
+	}
+	return c.Hex()
 }
@@ -0,0 +1,61 @@
+package color_test
+
+import (
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+func TestFromHex(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"#1a2B3c", "#1A2B3C"},
+		{"1a2b3c", "#1A2B3C"},
+		{"#1a2b3c80", "#1A2B3C80"},
+		{"#abc", "#AABBCC"},
+		{"#abc8", "#AABBCC88"},
+		{"#ffffffff", "#FFFFFF"},
+	}
+	for _, tc := range tests {
+		c, err := color.FromHex(tc.in)
+		if err != nil {
+			t.Errorf("FromHex(%q) failed: %v", tc.in, err)
+			continue
+		}
+		if got := c.ToHexAlpha(true); got != tc.want {
+			t.Errorf("FromHex(%q) = %s, want %s", tc.in, got, tc.want)
+		}
+	}
+
+	for _, in := range []string{"", "#12", "#12345", "#1234567", "#ggg"} {
+		if _, err := color.FromHex(in); err == nil {
+			t.Errorf("expected an error for %q", in)
+		}
+	}
+}
+
+func TestToHex_Rounds(t *testing.T) {
+	c := color.NewColor(0.999, 0.5, 0.002, 1)
+	if got := c.ToHex(true); got != "#FF8001" {
+		t.Errorf("expected components to round to the nearest value, got %s", got)
+	}
+	if got := c.Quantized().ToHex(true); got != "#FF8001" {
+		t.Errorf("expected quantizing to keep the hex value, got %s", got)
+	}
+}
+
+// A missing color has no value of its own, so nothing fills it in silently
+func TestNilColor(t *testing.T) {
+	var c *color.Color
+	if got := c.Hex(); got != "" {
+		t.Errorf("expected Hex of a nil color to be empty, got %q", got)
+	}
+	if got := c.HexAlpha(); got != "" {
+		t.Errorf("expected HexAlpha of a nil color to be empty, got %q", got)
+	}
+	if got := c.ToITermXML(); got != "" {
+		t.Errorf("expected ToITermXML of a nil color to be empty, got %q", got)
+	}
+}
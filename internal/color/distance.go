@@ -0,0 +1,230 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DistanceMetric selects how the difference between two colors is measured.
+// All metrics ignore alpha, which VisuallyIdentical compares separately.
+type DistanceMetric int
+
+const (
+	// CIEDE2000 is the CIE's most accurate ΔE formula, and the default
+	CIEDE2000 DistanceMetric = iota
+	// CIE94 is ΔE with the graphic arts weights
+	CIE94
+	// CIE76 is the Euclidean distance in CIELAB
+	CIE76
+	// OKLabDeltaE is the Euclidean distance in OKLab
+	OKLabDeltaE
+	// EuclideanRGB is the Euclidean distance between sRGB components in [0, 1].
+	// It is not perceptual, and only kept for comparing raw values.
+	EuclideanRGB
+)
+
+var metricNames = map[DistanceMetric]string{
+	CIEDE2000:    "ciede2000",
+	CIE94:        "cie94",
+	CIE76:        "cie76",
+	OKLabDeltaE:  "oklab",
+	EuclideanRGB: "rgb",
+}
+
+func (m DistanceMetric) String() string {
+	if name, ok := metricNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("DistanceMetric(%d)", int(m))
+}
+
+// ParseDistanceMetric returns the metric with the given name, as printed by String
+func ParseDistanceMetric(name string) (DistanceMetric, error) {
+	for m, n := range metricNames {
+		if strings.EqualFold(n, name) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown distance metric %q", name)
+}
+
+// JustNoticeable returns the distance below which two colors are generally
+// seen as identical under the metric
+func (m DistanceMetric) JustNoticeable() float64 {
+	switch m {
+	case CIE76:
+		return 2.3
+	case OKLabDeltaE:
+		return 0.02
+	case EuclideanRGB:
+		return 0.01
+	default: // CIE94, CIEDE2000
+		return 1.0
+	}
+}
+
+// Distance returns the difference between two colors under the metric
+func (m DistanceMetric) Distance(c1, c2 Color) float64 {
+	switch m {
+	case CIE76:
+		return deltaE76(c1.ToLab(), c2.ToLab())
+	case CIE94:
+		return deltaE94(c1.ToLab(), c2.ToLab())
+	case OKLabDeltaE:
+		a, b := c1.ToOKLab(), c2.ToOKLab()
+		return math.Sqrt(sq(a.L-b.L) + sq(a.A-b.A) + sq(a.B-b.B))
+	case EuclideanRGB:
+		return math.Sqrt(sq(c1.Red-c2.Red) + sq(c1.Green-c2.Green) + sq(c1.Blue-c2.Blue))
+	default:
+		return deltaE2000(c1.ToLab(), c2.ToLab())
+	}
+}
+
+// Similar reports whether two colors are within tol of each other under the
+// metric, and their alphas are within tol of each other as well
+func Similar(c1, c2 Color, metric DistanceMetric, tol float64) bool {
+	return math.Abs(c1.Alpha-c2.Alpha) <= tol && metric.Distance(c1, c2) <= tol
+}
+
+// VisuallyIdentical reports whether two colors can't be told apart: their
+// distance is below the metric's just noticeable difference and their alphas
+// round to the same 8-bit value
+func VisuallyIdentical(c1, c2 Color, metric DistanceMetric) bool {
+	return math.Abs(c1.Alpha-c2.Alpha) <= 0.5/255 &&
+		metric.Distance(c1, c2) < metric.JustNoticeable()
+}
+
+// Closest returns the index of the color in palette closest to c under the
+// metric, along with its distance. It returns -1 for an empty palette.
+func Closest(c Color, palette []Color, metric DistanceMetric) (int, float64) {
+	best, bestDist := -1, math.Inf(1)
+	for i, p := range palette {
+		if d := metric.Distance(c, p); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best, bestDist
+}
+
+// Dedup returns colors without those visually identical to an earlier color,
+// keeping the order of the first occurrences
+func Dedup(colors []Color, metric DistanceMetric) []Color {
+	var unique []Color
+	for _, c := range colors {
+		duplicate := false
+		for _, u := range unique {
+			if VisuallyIdentical(c, u, metric) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			unique = append(unique, c)
+		}
+	}
+	return unique
+}
+
+func sq(f float64) float64 {
+	return f * f
+}
+
+func deltaE76(a, b Lab) float64 {
+	return math.Sqrt(sq(a.L-b.L) + sq(a.A-b.A) + sq(a.B-b.B))
+}
+
+// deltaE94 uses the graphic arts weights (kL = 1, K1 = 0.045, K2 = 0.015)
+func deltaE94(a, b Lab) float64 {
+	c1 := math.Hypot(a.A, a.B)
+	c2 := math.Hypot(b.A, b.B)
+
+	dL := a.L - b.L
+	dC := c1 - c2
+	// ΔH² = Δa² + Δb² - ΔC², which can dip below zero through rounding
+	dH2 := math.Max(0, sq(a.A-b.A)+sq(a.B-b.B)-sq(dC))
+
+	sC := 1 + 0.045*c1
+	sH := 1 + 0.015*c1
+
+	return math.Sqrt(sq(dL) + sq(dC/sC) + dH2/sq(sH))
+}
+
+// deltaE2000 follows Sharma, Wu and Dalal, "The CIEDE2000 Color-Difference
+// Formula: Implementation Notes, Supplementary Test Data, and Mathematical
+// Observations" (2005), with kL = kC = kH = 1
+func deltaE2000(lab1, lab2 Lab) float64 {
+	const pow25to7 = 6103515625.0 // 25^7
+	deg := math.Pi / 180
+
+	c1 := math.Hypot(lab1.A, lab1.B)
+	c2 := math.Hypot(lab2.A, lab2.B)
+	cBar7 := math.Pow((c1+c2)/2, 7)
+	g := 0.5 * (1 - math.Sqrt(cBar7/(cBar7+pow25to7)))
+
+	a1 := (1 + g) * lab1.A
+	a2 := (1 + g) * lab2.A
+	c1p := math.Hypot(a1, lab1.B)
+	c2p := math.Hypot(a2, lab2.B)
+
+	hue := func(b, a float64) float64 {
+		if a == 0 && b == 0 {
+			return 0
+		}
+		return normalizeHue(math.Atan2(b, a) / deg)
+	}
+	h1p := hue(lab1.B, a1)
+	h2p := hue(lab2.B, a2)
+
+	dLp := lab2.L - lab1.L
+	dCp := c2p - c1p
+
+	var dhp float64
+	switch {
+	case c1p*c2p == 0:
+		dhp = 0
+	case math.Abs(h2p-h1p) <= 180:
+		dhp = h2p - h1p
+	case h2p-h1p > 180:
+		dhp = h2p - h1p - 360
+	default:
+		dhp = h2p - h1p + 360
+	}
+	dHp := 2 * math.Sqrt(c1p*c2p) * math.Sin(dhp/2*deg)
+
+	lBarp := (lab1.L + lab2.L) / 2
+	cBarp := (c1p + c2p) / 2
+
+	var hBarp float64
+	switch {
+	case c1p*c2p == 0:
+		hBarp = h1p + h2p
+	case math.Abs(h1p-h2p) <= 180:
+		hBarp = (h1p + h2p) / 2
+	case h1p+h2p < 360:
+		hBarp = (h1p + h2p + 360) / 2
+	default:
+		hBarp = (h1p + h2p - 360) / 2
+	}
+
+	t := 1 -
+		0.17*math.Cos((hBarp-30)*deg) +
+		0.24*math.Cos(2*hBarp*deg) +
+		0.32*math.Cos((3*hBarp+6)*deg) -
+		0.20*math.Cos((4*hBarp-63)*deg)
+
+	dTheta := 30 * math.Exp(-sq((hBarp-275)/25))
+	cBarp7 := math.Pow(cBarp, 7)
+	rC := 2 * math.Sqrt(cBarp7/(cBarp7+pow25to7))
+	sL := 1 + 0.015*sq(lBarp-50)/math.Sqrt(20+sq(lBarp-50))
+	sC := 1 + 0.045*cBarp
+	sH := 1 + 0.015*cBarp*t
+	rT := -math.Sin(2*dTheta*deg) * rC
+
+	return math.Sqrt(
+		sq(dLp/sL) +
+			sq(dCp/sC) +
+			sq(dHp/sH) +
+			rT*(dCp/sC)*(dHp/sH),
+	)
+}
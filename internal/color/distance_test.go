@@ -0,0 +1,141 @@
+package color_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+// fromLab builds a color from CIELAB, so metrics can be checked against
+// published Lab test data
+func fromLab(l, a, b float64) color.Color {
+	return color.FromLab(color.Lab{L: l, A: a, B: b}, 1)
+}
+
+// Pairs from Sharma, Wu and Dalal's CIEDE2000 supplementary test data
+func TestCIEDE2000_SharmaData(t *testing.T) {
+	tests := []struct {
+		lab1, lab2 [3]float64
+		want       float64
+	}{
+		{[3]float64{50, 2.6772, -79.7751}, [3]float64{50, 0, -82.7485}, 2.0425},
+		{[3]float64{50, 3.1571, -77.2803}, [3]float64{50, 0, -82.7485}, 2.8615},
+		{[3]float64{50, 2.8361, -74.0200}, [3]float64{50, 0, -82.7485}, 3.4412},
+		{[3]float64{50, -1.3802, -84.2814}, [3]float64{50, 0, -82.7485}, 1.0000},
+		{[3]float64{50, 0, 0}, [3]float64{50, -1, 2}, 2.3669},
+		{[3]float64{50, 2.49, -0.001}, [3]float64{50, -2.49, 0.0011}, 7.2195},
+		{[3]float64{50, 2.5, 0}, [3]float64{73, 25, -18}, 27.1492},
+		{[3]float64{60.2574, -34.0099, 36.2677}, [3]float64{60.4626, -34.1751, 39.4387}, 1.2644},
+		{[3]float64{22.7233, 20.0904, -46.6940}, [3]float64{23.0331, 14.9730, -42.5619}, 2.0373},
+		{[3]float64{90.8027, -2.0831, 1.4410}, [3]float64{91.1528, -1.6435, 0.0447}, 1.4441},
+	}
+
+	for _, tc := range tests {
+		c1 := fromLab(tc.lab1[0], tc.lab1[1], tc.lab1[2])
+		c2 := fromLab(tc.lab2[0], tc.lab2[1], tc.lab2[2])
+		got := color.CIEDE2000.Distance(c1, c2)
+		if math.Abs(got-tc.want) > 1e-4 {
+			t.Errorf("ΔE2000(%v, %v) = %.4f, want %.4f", tc.lab1, tc.lab2, got, tc.want)
+		}
+	}
+}
+
+func TestDistance_KnownValues(t *testing.T) {
+	c1 := fromLab(50, 0, 0)
+	c2 := fromLab(53, 4, 0)
+
+	if got := color.CIE76.Distance(c1, c2); math.Abs(got-5) > 1e-6 {
+		t.Errorf("ΔE76 = %f, want 5", got)
+	}
+	// Pure lightness and chroma differences from a neutral reference
+	if got := color.CIE94.Distance(c1, c2); math.Abs(got-5) > 1e-6 {
+		t.Errorf("ΔE94 = %f, want 5", got)
+	}
+
+	black := color.NewColor(0, 0, 0, 1)
+	white := color.NewColor(1, 1, 1, 1)
+	if got := color.OKLabDeltaE.Distance(black, white); math.Abs(got-1) > 1e-6 {
+		t.Errorf("OKLab ΔE(black, white) = %f, want 1", got)
+	}
+	if got := color.EuclideanRGB.Distance(black, white); math.Abs(got-math.Sqrt(3)) > 1e-9 {
+		t.Errorf("RGB distance(black, white) = %f, want √3", got)
+	}
+}
+
+func TestDistance_Properties(t *testing.T) {
+	metrics := []color.DistanceMetric{
+		color.CIEDE2000, color.CIE94, color.CIE76, color.OKLabDeltaE, color.EuclideanRGB,
+	}
+	colors := sampleColors()
+
+	for _, m := range metrics {
+		t.Run(m.String(), func(t *testing.T) {
+			for i := 0; i < len(colors); i += 7 {
+				a, b := colors[i], colors[(i*13)%len(colors)]
+				if d := m.Distance(a, a); d > 1e-9 {
+					t.Errorf("distance of %s to itself is %f", a.ToHex(true), d)
+				}
+				// CIE94 is asymmetric by definition, as it weighs by the first color
+				if m != color.CIE94 && math.Abs(m.Distance(a, b)-m.Distance(b, a)) > 1e-9 {
+					t.Errorf("distance between %s and %s is asymmetric", a.ToHex(true), b.ToHex(true))
+				}
+			}
+		})
+	}
+}
+
+func TestParseDistanceMetric(t *testing.T) {
+	for _, m := range []color.DistanceMetric{color.CIEDE2000, color.CIE94, color.CIE76, color.OKLabDeltaE, color.EuclideanRGB} {
+		parsed, err := color.ParseDistanceMetric(m.String())
+		if err != nil || parsed != m {
+			t.Errorf("ParseDistanceMetric(%q) = %v, %v", m.String(), parsed, err)
+		}
+	}
+	if _, err := color.ParseDistanceMetric("nope"); err == nil {
+		t.Errorf("expected an error for an unknown metric")
+	}
+}
+
+func TestVisuallyIdentical(t *testing.T) {
+	base, _ := color.FromHex("#336699")
+	nudged, _ := color.FromHex("#336799")
+	distinct, _ := color.FromHex("#3366CC")
+
+	if !color.VisuallyIdentical(base, nudged, color.CIEDE2000) {
+		t.Errorf("expected a one step difference to be invisible")
+	}
+	if color.VisuallyIdentical(base, distinct, color.CIEDE2000) {
+		t.Errorf("expected %s and %s to be distinguishable", base.ToHex(true), distinct.ToHex(true))
+	}
+
+	transparent := base
+	transparent.Alpha = 0.5
+	if color.VisuallyIdentical(base, transparent, color.CIEDE2000) {
+		t.Errorf("expected a difference in alpha to be visible")
+	}
+}
+
+func TestClosestAndDedup(t *testing.T) {
+	red, _ := color.FromHex("#FF0000")
+	nearRed, _ := color.FromHex("#FE0101")
+	green, _ := color.FromHex("#00FF00")
+	blue, _ := color.FromHex("#0000FF")
+	orange, _ := color.FromHex("#FF6600")
+
+	palette := []color.Color{green, blue, red}
+	if i, _ := color.Closest(orange, palette, color.CIEDE2000); i != 2 {
+		t.Errorf("expected orange to be closest to red, got index %d", i)
+	}
+	if i, _ := color.Closest(orange, nil, color.CIEDE2000); i != -1 {
+		t.Errorf("expected -1 for an empty palette, got %d", i)
+	}
+
+	unique := color.Dedup([]color.Color{red, green, nearRed, blue, red}, color.CIEDE2000)
+	if len(unique) != 3 {
+		t.Fatalf("expected 3 unique colors, got %d", len(unique))
+	}
+	if unique[0] != red || unique[1] != green || unique[2] != blue {
+		t.Errorf("expected first occurrences in order, got %v", unique)
+	}
+}
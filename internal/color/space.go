@@ -0,0 +1,475 @@
+package color
+
+import "math"
+
+// Conversions between sRGB, which Color stores, and other color spaces. Hues
+// are in degrees in [0, 360), all other components are unbounded floats, so
+// values outside the sRGB gamut survive a round trip through them. Use
+// InGamut and Clamped before presenting such a color.
+
+// HSL is the hue, saturation and lightness form of an sRGB color
+type HSL struct {
+	H float64 // Hue in degrees
+	S float64 // Saturation in [0, 1]
+	L float64 // Lightness in [0, 1]
+}
+
+// HSV is the hue, saturation and value form of an sRGB color
+type HSV struct {
+	H float64 // Hue in degrees
+	S float64 // Saturation in [0, 1]
+	V float64 // Value in [0, 1]
+}
+
+// LinearRGB is an sRGB color with the transfer function removed
+type LinearRGB struct {
+	R, G, B float64
+}
+
+// XYZ is a CIE 1931 XYZ color relative to the D65 white point, with Y = 1 for white
+type XYZ struct {
+	X, Y, Z float64
+}
+
+// Lab is a CIELAB color relative to the D65 white point
+type Lab struct {
+	L float64 // Lightness in [0, 100]
+	A float64 // Green (negative) to red (positive)
+	B float64 // Blue (negative) to yellow (positive)
+}
+
+// LCh is the cylindrical form of CIELAB
+type LCh struct {
+	L float64 // Lightness in [0, 100]
+	C float64 // Chroma
+	H float64 // Hue in degrees
+}
+
+// OKLab is a color in Björn Ottosson's perceptual OKLab space
+type OKLab struct {
+	L float64 // Lightness in [0, 1]
+	A float64 // Green (negative) to red (positive)
+	B float64 // Blue (negative) to yellow (positive)
+}
+
+// OKLCH is the cylindrical form of OKLab
+type OKLCH struct {
+	L float64 // Lightness in [0, 1]
+	C float64 // Chroma
+	H float64 // Hue in degrees
+}
+
+// matrix3 is a row-major 3x3 matrix
+type matrix3 [3][3]float64
+
+func (m matrix3) mul(a, b, c float64) (float64, float64, float64) {
+	return m[0][0]*a + m[0][1]*b + m[0][2]*c,
+		m[1][0]*a + m[1][1]*b + m[1][2]*c,
+		m[2][0]*a + m[2][1]*b + m[2][2]*c
+}
+
+// inverse returns the inverse of m. The published inverses of the matrices
+// below are rounded to fewer digits than a float64 holds, which would limit
+// round trips to around 1e-7, so they are derived from the forward matrices.
+func (m matrix3) inverse() matrix3 {
+	det := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+
+	return matrix3{
+		{
+			(m[1][1]*m[2][2] - m[1][2]*m[2][1]) / det,
+			(m[0][2]*m[2][1] - m[0][1]*m[2][2]) / det,
+			(m[0][1]*m[1][2] - m[0][2]*m[1][1]) / det,
+		},
+		{
+			(m[1][2]*m[2][0] - m[1][0]*m[2][2]) / det,
+			(m[0][0]*m[2][2] - m[0][2]*m[2][0]) / det,
+			(m[0][2]*m[1][0] - m[0][0]*m[1][2]) / det,
+		},
+		{
+			(m[1][0]*m[2][1] - m[1][1]*m[2][0]) / det,
+			(m[0][1]*m[2][0] - m[0][0]*m[2][1]) / det,
+			(m[0][0]*m[1][1] - m[0][1]*m[1][0]) / det,
+		},
+	}
+}
+
+var (
+	// Linear sRGB to XYZ (D65)
+	linearToXYZ = matrix3{
+		{0.4124564, 0.3575761, 0.1804375},
+		{0.2126729, 0.7151522, 0.0721750},
+		{0.0193339, 0.1191920, 0.9503041},
+	}
+	xyzToLinear = linearToXYZ.inverse()
+
+	// Linear sRGB to OKLab's cone responses (LMS)
+	linearToLMS = matrix3{
+		{0.4122214708, 0.5363325363, 0.0514459929},
+		{0.2119034982, 0.6806995451, 0.1073969566},
+		{0.0883024619, 0.2817188376, 0.6299787005},
+	}
+	lmsToLinear = linearToLMS.inverse()
+
+	// Nonlinear LMS to OKLab
+	lmsToOKLab = matrix3{
+		{0.2104542553, 0.7936177850, -0.0040720468},
+		{1.9779984951, -2.4285922050, 0.4505937099},
+		{0.0259040371, 0.7827717662, -0.8086757660},
+	}
+	okLabToLMS = lmsToOKLab.inverse()
+
+	// D65 reference white in XYZ, taken from the matrix so that sRGB grays
+	// are exactly achromatic in CIELAB
+	whiteD65 = LinearRGB{R: 1, G: 1, B: 1}.ToXYZ()
+)
+
+// InGamut reports whether all RGBA components are within [0, 1], allowing for
+// floating point error
+func (c Color) InGamut() bool {
+	const eps = 1e-9
+	for _, v := range []float64{c.Red, c.Green, c.Blue, c.Alpha} {
+		if v < -eps || v > 1+eps {
+			return false
+		}
+	}
+	return true
+}
+
+// Clamped returns the color with all RGBA components clamped to [0, 1]
+func (c Color) Clamped() Color {
+	return Color{
+		Red:   clamp01(c.Red),
+		Green: clamp01(c.Green),
+		Blue:  clamp01(c.Blue),
+		Alpha: clamp01(c.Alpha),
+	}
+}
+
+func clamp01(f float64) float64 {
+	return math.Max(0, math.Min(1, f))
+}
+
+// normalizeHue wraps a hue in degrees to [0, 360)
+func normalizeHue(h float64) float64 {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// -----------------------------------------------------------------------------
+// HSL and HSV
+// -----------------------------------------------------------------------------
+
+// hueChroma returns the hue in degrees along with the max and min component
+func (c Color) hueChroma() (h, max, min float64) {
+	r, g, b := c.Red, c.Green, c.Blue
+	max = math.Max(r, math.Max(g, b))
+	min = math.Min(r, math.Min(g, b))
+	chroma := max - min
+
+	switch {
+	case chroma == 0:
+		h = 0
+	case max == r:
+		h = 60 * math.Mod((g-b)/chroma, 6)
+	case max == g:
+		h = 60 * ((b-r)/chroma + 2)
+	default:
+		h = 60 * ((r-g)/chroma + 4)
+	}
+	return normalizeHue(h), max, min
+}
+
+// ToHSL converts the color to HSL
+func (c Color) ToHSL() HSL {
+	h, max, min := c.hueChroma()
+	l := (max + min) / 2
+
+	s := 0.0
+	if max != min {
+		s = (max - min) / (1 - math.Abs(2*l-1))
+	}
+	return HSL{H: h, S: s, L: l}
+}
+
+// FromHSL creates a Color from HSL with the given alpha
+func FromHSL(hsl HSL, alpha float64) Color {
+	chroma := (1 - math.Abs(2*hsl.L-1)) * hsl.S
+	return fromHueChroma(hsl.H, chroma, hsl.L-chroma/2, alpha)
+}
+
+// ToHSV converts the color to HSV
+func (c Color) ToHSV() HSV {
+	h, max, min := c.hueChroma()
+
+	s := 0.0
+	if max != 0 {
+		s = (max - min) / max
+	}
+	return HSV{H: h, S: s, V: max}
+}
+
+// FromHSV creates a Color from HSV with the given alpha
+func FromHSV(hsv HSV, alpha float64) Color {
+	chroma := hsv.V * hsv.S
+	return fromHueChroma(hsv.H, chroma, hsv.V-chroma, alpha)
+}
+
+// fromHueChroma builds a color from a hue, a chroma and the smallest component
+func fromHueChroma(h, chroma, min, alpha float64) Color {
+	h = normalizeHue(h) / 60
+	x := chroma * (1 - math.Abs(math.Mod(h, 2)-1))
+
+	var r, g, b float64
+	switch {
+	case h < 1:
+		r, g, b = chroma, x, 0
+	case h < 2:
+		r, g, b = x, chroma, 0
+	case h < 3:
+		r, g, b = 0, chroma, x
+	case h < 4:
+		r, g, b = 0, x, chroma
+	case h < 5:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+	return Color{Red: r + min, Green: g + min, Blue: b + min, Alpha: alpha}
+}
+
+// -----------------------------------------------------------------------------
+// Linear sRGB and XYZ
+// -----------------------------------------------------------------------------
+
+// linearize removes the sRGB transfer function from a component
+func linearize(v float64) float64 {
+	sign := 1.0
+	if v < 0 {
+		sign, v = -1, -v
+	}
+	if v <= 0.04045 {
+		return sign * v / 12.92
+	}
+	return sign * math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// delinearize applies the sRGB transfer function to a linear component
+func delinearize(v float64) float64 {
+	sign := 1.0
+	if v < 0 {
+		sign, v = -1, -v
+	}
+	if v <= 0.0031308 {
+		return sign * v * 12.92
+	}
+	return sign * (1.055*math.Pow(v, 1/2.4) - 0.055)
+}
+
+// ToLinearRGB converts the color to linear sRGB
+func (c Color) ToLinearRGB() LinearRGB {
+	return LinearRGB{R: linearize(c.Red), G: linearize(c.Green), B: linearize(c.Blue)}
+}
+
+// FromLinearRGB creates a Color from linear sRGB with the given alpha
+func FromLinearRGB(lin LinearRGB, alpha float64) Color {
+	return Color{
+		Red:   delinearize(lin.R),
+		Green: delinearize(lin.G),
+		Blue:  delinearize(lin.B),
+		Alpha: alpha,
+	}
+}
+
+// ToXYZ converts linear sRGB to XYZ
+func (lin LinearRGB) ToXYZ() XYZ {
+	x, y, z := linearToXYZ.mul(lin.R, lin.G, lin.B)
+	return XYZ{X: x, Y: y, Z: z}
+}
+
+// ToLinearRGB converts XYZ to linear sRGB
+func (xyz XYZ) ToLinearRGB() LinearRGB {
+	r, g, b := xyzToLinear.mul(xyz.X, xyz.Y, xyz.Z)
+	return LinearRGB{R: r, G: g, B: b}
+}
+
+// ToXYZ converts the color to CIE XYZ
+func (c Color) ToXYZ() XYZ {
+	return c.ToLinearRGB().ToXYZ()
+}
+
+// FromXYZ creates a Color from CIE XYZ with the given alpha
+func FromXYZ(xyz XYZ, alpha float64) Color {
+	return FromLinearRGB(xyz.ToLinearRGB(), alpha)
+}
+
+// -----------------------------------------------------------------------------
+// CIELAB and LCh
+// -----------------------------------------------------------------------------
+
+const (
+	labEpsilon = 216.0 / 24389.0
+	labKappa   = 24389.0 / 27.0
+)
+
+// ToLab converts XYZ to CIELAB
+func (xyz XYZ) ToLab() Lab {
+	f := func(t float64) float64 {
+		if t > labEpsilon {
+			return math.Cbrt(t)
+		}
+		return (labKappa*t + 16) / 116
+	}
+	fx := f(xyz.X / whiteD65.X)
+	fy := f(xyz.Y / whiteD65.Y)
+	fz := f(xyz.Z / whiteD65.Z)
+
+	return Lab{L: 116*fy - 16, A: 500 * (fx - fy), B: 200 * (fy - fz)}
+}
+
+// ToXYZ converts CIELAB to XYZ
+func (lab Lab) ToXYZ() XYZ {
+	fy := (lab.L + 16) / 116
+	fx := fy + lab.A/500
+	fz := fy - lab.B/200
+
+	finv := func(f float64) float64 {
+		if f3 := f * f * f; f3 > labEpsilon {
+			return f3
+		}
+		return (116*f - 16) / labKappa
+	}
+
+	var y float64
+	if lab.L > labKappa*labEpsilon {
+		y = fy * fy * fy
+	} else {
+		y = lab.L / labKappa
+	}
+	return XYZ{X: finv(fx) * whiteD65.X, Y: y * whiteD65.Y, Z: finv(fz) * whiteD65.Z}
+}
+
+// ToLCh converts CIELAB to its cylindrical form
+func (lab Lab) ToLCh() LCh {
+	l, c, h := toPolar(lab.L, lab.A, lab.B)
+	return LCh{L: l, C: c, H: h}
+}
+
+// ToLab converts LCh to CIELAB
+func (lch LCh) ToLab() Lab {
+	l, a, b := fromPolar(lch.L, lch.C, lch.H)
+	return Lab{L: l, A: a, B: b}
+}
+
+// ToLab converts the color to CIELAB
+func (c Color) ToLab() Lab {
+	return c.ToXYZ().ToLab()
+}
+
+// FromLab creates a Color from CIELAB with the given alpha
+func FromLab(lab Lab, alpha float64) Color {
+	return FromXYZ(lab.ToXYZ(), alpha)
+}
+
+// ToLCh converts the color to CIELAB LCh
+func (c Color) ToLCh() LCh {
+	return c.ToLab().ToLCh()
+}
+
+// FromLCh creates a Color from CIELAB LCh with the given alpha
+func FromLCh(lch LCh, alpha float64) Color {
+	return FromLab(lch.ToLab(), alpha)
+}
+
+// -----------------------------------------------------------------------------
+// OKLab and OKLCH
+// -----------------------------------------------------------------------------
+
+// ToOKLab converts linear sRGB to OKLab
+func (lin LinearRGB) ToOKLab() OKLab {
+	l, m, s := linearToLMS.mul(lin.R, lin.G, lin.B)
+	L, a, b := lmsToOKLab.mul(math.Cbrt(l), math.Cbrt(m), math.Cbrt(s))
+	return OKLab{L: L, A: a, B: b}
+}
+
+// ToLinearRGB converts OKLab to linear sRGB
+func (ok OKLab) ToLinearRGB() LinearRGB {
+	l, m, s := okLabToLMS.mul(ok.L, ok.A, ok.B)
+	r, g, b := lmsToLinear.mul(l*l*l, m*m*m, s*s*s)
+	return LinearRGB{R: r, G: g, B: b}
+}
+
+// ToOKLCH converts OKLab to its cylindrical form
+func (ok OKLab) ToOKLCH() OKLCH {
+	l, c, h := toPolar(ok.L, ok.A, ok.B)
+	return OKLCH{L: l, C: c, H: h}
+}
+
+// ToOKLab converts OKLCH to OKLab
+func (lch OKLCH) ToOKLab() OKLab {
+	l, a, b := fromPolar(lch.L, lch.C, lch.H)
+	return OKLab{L: l, A: a, B: b}
+}
+
+// ToOKLab converts the color to OKLab
+func (c Color) ToOKLab() OKLab {
+	return c.ToLinearRGB().ToOKLab()
+}
+
+// FromOKLab creates a Color from OKLab with the given alpha
+func FromOKLab(ok OKLab, alpha float64) Color {
+	return FromLinearRGB(ok.ToLinearRGB(), alpha)
+}
+
+// ToOKLCH converts the color to OKLCH
+func (c Color) ToOKLCH() OKLCH {
+	return c.ToOKLab().ToOKLCH()
+}
+
+// FromOKLCH creates a Color from OKLCH with the given alpha
+func FromOKLCH(lch OKLCH, alpha float64) Color {
+	return FromOKLab(lch.ToOKLab(), alpha)
+}
+
+// FromOKLCHInGamut creates a Color from OKLCH with the given alpha, reducing
+// chroma as little as possible to fit in sRGB while keeping lightness and hue
+func FromOKLCHInGamut(lch OKLCH, alpha float64) Color {
+	lch.L = clamp01(lch.L)
+	if c := FromOKLCH(lch, alpha); c.InGamut() {
+		return c.Clamped()
+	}
+
+	lo, hi := 0.0, lch.C
+	for i := 0; i < 32; i++ {
+		mid := (lo + hi) / 2
+		if FromOKLCH(OKLCH{L: lch.L, C: mid, H: lch.H}, alpha).InGamut() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return FromOKLCH(OKLCH{L: lch.L, C: lo, H: lch.H}, alpha).Clamped()
+}
+
+// -----------------------------------------------------------------------------
+// Helpers
+// -----------------------------------------------------------------------------
+
+// toPolar converts the a and b axes of a Lab-like space to chroma and hue
+func toPolar(l, a, b float64) (float64, float64, float64) {
+	c := math.Hypot(a, b)
+	if c == 0 {
+		return l, 0, 0
+	}
+	return l, c, normalizeHue(math.Atan2(b, a) * 180 / math.Pi)
+}
+
+// fromPolar converts chroma and hue of a Lab-like space to the a and b axes
+func fromPolar(l, c, h float64) (float64, float64, float64) {
+	rad := h * math.Pi / 180
+	return l, c * math.Cos(rad), c * math.Sin(rad)
+}
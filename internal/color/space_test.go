@@ -0,0 +1,149 @@
+package color_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+const roundTripTolerance = 1e-9
+
+// sampleColors returns a grid over the sRGB cube, including its corners
+func sampleColors() []color.Color {
+	steps := []float64{0, 0.02, 0.1, 0.25, 0.5, 0.75, 0.9, 1}
+	var colors []color.Color
+	for _, r := range steps {
+		for _, g := range steps {
+			for _, b := range steps {
+				colors = append(colors, color.NewColor(r, g, b, 0.5))
+			}
+		}
+	}
+	return colors
+}
+
+func assertColorsClose(t *testing.T, label string, got, want color.Color, tol float64) {
+	t.Helper()
+	diffs := []float64{
+		got.Red - want.Red,
+		got.Green - want.Green,
+		got.Blue - want.Blue,
+		got.Alpha - want.Alpha,
+	}
+	for _, d := range diffs {
+		if math.Abs(d) > tol {
+			t.Errorf("%s: got %s, want %s", label, got.AsString(), want.AsString())
+			return
+		}
+	}
+}
+
+func TestColorSpaces_RoundTrip(t *testing.T) {
+	conversions := []struct {
+		name      string
+		roundTrip func(color.Color) color.Color
+	}{
+		{"HSL", func(c color.Color) color.Color { return color.FromHSL(c.ToHSL(), c.Alpha) }},
+		{"HSV", func(c color.Color) color.Color { return color.FromHSV(c.ToHSV(), c.Alpha) }},
+		{"LinearRGB", func(c color.Color) color.Color { return color.FromLinearRGB(c.ToLinearRGB(), c.Alpha) }},
+		{"XYZ", func(c color.Color) color.Color { return color.FromXYZ(c.ToXYZ(), c.Alpha) }},
+		{"Lab", func(c color.Color) color.Color { return color.FromLab(c.ToLab(), c.Alpha) }},
+		{"LCh", func(c color.Color) color.Color { return color.FromLCh(c.ToLCh(), c.Alpha) }},
+		{"OKLab", func(c color.Color) color.Color { return color.FromOKLab(c.ToOKLab(), c.Alpha) }},
+		{"OKLCH", func(c color.Color) color.Color { return color.FromOKLCH(c.ToOKLCH(), c.Alpha) }},
+	}
+
+	for _, conv := range conversions {
+		t.Run(conv.name, func(t *testing.T) {
+			for _, c := range sampleColors() {
+				assertColorsClose(t, c.ToHex(true), conv.roundTrip(c), c, roundTripTolerance)
+			}
+		})
+	}
+}
+
+func TestColorSpaces_ReferenceValues(t *testing.T) {
+	red := color.NewColor(1, 0, 0, 1)
+	white := color.NewColor(1, 1, 1, 1)
+	teal := color.NewColor(0, 0.5, 0.5, 1)
+
+	tests := []struct {
+		name string
+		got  []float64
+		want []float64
+		tol  float64
+	}{
+		{"HSL teal", hsl(teal.ToHSL()), []float64{180, 1, 0.25}, 1e-9},
+		{"HSV teal", hsv(teal.ToHSV()), []float64{180, 1, 0.5}, 1e-9},
+		{"XYZ white", xyz(white.ToXYZ()), []float64{0.95047, 1, 1.08883}, 1e-4},
+		{"Lab white", lab(white.ToLab()), []float64{100, 0, 0}, 1e-3},
+		{"Lab red", lab(red.ToLab()), []float64{53.2408, 80.0925, 67.2032}, 1e-3},
+		{"LCh red", lch(red.ToLCh()), []float64{53.2408, 104.5518, 39.9990}, 1e-3},
+		{"OKLab white", oklab(white.ToOKLab()), []float64{1, 0, 0}, 1e-6},
+		{"OKLab red", oklab(red.ToOKLab()), []float64{0.627955, 0.224863, 0.125846}, 1e-5},
+		{"OKLCH red", oklch(red.ToOKLCH()), []float64{0.627955, 0.257683, 29.2339}, 1e-4},
+	}
+
+	for _, tc := range tests {
+		for i := range tc.want {
+			if math.Abs(tc.got[i]-tc.want[i]) > tc.tol {
+				t.Errorf("%s: got %v, want %v", tc.name, tc.got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestColorSpaces_Achromatic(t *testing.T) {
+	gray := color.NewColor(0.5, 0.5, 0.5, 1)
+	if lch := gray.ToOKLCH(); lch.C > 1e-6 {
+		t.Errorf("expected gray to have no chroma, got %+v", lch)
+	}
+	if lch := gray.ToLCh(); lch.C > 1e-6 {
+		t.Errorf("expected gray to have no chroma, got %+v", lch)
+	}
+	if h := gray.ToHSL(); h.S != 0 || h.H != 0 {
+		t.Errorf("expected gray to have no saturation or hue, got %+v", h)
+	}
+}
+
+func TestColor_Gamut(t *testing.T) {
+	// A very saturated OKLCH green lies outside sRGB
+	outside := color.FromOKLCH(color.OKLCH{L: 0.8, C: 0.4, H: 140}, 1)
+	if outside.InGamut() {
+		t.Errorf("expected %s to be out of gamut", outside.AsString())
+	}
+	if clamped := outside.Clamped(); !clamped.InGamut() {
+		t.Errorf("expected clamped color to be in gamut, got %s", clamped.AsString())
+	}
+	if !color.NewColor(0, 0.5, 1, 1).InGamut() {
+		t.Errorf("expected in-range color to be in gamut")
+	}
+}
+
+func TestFromOKLCHInGamut(t *testing.T) {
+	want := color.OKLCH{L: 0.8, C: 0.4, H: 140}
+	got := color.FromOKLCHInGamut(want, 1)
+	if !got.InGamut() {
+		t.Fatalf("expected an in-gamut color, got %s", got.AsString())
+	}
+	lch := got.ToOKLCH()
+	if math.Abs(lch.L-want.L) > 1e-6 || math.Abs(lch.H-want.H) > 1e-3 {
+		t.Errorf("expected lightness and hue to be kept, got %+v", lch)
+	}
+	if lch.C >= want.C {
+		t.Errorf("expected chroma to be reduced, got %+v", lch)
+	}
+
+	inside := color.OKLCH{L: 0.5, C: 0.05, H: 200}
+	assertColorsClose(t, "in gamut", color.FromOKLCHInGamut(inside, 1), color.FromOKLCH(inside, 1), 1e-9)
+}
+
+func hsl(v color.HSL) []float64     { return []float64{v.H, v.S, v.L} }
+func hsv(v color.HSV) []float64     { return []float64{v.H, v.S, v.V} }
+func xyz(v color.XYZ) []float64     { return []float64{v.X, v.Y, v.Z} }
+func lab(v color.Lab) []float64     { return []float64{v.L, v.A, v.B} }
+func lch(v color.LCh) []float64     { return []float64{v.L, v.C, v.H} }
+func oklab(v color.OKLab) []float64 { return []float64{v.L, v.A, v.B} }
+func oklch(v color.OKLCH) []float64 { return []float64{v.L, v.C, v.H} }
@@ -0,0 +1,51 @@
+package color
+
+// xtermSystem are xterm's default values of the 16 system colors, which
+// terminals usually let themes change
+var xtermSystem = []int{
+	0x000000, 0xcd0000, 0x00cd00, 0xcdcd00, 0x0000ee, 0xcd00cd, 0x00cdcd, 0xe5e5e5,
+	0x7f7f7f, 0xff0000, 0x00ff00, 0xffff00, 0x5c5cff, 0xff00ff, 0x00ffff, 0xffffff,
+}
+
+// xterm256 is the xterm 256-color palette: the 16 system colors, a 6x6x6
+// color cube and a ramp of 24 grays
+var xterm256 = func() []Color {
+	rgb := func(r, g, b int) Color {
+		return NewColor(float64(r)/255, float64(g)/255, float64(b)/255, 1)
+	}
+	palette := make([]Color, 256)
+	for i, hex := range xtermSystem {
+		palette[i] = rgb(hex>>16, hex>>8&0xff, hex&0xff)
+	}
+	levels := []int{0, 95, 135, 175, 215, 255}
+	for i := 0; i < 216; i++ {
+		palette[16+i] = rgb(levels[i/36], levels[i/6%6], levels[i%6])
+	}
+	for i := 0; i < 24; i++ {
+		gray := 8 + 10*i
+		palette[232+i] = rgb(gray, gray, gray)
+	}
+	return palette
+}()
+
+// Xterm256 returns the color of the xterm 256-color palette at the index,
+// with xterm's defaults for the 16 system colors
+func Xterm256(index int) Color {
+	return xterm256[index]
+}
+
+// NearestXterm256 returns the index of the color of the xterm 256-color
+// palette closest to c under the metric. Only the color cube and the grays, 16
+// to 255, are considered, as the system colors below them vary with the
+// terminal's theme.
+func (c Color) NearestXterm256(metric DistanceMetric) int {
+	i, _ := Closest(c, xterm256[16:], metric)
+	return 16 + i
+}
+
+// NearestXterm16 returns the index of the system color closest to c under the
+// metric, taking the system colors to have xterm's default values
+func (c Color) NearestXterm16(metric DistanceMetric) int {
+	i, _ := Closest(c, xterm256[:16], metric)
+	return i
+}
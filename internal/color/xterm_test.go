@@ -0,0 +1,58 @@
+package color
+
+import "testing"
+
+// Colors of the palette map onto themselves
+func TestNearestXterm256(t *testing.T) {
+	for _, metric := range []DistanceMetric{CIEDE2000, OKLabDeltaE} {
+		for _, i := range []int{16, 21, 46, 196, 231, 232, 244, 255} {
+			if got := Xterm256(i).NearestXterm256(metric); got != i {
+				t.Errorf("%s: expected %d (%s), got %d", metric, i, Xterm256(i).ToHex(true), got)
+			}
+		}
+	}
+
+	tests := []struct {
+		hex  string
+		want int
+	}{
+		{"#ff0505", 196},
+		{"#000000", 16},
+		{"#ffffff", 231},
+		{"#0c0c0c", 232},
+		{"#808080", 244},
+		{"#5f87af", 67},
+	}
+	for _, tc := range tests {
+		c, err := FromHex(tc.hex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.NearestXterm256(CIEDE2000); got != tc.want {
+			t.Errorf("%s: expected %d, got %d", tc.hex, tc.want, got)
+		}
+	}
+}
+
+func TestNearestXterm16(t *testing.T) {
+	tests := []struct {
+		hex  string
+		want int
+	}{
+		{"#000000", 0},
+		{"#c50f1f", 1},
+		{"#13a10e", 2},
+		{"#767676", 8},
+		{"#f2f2f2", 15},
+		{"#3b78ff", 12},
+	}
+	for _, tc := range tests {
+		c, err := FromHex(tc.hex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.NearestXterm16(CIEDE2000); got != tc.want {
+			t.Errorf("%s: expected %d (%s), got %d (%s)", tc.hex, tc.want, Xterm256(tc.want).ToHex(true), got, Xterm256(got).ToHex(true))
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package contrast
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/structutil"
+)
+
+// Pair names a foreground field that is drawn on top of a background field,
+// both as dot-separated paths into an AbstractScheme
+type Pair struct {
+	Foreground string `json:"foreground"`
+	Background string `json:"background"`
+}
+
+// Result is the contrast of a single pair
+type Result struct {
+	Pair
+	ForegroundColor string  `json:"foreground_color"`
+	BackgroundColor string  `json:"background_color"`
+	Ratio           float64 `json:"ratio"` // WCAG contrast ratio
+	Lc              float64 `json:"lc"`    // APCA lightness contrast
+	WCAG            Level   `json:"wcag"`  // Highest WCAG level reached
+	APCA            Level   `json:"apca"`  // Highest APCA level reached
+}
+
+// Level returns the highest level the pair reaches under the standard
+func (r Result) Level(standard Standard) Level {
+	if standard == APCA {
+		return r.APCA
+	}
+	return r.WCAG
+}
+
+// Report is the result of auditing a scheme
+type Report struct {
+	Results []Result `json:"results"`
+	Skipped []Pair   `json:"skipped"` // Pairs with a color missing from the scheme
+}
+
+// Failing returns the results that don't reach the level under the standard
+func (r *Report) Failing(standard Standard, level Level) []Result {
+	var failing []Result
+	for _, res := range r.Results {
+		if res.Level(standard) < level {
+			failing = append(failing, res)
+		}
+	}
+	return failing
+}
+
+// JSON returns the report as indented JSON
+func (r *Report) JSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// String returns the report as a human-readable table
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-28s %-26s %-7s %-7s %6s %5s %7s %5s\n",
+		"Foreground", "Background", "Fg", "Bg", "Ratio", "WCAG", "Lc", "APCA")
+	for _, res := range r.Results {
+		fmt.Fprintf(&b, "%-28s %-26s %-7s %-7s %6.2f %5s %7.1f %5s\n",
+			res.Foreground, res.Background,
+			res.ForegroundColor, res.BackgroundColor,
+			res.Ratio, res.WCAG, res.Lc, res.APCA)
+	}
+	for _, p := range r.Skipped {
+		fmt.Fprintf(&b, "%-28s %-26s skipped, missing a color\n", p.Foreground, p.Background)
+	}
+	return b.String()
+}
+
+// ansiNames are the ANSI colors in palette order
+var ansiNames = []string{
+	"Black", "Red", "Green", "Yellow", "Blue", "Magenta", "Cyan", "White",
+	"BrightBlack", "BrightRed", "BrightGreen", "BrightYellow",
+	"BrightBlue", "BrightMagenta", "BrightCyan", "BrightWhite",
+}
+
+// SchemePairs returns the pairs an audit checks: every ANSI color and the
+// foreground against the background, selected text against the selection,
+// and cursor text against the cursor
+func SchemePairs() []Pair {
+	var pairs []Pair
+	for _, name := range ansiNames {
+		pairs = append(pairs, Pair{"AnsiColors." + name, "SpecialColors.Background"})
+	}
+	return append(pairs,
+		Pair{"SpecialColors.Foreground", "SpecialColors.Background"},
+		Pair{"SpecialColors.SelectedText", "SpecialColors.Selection"},
+		Pair{"SpecialColors.CursorText", "SpecialColors.Cursor"},
+	)
+}
+
+// lookupColor returns the color field at the path, which is settable when
+// the scheme is passed by pointer
+func lookupColor(s *adapter.AbstractScheme, path string) (reflect.Value, error) {
+	found, _, value := structutil.HasNestedFieldSlice(reflect.ValueOf(s), strings.Split(path, "."))
+	if !found {
+		return reflect.Value{}, fmt.Errorf("scheme has no field %q", path)
+	}
+	if _, ok := value.Interface().(*color.Color); !ok {
+		return reflect.Value{}, fmt.Errorf("field %q is not a color", path)
+	}
+	return value, nil
+}
+
+// getColor returns the color at the path, or nil if it is unset
+func getColor(s *adapter.AbstractScheme, path string) (*color.Color, error) {
+	value, err := lookupColor(s, path)
+	if err != nil {
+		return nil, err
+	}
+	return value.Interface().(*color.Color), nil
+}
+
+// Check measures the contrast of a single pair of colors
+func Check(pair Pair, fg, bg color.Color) Result {
+	ratio := Ratio(fg, bg)
+	lc := LightnessContrast(fg, bg)
+	return Result{
+		Pair:            pair,
+		ForegroundColor: fg.ToHex(true),
+		BackgroundColor: bg.ToHex(true),
+		Ratio:           ratio,
+		Lc:              lc,
+		WCAG:            LevelOf(WCAG, ratio),
+		APCA:            LevelOf(APCA, math.Abs(lc)),
+	}
+}
+
+// Audit checks the contrast of every pair from SchemePairs in the scheme
+func Audit(s *adapter.AbstractScheme) (*Report, error) {
+	return AuditPairs(s, SchemePairs())
+}
+
+// AuditPairs checks the contrast of the given pairs in the scheme. Pairs with
+// a color missing from the scheme are skipped; paths that don't name a color
+// field are an error.
+func AuditPairs(s *adapter.AbstractScheme, pairs []Pair) (*Report, error) {
+	report := &Report{Results: []Result{}, Skipped: []Pair{}}
+	for _, pair := range pairs {
+		fg, err := getColor(s, pair.Foreground)
+		if err != nil {
+			return nil, err
+		}
+		bg, err := getColor(s, pair.Background)
+		if err != nil {
+			return nil, err
+		}
+		if fg == nil || bg == nil {
+			report.Skipped = append(report.Skipped, pair)
+			continue
+		}
+		report.Results = append(report.Results, Check(pair, *fg, *bg))
+	}
+	return report, nil
+}
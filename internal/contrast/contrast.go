@@ -0,0 +1,230 @@
+// Package contrast measures how readable colors are against each other, using
+// the WCAG 2.x contrast ratio and the APCA lightness contrast (Lc).
+package contrast
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+// Standard is a contrast standard
+type Standard int
+
+const (
+	WCAG Standard = iota // WCAG 2.x contrast ratio, from 1 to 21
+	APCA                 // APCA lightness contrast, from about -108 to 106
+)
+
+func (s Standard) String() string {
+	switch s {
+	case WCAG:
+		return "wcag"
+	case APCA:
+		return "apca"
+	}
+	return fmt.Sprintf("Standard(%d)", int(s))
+}
+
+// MarshalText encodes the standard by name
+func (s Standard) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a standard encoded by MarshalText
+func (s *Standard) UnmarshalText(text []byte) error {
+	standard, err := ParseStandard(string(text))
+	if err != nil {
+		return err
+	}
+	*s = standard
+	return nil
+}
+
+// ParseStandard returns the standard with the given name, as printed by String
+func ParseStandard(name string) (Standard, error) {
+	switch strings.ToLower(name) {
+	case "wcag":
+		return WCAG, nil
+	case "apca":
+		return APCA, nil
+	}
+	return 0, fmt.Errorf("unknown contrast standard %q, expected wcag or apca", name)
+}
+
+// Level is a conformance level a pair of colors can reach
+type Level int
+
+const (
+	Fail Level = iota
+	AA
+	AAA
+)
+
+func (l Level) String() string {
+	switch l {
+	case Fail:
+		return "fail"
+	case AA:
+		return "AA"
+	case AAA:
+		return "AAA"
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// MarshalText encodes the level by name
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText decodes a level encoded by MarshalText
+func (l *Level) UnmarshalText(text []byte) error {
+	if strings.EqualFold(string(text), Fail.String()) {
+		*l = Fail
+		return nil
+	}
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// ParseLevel returns the level with the given name, AA or AAA
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToUpper(name) {
+	case "AA":
+		return AA, nil
+	case "AAA":
+		return AAA, nil
+	}
+	return 0, fmt.Errorf("unknown contrast level %q, expected AA or AAA", name)
+}
+
+// Threshold returns the minimum contrast body text needs to reach the level.
+// For WCAG this is the ratio from success criteria 1.4.3 and 1.4.6. APCA has
+// no levels of its own, so these are its recommended Lc for body text (75)
+// and for other content text (60), applied to the absolute Lc.
+func Threshold(standard Standard, level Level) float64 {
+	switch {
+	case standard == WCAG && level == AAA:
+		return 7
+	case standard == WCAG && level == AA:
+		return 4.5
+	case standard == APCA && level == AAA:
+		return 75
+	case standard == APCA && level == AA:
+		return 60
+	}
+	return 0
+}
+
+// Measure returns the contrast of the foreground against the background under
+// the standard. APCA results are returned as absolute Lc so that larger is
+// always better.
+func Measure(standard Standard, fg, bg color.Color) float64 {
+	if standard == APCA {
+		return math.Abs(LightnessContrast(fg, bg))
+	}
+	return Ratio(fg, bg)
+}
+
+// LevelOf returns the highest level the contrast reaches under the standard
+func LevelOf(standard Standard, contrast float64) Level {
+	switch {
+	case contrast >= Threshold(standard, AAA):
+		return AAA
+	case contrast >= Threshold(standard, AA):
+		return AA
+	}
+	return Fail
+}
+
+// over composites a translucent foreground onto an opaque background
+func over(fg, bg color.Color) color.Color {
+	a := fg.Alpha
+	return color.Color{
+		Red:   fg.Red*a + bg.Red*(1-a),
+		Green: fg.Green*a + bg.Green*(1-a),
+		Blue:  fg.Blue*a + bg.Blue*(1-a),
+		Alpha: 1,
+	}
+}
+
+// RelativeLuminance returns the WCAG relative luminance of a color, ignoring alpha
+func RelativeLuminance(c color.Color) float64 {
+	return c.Clamped().ToXYZ().Y
+}
+
+// Ratio returns the WCAG 2.x contrast ratio of the foreground against the
+// background, from 1 to 21. A translucent foreground is composited onto the
+// background first; the background is treated as opaque.
+func Ratio(fg, bg color.Color) float64 {
+	l1 := RelativeLuminance(over(fg, bg))
+	l2 := RelativeLuminance(bg)
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// APCA-W3 0.0.98G-4g constants
+const (
+	apcaExponent  = 2.4
+	apcaBlkThrs   = 0.022
+	apcaBlkClmp   = 1.414
+	apcaDeltaYMin = 0.0005
+	apcaNormBG    = 0.56
+	apcaNormTXT   = 0.57
+	apcaRevTXT    = 0.62
+	apcaRevBG     = 0.65
+	apcaScale     = 1.14
+	apcaLoOffset  = 0.027
+	apcaLoClip    = 0.1
+)
+
+// apcaLuminance estimates screen luminance the way APCA does, with a simple
+// exponent and a soft clamp near black
+func apcaLuminance(c color.Color) float64 {
+	c = c.Clamped()
+	y := 0.2126729*math.Pow(c.Red, apcaExponent) +
+		0.7151522*math.Pow(c.Green, apcaExponent) +
+		0.0721750*math.Pow(c.Blue, apcaExponent)
+	if y < apcaBlkThrs {
+		y += math.Pow(apcaBlkThrs-y, apcaBlkClmp)
+	}
+	return y
+}
+
+// LightnessContrast returns the APCA lightness contrast (Lc) of text against
+// a background. It is positive for dark text on a light background and
+// negative for light text on a dark background. A translucent foreground is
+// composited onto the background first.
+func LightnessContrast(text, bg color.Color) float64 {
+	yText := apcaLuminance(over(text, bg))
+	yBg := apcaLuminance(bg)
+
+	if math.Abs(yBg-yText) < apcaDeltaYMin {
+		return 0
+	}
+
+	if yBg > yText {
+		// Dark text on a light background
+		sapc := (math.Pow(yBg, apcaNormBG) - math.Pow(yText, apcaNormTXT)) * apcaScale
+		if sapc < apcaLoClip {
+			return 0
+		}
+		return (sapc - apcaLoOffset) * 100
+	}
+
+	// Light text on a dark background
+	sapc := (math.Pow(yBg, apcaRevBG) - math.Pow(yText, apcaRevTXT)) * apcaScale
+	if sapc > -apcaLoClip {
+		return 0
+	}
+	return (sapc + apcaLoOffset) * 100
+}
@@ -0,0 +1,153 @@
+package contrast_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/contrast"
+)
+
+func hex(t *testing.T, s string) color.Color {
+	t.Helper()
+	c, err := color.FromHex(s)
+	if err != nil {
+		t.Fatalf("invalid test color %q: %v", s, err)
+	}
+	return c
+}
+
+func hexPtr(t *testing.T, s string) *color.Color {
+	c := hex(t, s)
+	return &c
+}
+
+func TestRatio(t *testing.T) {
+	tests := []struct {
+		fg, bg string
+		want   float64
+	}{
+		{"#000000", "#FFFFFF", 21},
+		{"#FFFFFF", "#000000", 21},
+		{"#FFFFFF", "#FFFFFF", 1},
+		{"#777777", "#FFFFFF", 4.48},
+		{"#0000FF", "#FFFFFF", 8.59},
+		{"#FF0000", "#000000", 5.25},
+	}
+
+	for _, tc := range tests {
+		got := contrast.Ratio(hex(t, tc.fg), hex(t, tc.bg))
+		if math.Abs(got-tc.want) > 0.01 {
+			t.Errorf("Ratio(%s, %s) = %.2f, want %.2f", tc.fg, tc.bg, got, tc.want)
+		}
+	}
+}
+
+// Reference values from the APCA-W3 0.0.98G-4g test suite
+func TestLightnessContrast(t *testing.T) {
+	tests := []struct {
+		text, bg string
+		want     float64
+	}{
+		{"#888888", "#FFFFFF", 63.056},
+		{"#FFFFFF", "#888888", -68.541},
+		{"#000000", "#AAAAAA", 58.146},
+		{"#AAAAAA", "#000000", -56.24},
+		{"#112233", "#DDEEFF", 91.66},
+		{"#DDEEFF", "#112233", -93.07},
+		{"#123456", "#123456", 0},
+	}
+
+	for _, tc := range tests {
+		got := contrast.LightnessContrast(hex(t, tc.text), hex(t, tc.bg))
+		if math.Abs(got-tc.want) > 0.05 {
+			t.Errorf("LightnessContrast(%s, %s) = %.3f, want %.3f", tc.text, tc.bg, got, tc.want)
+		}
+	}
+}
+
+func TestRatio_TranslucentForeground(t *testing.T) {
+	fg := hex(t, "#FFFFFF")
+	fg.Alpha = 0
+	if got := contrast.Ratio(fg, hex(t, "#000000")); math.Abs(got-1) > 1e-9 {
+		t.Errorf("expected an invisible foreground to have no contrast, got %.2f", got)
+	}
+}
+
+func TestLevelOf(t *testing.T) {
+	tests := []struct {
+		standard contrast.Standard
+		value    float64
+		want     contrast.Level
+	}{
+		{contrast.WCAG, 3, contrast.Fail},
+		{contrast.WCAG, 4.5, contrast.AA},
+		{contrast.WCAG, 7.1, contrast.AAA},
+		{contrast.APCA, 45, contrast.Fail},
+		{contrast.APCA, 60, contrast.AA},
+		{contrast.APCA, 90, contrast.AAA},
+	}
+	for _, tc := range tests {
+		if got := contrast.LevelOf(tc.standard, tc.value); got != tc.want {
+			t.Errorf("LevelOf(%s, %.1f) = %s, want %s", tc.standard, tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestAudit(t *testing.T) {
+	s := &adapter.AbstractScheme{}
+	s.SpecialColors.Background = hexPtr(t, "#000000")
+	s.SpecialColors.Foreground = hexPtr(t, "#FFFFFF")
+	s.AnsiColors.Black = hexPtr(t, "#111111")
+	s.AnsiColors.Blue = hexPtr(t, "#3465A4")
+	s.SpecialColors.Cursor = hexPtr(t, "#FFFFFF")
+	s.SpecialColors.CursorText = hexPtr(t, "#000000")
+
+	report, err := contrast.Audit(s)
+	if err != nil {
+		t.Fatalf("Audit failed: %v", err)
+	}
+
+	byForeground := make(map[string]contrast.Result)
+	for _, res := range report.Results {
+		byForeground[res.Foreground] = res
+	}
+
+	if len(report.Results) != 4 {
+		t.Errorf("expected 4 checked pairs, got %d", len(report.Results))
+	}
+	if res := byForeground["SpecialColors.Foreground"]; res.WCAG != contrast.AAA || res.APCA != contrast.AAA {
+		t.Errorf("expected white on black to pass AAA, got %+v", res)
+	}
+	if res := byForeground["AnsiColors.Black"]; res.WCAG != contrast.Fail {
+		t.Errorf("expected black on black to fail, got %+v", res)
+	}
+	if res := byForeground["AnsiColors.Blue"]; res.WCAG != contrast.Fail || res.APCA != contrast.Fail {
+		t.Errorf("expected dark blue on black to fail, got %+v", res)
+	}
+	if res, ok := byForeground["SpecialColors.CursorText"]; !ok || res.Background != "SpecialColors.Cursor" {
+		t.Errorf("expected cursor text to be checked against the cursor, got %+v", res)
+	}
+
+	// Everything without both colors is skipped, e.g. the selection
+	wantSkipped := len(contrast.SchemePairs()) - 4
+	if len(report.Skipped) != wantSkipped {
+		t.Errorf("expected %d skipped pairs, got %d", wantSkipped, len(report.Skipped))
+	}
+
+	failing := report.Failing(contrast.WCAG, contrast.AA)
+	if len(failing) != 2 {
+		t.Errorf("expected 2 pairs failing AA, got %+v", failing)
+	}
+}
+
+func TestAuditPairs_InvalidPath(t *testing.T) {
+	s := &adapter.AbstractScheme{}
+	if _, err := contrast.AuditPairs(s, []contrast.Pair{{"AnsiColors.Nope", "SpecialColors.Background"}}); err == nil {
+		t.Errorf("expected an error for an unknown field")
+	}
+	if _, err := contrast.AuditPairs(s, []contrast.Pair{{"Metadata.Name", "SpecialColors.Background"}}); err == nil {
+		t.Errorf("expected an error for a field that is not a color")
+	}
+}
@@ -0,0 +1,150 @@
+package contrast
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+// Adjustment records a foreground color that was moved to reach the target
+type Adjustment struct {
+	Pair
+	From   string  `json:"from"`    // Original color
+	To     string  `json:"to"`      // Adjusted color
+	DeltaL float64 `json:"delta_l"` // Change in OKLCH lightness
+	Before float64 `json:"before"`  // Contrast before the adjustment
+	After  float64 `json:"after"`   // Contrast after the adjustment
+}
+
+// FixReport lists the changes made by Fix
+type FixReport struct {
+	Standard  Standard     `json:"standard"`
+	Target    float64      `json:"target"`
+	Adjusted  []Adjustment `json:"adjusted"`
+	Unfixable []Pair       `json:"unfixable"` // Pairs that can't reach the target by lightness alone
+}
+
+// JSON returns the report as indented JSON
+func (r *FixReport) JSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// String returns the report as human-readable text
+func (r *FixReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Adjusted to reach %s %.4g (%d)\n", r.Standard, r.Target, len(r.Adjusted))
+	for _, a := range r.Adjusted {
+		fmt.Fprintf(&b, "  %s: %s -> %s (L %+.3f, %.2f -> %.2f against %s)\n",
+			a.Foreground, a.From, a.To, a.DeltaL, a.Before, a.After, a.Background)
+	}
+	if len(r.Unfixable) > 0 {
+		fmt.Fprintf(&b, "Could not fix (%d)\n", len(r.Unfixable))
+		for _, p := range r.Unfixable {
+			fmt.Fprintf(&b, "  %s against %s\n", p.Foreground, p.Background)
+		}
+	}
+	return b.String()
+}
+
+// Fix adjusts every foreground from SchemePairs that falls short of the target
+// contrast under the standard. See FixPairs.
+func Fix(s *adapter.AbstractScheme, standard Standard, target float64) (*FixReport, error) {
+	return FixPairs(s, SchemePairs(), standard, target)
+}
+
+// FixPairs moves each foreground that falls short of the target contrast
+// against its background to the nearest OKLCH lightness that reaches it,
+// keeping hue and reducing chroma only as far as needed to stay in sRGB.
+// Adjusted colors replace the field rather than being modified in place, as
+// the same color may be shared between fields. Pairs with a missing color are
+// left alone.
+func FixPairs(s *adapter.AbstractScheme, pairs []Pair, standard Standard, target float64) (*FixReport, error) {
+	report := &FixReport{Standard: standard, Target: target, Adjusted: []Adjustment{}, Unfixable: []Pair{}}
+	for _, pair := range pairs {
+		fgValue, err := lookupColor(s, pair.Foreground)
+		if err != nil {
+			return nil, err
+		}
+		bg, err := getColor(s, pair.Background)
+		if err != nil {
+			return nil, err
+		}
+		fg := fgValue.Interface().(*color.Color)
+		if fg == nil || bg == nil {
+			continue
+		}
+
+		before := Measure(standard, *fg, *bg)
+		if before >= target {
+			continue
+		}
+
+		fixed, ok := nearestPassing(standard, *fg, *bg, target)
+		if !ok {
+			report.Unfixable = append(report.Unfixable, pair)
+			continue
+		}
+
+		fgValue.Set(reflect.ValueOf(&fixed))
+		report.Adjusted = append(report.Adjusted, Adjustment{
+			Pair:   pair,
+			From:   fg.ToHex(true),
+			To:     fixed.ToHex(true),
+			DeltaL: fixed.ToOKLCH().L - fg.ToOKLCH().L,
+			Before: before,
+			After:  Measure(standard, fixed, *bg),
+		})
+	}
+	return report, nil
+}
+
+// nearestPassing searches both lighter and darker for the smallest change in
+// OKLCH lightness that brings fg to the target contrast against bg. Results
+// are rounded to 8 bits, so they still pass once written out as hex.
+func nearestPassing(standard Standard, fg, bg color.Color, target float64) (color.Color, bool) {
+	lch := fg.ToOKLCH()
+	at := func(l float64) color.Color {
+		return color.FromOKLCHInGamut(color.OKLCH{L: l, C: lch.C, H: lch.H}, fg.Alpha).Quantized()
+	}
+	passes := func(l float64) bool {
+		return Measure(standard, at(l), bg) >= target
+	}
+
+	bgL := bg.ToOKLCH().L
+
+	var best color.Color
+	bestDelta := math.Inf(1)
+	for _, end := range []float64{0, 1} {
+		if !passes(end) {
+			continue
+		}
+		// Contrast grows as lightness moves away from the background's, so
+		// the passing side of the interval can be found by bisection. Moving
+		// across the background's lightness only starts to help past it.
+		near, far := lch.L, end
+		if (bgL-near)*(bgL-far) < 0 {
+			near = bgL
+		}
+		for i := 0; i < 32; i++ {
+			mid := (near + far) / 2
+			if passes(mid) {
+				far = mid
+			} else {
+				near = mid
+			}
+		}
+		if delta := math.Abs(far - lch.L); delta < bestDelta {
+			best, bestDelta = at(far), delta
+		}
+	}
+	return best, !math.IsInf(bestDelta, 1)
+}
@@ -0,0 +1,109 @@
+package contrast_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/contrast"
+)
+
+func TestFix(t *testing.T) {
+	s := &adapter.AbstractScheme{}
+	s.SpecialColors.Background = hexPtr(t, "#1E1E2E")
+	s.SpecialColors.Foreground = hexPtr(t, "#CDD6F4")
+	s.AnsiColors.Blue = hexPtr(t, "#2A4A9A")
+	s.AnsiColors.Red = hexPtr(t, "#5A1E2A")
+	// A fallback shares its source's pointer, which must not be changed
+	s.AnsiColors.Black = s.AnsiColors.Red
+
+	for _, standard := range []contrast.Standard{contrast.WCAG, contrast.APCA} {
+		t.Run(standard.String(), func(t *testing.T) {
+			scheme := *s
+			target := contrast.Threshold(standard, contrast.AA)
+			pairs := []contrast.Pair{
+				{"AnsiColors.Blue", "SpecialColors.Background"},
+				{"AnsiColors.Red", "SpecialColors.Background"},
+				{"SpecialColors.Foreground", "SpecialColors.Background"},
+			}
+
+			report, err := contrast.FixPairs(&scheme, pairs, standard, target)
+			if err != nil {
+				t.Fatalf("FixPairs failed: %v", err)
+			}
+			if len(report.Adjusted) != 2 || len(report.Unfixable) != 0 {
+				t.Fatalf("expected blue and red to be adjusted, got %s", report)
+			}
+
+			for _, adj := range report.Adjusted {
+				if adj.After < target {
+					t.Errorf("%s still below target: %.2f", adj.Foreground, adj.After)
+				}
+				if adj.DeltaL <= 0 {
+					t.Errorf("expected %s to get lighter on a dark background, got ΔL %.3f", adj.Foreground, adj.DeltaL)
+				}
+			}
+
+			// The hue is kept
+			before, after := s.AnsiColors.Blue.ToOKLCH(), scheme.AnsiColors.Blue.ToOKLCH()
+			if math.Abs(before.H-after.H) > 2 {
+				t.Errorf("expected blue to keep its hue, got %.1f -> %.1f", before.H, after.H)
+			}
+
+			// Colors that already pass, and colors shared with other fields, stay put
+			if scheme.SpecialColors.Foreground != s.SpecialColors.Foreground {
+				t.Errorf("expected the foreground to be left alone")
+			}
+			if scheme.AnsiColors.Black.ToHex(true) != "#5A1E2A" {
+				t.Errorf("expected black to be left alone, got %s", scheme.AnsiColors.Black.ToHex(true))
+			}
+
+			// The result reaches the target once written out as hex
+			audit, err := contrast.AuditPairs(&scheme, pairs)
+			if err != nil {
+				t.Fatalf("AuditPairs failed: %v", err)
+			}
+			for _, res := range audit.Results {
+				fg, bg := hex(t, res.ForegroundColor), hex(t, res.BackgroundColor)
+				if got := contrast.Measure(standard, fg, bg); got < target {
+					t.Errorf("%s is %s, below target at %.3f", res.Foreground, res.ForegroundColor, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFix_Minimal(t *testing.T) {
+	s := &adapter.AbstractScheme{}
+	s.SpecialColors.Background = hexPtr(t, "#FFFFFF")
+	s.AnsiColors.Yellow = hexPtr(t, "#C0A000")
+
+	report, err := contrast.FixPairs(s, []contrast.Pair{{"AnsiColors.Yellow", "SpecialColors.Background"}}, contrast.WCAG, 4.5)
+	if err != nil {
+		t.Fatalf("FixPairs failed: %v", err)
+	}
+	if len(report.Adjusted) != 1 {
+		t.Fatalf("expected yellow to be adjusted, got %s", report)
+	}
+	// Landing just above the target means no more lightness was taken than needed
+	if after := report.Adjusted[0].After; after > 4.6 {
+		t.Errorf("expected a minimal adjustment, got a ratio of %.2f", after)
+	}
+}
+
+func TestFix_Unfixable(t *testing.T) {
+	s := &adapter.AbstractScheme{}
+	s.SpecialColors.Background = hexPtr(t, "#777777")
+	s.SpecialColors.Foreground = hexPtr(t, "#888888")
+
+	report, err := contrast.FixPairs(s, []contrast.Pair{{"SpecialColors.Foreground", "SpecialColors.Background"}}, contrast.WCAG, 7)
+	if err != nil {
+		t.Fatalf("FixPairs failed: %v", err)
+	}
+	if len(report.Unfixable) != 1 || len(report.Adjusted) != 0 {
+		t.Errorf("expected a mid gray background to be unfixable at 7:1, got %s", report)
+	}
+	if s.SpecialColors.Foreground.ToHex(true) != "#888888" {
+		t.Errorf("expected an unfixable color to be left alone")
+	}
+}
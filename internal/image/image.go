@@ -0,0 +1,269 @@
+// Package image makes a color scheme from an image: it extracts the image's
+// dominant colors, by k-means clustering or median cut in OKLab, and assigns
+// them to the scheme's colors by hue and lightness.
+package image
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+// Method is a way of extracting the dominant colors of an image
+type Method int
+
+const (
+	KMeans    Method = iota // k-means clustering in OKLab
+	MedianCut               // Median cut of the OKLab color space
+)
+
+func (m Method) String() string {
+	switch m {
+	case KMeans:
+		return "kmeans"
+	case MedianCut:
+		return "median-cut"
+	}
+	return fmt.Sprintf("Method(%d)", int(m))
+}
+
+// ParseMethod returns the method with the given name, as printed by String
+func ParseMethod(name string) (Method, error) {
+	switch strings.ToLower(name) {
+	case "kmeans", "k-means":
+		return KMeans, nil
+	case "median-cut", "mediancut":
+		return MedianCut, nil
+	}
+	return 0, fmt.Errorf("unknown method %q, expected kmeans or median-cut", name)
+}
+
+// Swatch is a dominant color of an image
+type Swatch struct {
+	Color  color.Color
+	Weight float64 // Share of the sampled pixels it stands for, from 0 to 1
+}
+
+// Pixels sampled from an image at most, spread evenly over larger images
+const maxSamples = 16384
+
+// Iterations of k-means at most, if the clusters don't settle before
+const maxIterations = 32
+
+// Decode reads a PNG, JPEG or GIF image
+func Decode(r io.Reader) (image.Image, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return img, nil
+}
+
+// Extract returns up to n dominant colors of the image, heaviest first.
+// Mostly transparent pixels are ignored.
+func Extract(img image.Image, method Method, n int) ([]Swatch, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("expected at least one color, got %d", n)
+	}
+	points := samples(img)
+	if len(points) == 0 {
+		return nil, errors.New("image has no opaque pixels")
+	}
+
+	var clusters [][]color.OKLab
+	switch method {
+	case KMeans:
+		clusters = kmeans(points, n)
+	case MedianCut:
+		clusters = medianCut(points, n)
+	default:
+		return nil, fmt.Errorf("unknown method %s", method)
+	}
+
+	swatches := make([]Swatch, 0, len(clusters))
+	for _, cluster := range clusters {
+		swatches = append(swatches, Swatch{
+			Color:  color.FromOKLab(mean(cluster), 1).Clamped().Quantized(),
+			Weight: float64(len(cluster)) / float64(len(points)),
+		})
+	}
+	sort.SliceStable(swatches, func(i, j int) bool {
+		return swatches[i].Weight > swatches[j].Weight
+	})
+	return swatches, nil
+}
+
+// samples returns the OKLab coordinates of the opaque pixels of the image,
+// skipping rows and columns evenly to stay under maxSamples
+func samples(img image.Image) []color.OKLab {
+	b := img.Bounds()
+	step := 1
+	for ((b.Dx()+step-1)/step)*((b.Dy()+step-1)/step) > maxSamples {
+		step++
+	}
+
+	var points []color.OKLab
+	for y := b.Min.Y; y < b.Max.Y; y += step {
+		for x := b.Min.X; x < b.Max.X; x += step {
+			r, g, bl, a := img.At(x, y).RGBA()
+			if a < 0x8000 {
+				continue
+			}
+			// Components are premultiplied by alpha
+			alpha := float64(a)
+			c := color.NewColor(float64(r)/alpha, float64(g)/alpha, float64(bl)/alpha, 1)
+			points = append(points, c.ToOKLab())
+		}
+	}
+	return points
+}
+
+func distanceSq(a, b color.OKLab) float64 {
+	return (a.L-b.L)*(a.L-b.L) + (a.A-b.A)*(a.A-b.A) + (a.B-b.B)*(a.B-b.B)
+}
+
+func mean(points []color.OKLab) color.OKLab {
+	var sum color.OKLab
+	for _, p := range points {
+		sum.L += p.L
+		sum.A += p.A
+		sum.B += p.B
+	}
+	n := float64(len(points))
+	return color.OKLab{L: sum.L / n, A: sum.A / n, B: sum.B / n}
+}
+
+// nearest returns the index of the center closest to p
+func nearest(p color.OKLab, centers []color.OKLab) int {
+	best, bestDist := 0, math.Inf(1)
+	for i, c := range centers {
+		if d := distanceSq(p, c); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// kmeans clusters the points into at most k clusters. Centers are seeded by
+// k-means++ from a fixed seed, so the same image always gives the same
+// colors; images with fewer than k distinct colors give fewer clusters.
+func kmeans(points []color.OKLab, k int) [][]color.OKLab {
+	rng := rand.New(rand.NewSource(1))
+	centers := []color.OKLab{points[rng.Intn(len(points))]}
+	dists := make([]float64, len(points))
+	for len(centers) < k {
+		total := 0.0
+		for i, p := range points {
+			dists[i] = distanceSq(p, centers[nearest(p, centers)])
+			total += dists[i]
+		}
+		if total == 0 {
+			break
+		}
+		target := rng.Float64() * total
+		i := 0
+		for ; i < len(points)-1; i++ {
+			if target -= dists[i]; target <= 0 {
+				break
+			}
+		}
+		centers = append(centers, points[i])
+	}
+
+	assignment := make([]int, len(points))
+	for iter := 0; iter < maxIterations; iter++ {
+		changed := iter == 0
+		for i, p := range points {
+			if c := nearest(p, centers); c != assignment[i] {
+				assignment[i], changed = c, true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		clusters := make([][]color.OKLab, len(centers))
+		for i, p := range points {
+			clusters[assignment[i]] = append(clusters[assignment[i]], p)
+		}
+		for i, cluster := range clusters {
+			if len(cluster) > 0 {
+				centers[i] = mean(cluster)
+			}
+		}
+	}
+
+	clusters := make([][]color.OKLab, len(centers))
+	for i, p := range points {
+		clusters[assignment[i]] = append(clusters[assignment[i]], p)
+	}
+	result := clusters[:0]
+	for _, cluster := range clusters {
+		if len(cluster) > 0 {
+			result = append(result, cluster)
+		}
+	}
+	return result
+}
+
+// medianCut splits the points into at most n boxes, each time cutting the box
+// with the longest side in two at the median along that side
+func medianCut(points []color.OKLab, n int) [][]color.OKLab {
+	axes := []func(color.OKLab) float64{
+		func(p color.OKLab) float64 { return p.L },
+		func(p color.OKLab) float64 { return p.A },
+		func(p color.OKLab) float64 { return p.B },
+	}
+	// longest returns the longest side of the box and its length
+	longest := func(box []color.OKLab) (int, float64) {
+		bestAxis, bestRange := 0, 0.0
+		for axis, value := range axes {
+			lo, hi := math.Inf(1), math.Inf(-1)
+			for _, p := range box {
+				lo, hi = math.Min(lo, value(p)), math.Max(hi, value(p))
+			}
+			if hi-lo > bestRange {
+				bestAxis, bestRange = axis, hi-lo
+			}
+		}
+		return bestAxis, bestRange
+	}
+
+	boxes := [][]color.OKLab{append([]color.OKLab(nil), points...)}
+	for len(boxes) < n {
+		split, splitAxis, splitRange := -1, 0, 0.0
+		for i, box := range boxes {
+			if axis, r := longest(box); r > splitRange {
+				split, splitAxis, splitRange = i, axis, r
+			}
+		}
+		if split < 0 {
+			break // Every box holds a single color
+		}
+
+		box, value := boxes[split], axes[splitAxis]
+		sort.Slice(box, func(i, j int) bool { return value(box[i]) < value(box[j]) })
+		// Cut between distinct values, so neither half is empty
+		mid := len(box) / 2
+		for mid > 0 && value(box[mid-1]) == value(box[mid]) {
+			mid--
+		}
+		if mid == 0 {
+			for mid = len(box) / 2; value(box[mid-1]) == value(box[mid]); mid++ {
+			}
+		}
+		boxes[split] = box[:mid]
+		boxes = append(boxes, box[mid:])
+	}
+	return boxes
+}
@@ -0,0 +1,200 @@
+package image
+
+import (
+	"bytes"
+	"image"
+	stdcolor "image/color"
+	"image/png"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/contrast"
+)
+
+// stripes returns an image of vertical stripes, each as many pixels wide as
+// given
+func stripes(colors []stdcolor.Color, widths []int) image.Image {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, total, 10))
+	x := 0
+	for i, w := range widths {
+		for ; w > 0; w-- {
+			for y := 0; y < 10; y++ {
+				img.Set(x, y, colors[i])
+			}
+			x++
+		}
+	}
+	return img
+}
+
+func TestParseMethod(t *testing.T) {
+	for _, m := range []Method{KMeans, MedianCut} {
+		if parsed, err := ParseMethod(m.String()); err != nil || parsed != m {
+			t.Errorf("ParseMethod(%q) = %v, %v", m.String(), parsed, err)
+		}
+	}
+	if _, err := ParseMethod("octree"); err == nil {
+		t.Errorf("expected an error for an unknown method")
+	}
+}
+
+func TestExtract(t *testing.T) {
+	colors := []stdcolor.Color{
+		stdcolor.NRGBA{0x10, 0x10, 0x20, 0xff},
+		stdcolor.NRGBA{0xe0, 0x30, 0x30, 0xff},
+		stdcolor.NRGBA{0x30, 0xa0, 0x40, 0xff},
+		stdcolor.NRGBA{0x00, 0x00, 0x00, 0x00}, // Transparent, ignored
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, stripes(colors, []int{50, 30, 20, 40})); err != nil {
+		t.Fatal(err)
+	}
+	img, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	want := []struct {
+		hex    string
+		weight float64
+	}{{"#101020", 0.5}, {"#E03030", 0.3}, {"#30A040", 0.2}}
+	for _, method := range []Method{KMeans, MedianCut} {
+		t.Run(method.String(), func(t *testing.T) {
+			swatches, err := Extract(img, method, 8)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if len(swatches) != len(want) {
+				t.Fatalf("expected %d swatches, one per distinct color, got %+v", len(want), swatches)
+			}
+			for i, w := range want {
+				if got := swatches[i].Color.ToHex(true); got != w.hex || math.Abs(swatches[i].Weight-w.weight) > 1e-9 {
+					t.Errorf("swatch %d: expected %s at %.2f, got %s at %.2f", i, w.hex, w.weight, got, swatches[i].Weight)
+				}
+			}
+
+			// Fewer colors than the image has merge the closest ones
+			swatches, err = Extract(img, method, 2)
+			if err != nil || len(swatches) != 2 {
+				t.Errorf("expected 2 swatches, got %+v, %v", swatches, err)
+			}
+		})
+	}
+}
+
+func TestExtract_Errors(t *testing.T) {
+	transparent := stripes([]stdcolor.Color{stdcolor.NRGBA{}}, []int{10})
+	if _, err := Extract(transparent, KMeans, 4); err == nil {
+		t.Errorf("expected an error for an image without opaque pixels")
+	}
+	if _, err := Extract(transparent, KMeans, 0); err == nil {
+		t.Errorf("expected an error for zero colors")
+	}
+	if _, err := Decode(bytes.NewReader([]byte("not an image"))); err == nil {
+		t.Errorf("expected an error decoding garbage")
+	}
+}
+
+func palette(t *testing.T, hexes ...string) []Swatch {
+	t.Helper()
+	var swatches []Swatch
+	for _, hex := range hexes {
+		c, err := color.FromHex(hex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		swatches = append(swatches, Swatch{Color: c, Weight: 1 / float64(len(hexes))})
+	}
+	return swatches
+}
+
+// checkScheme checks the hue slots sit near their canonical hue and every
+// text color reaches the contrast
+func checkScheme(t *testing.T, s *adapter.AbstractScheme, minContrast float64, exempt ...string) {
+	t.Helper()
+	a := s.AnsiColors
+	slots := []*color.Color{a.Red, a.Green, a.Yellow, a.Blue, a.Magenta, a.Cyan}
+	for i, c := range slots {
+		canonical, _ := color.FromHex(hueSlots[i])
+		if d := hueDistance(canonical.ToOKLCH().H, c.ToOKLCH().H); d > maxHueShift+1 {
+			t.Errorf("slot %s: %s is %.0f degrees off its hue", hueSlots[i], c.ToHex(true), d)
+		}
+	}
+
+	report, err := contrast.Audit(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range report.Results {
+		if slices.Contains(exempt, r.Foreground) || r.Background != "SpecialColors.Background" {
+			continue
+		}
+		if r.Ratio < minContrast {
+			t.Errorf("%s: contrast %.2f below %.2f", r.Foreground, r.Ratio, minContrast)
+		}
+	}
+}
+
+func TestScheme(t *testing.T) {
+	// A sunset: dark purple, orange, pink, a muddy green and a pale sky
+	colors := palette(t, "#1b1030", "#f08030", "#e05080", "#607040", "#f0e8d0", "#4060a0")
+
+	s, err := Scheme(colors, Options{Name: "Sunset"})
+	if err != nil {
+		t.Fatalf("Scheme failed: %v", err)
+	}
+	if s.Metadata.Name == nil || *s.Metadata.Name != "Sunset" {
+		t.Errorf("expected the name to be set, got %v", s.Metadata.Name)
+	}
+	if l := s.SpecialColors.Background.ToOKLCH().L; l > 0.25 {
+		t.Errorf("expected a dark background, got %s", s.SpecialColors.Background.ToHex(true))
+	}
+	if l := s.SpecialColors.Foreground.ToOKLCH().L; l < 0.85 {
+		t.Errorf("expected a light foreground, got %s", s.SpecialColors.Foreground.ToHex(true))
+	}
+	// Blue is taken from the image's blue rather than made up
+	if d := color.OKLabDeltaE.Distance(*s.AnsiColors.Blue, colors[5].Color); d > 0.15 {
+		t.Errorf("expected blue near %s, got %s", colors[5].Color.ToHex(true), s.AnsiColors.Blue.ToHex(true))
+	}
+	checkScheme(t, s, defaultMinContrast, "AnsiColors.Black")
+
+	light, err := Scheme(colors, Options{Light: true, MinContrast: 7})
+	if err != nil {
+		t.Fatalf("Scheme failed: %v", err)
+	}
+	if l := light.SpecialColors.Background.ToOKLCH().L; l < 0.9 {
+		t.Errorf("expected a light background, got %s", light.SpecialColors.Background.ToHex(true))
+	}
+	checkScheme(t, light, 7, "AnsiColors.White", "AnsiColors.BrightWhite")
+}
+
+func TestScheme_Grayscale(t *testing.T) {
+	// Without any hue to go on the slots take their canonical hues
+	s, err := Scheme(palette(t, "#202020", "#808080", "#d0d0d0"), Options{})
+	if err != nil {
+		t.Fatalf("Scheme failed: %v", err)
+	}
+	checkScheme(t, s, defaultMinContrast, "AnsiColors.Black")
+}
+
+func TestFromImage(t *testing.T) {
+	img := stripes([]stdcolor.Color{
+		stdcolor.NRGBA{0x0b, 0x1d, 0x2a, 0xff},
+		stdcolor.NRGBA{0x3a, 0x8f, 0xd0, 0xff},
+		stdcolor.NRGBA{0xd0, 0xd8, 0xe0, 0xff},
+	}, []int{70, 20, 10})
+	for _, method := range []Method{KMeans, MedianCut} {
+		s, err := FromImage(img, Options{Method: method})
+		if err != nil {
+			t.Fatalf("FromImage(%s) failed: %v", method, err)
+		}
+		checkScheme(t, s, defaultMinContrast, "AnsiColors.Black")
+	}
+}
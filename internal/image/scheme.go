@@ -0,0 +1,254 @@
+package image
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/contrast"
+)
+
+// Options adjust how a scheme is made from an image
+type Options struct {
+	Method      Method
+	Colors      int     // Dominant colors extracted, 16 if zero
+	Light       bool    // Make a light scheme rather than a dark one
+	MinContrast float64 // WCAG contrast ratio of text against the background, 4.5 if zero
+	Name        string
+}
+
+const (
+	defaultColors      = 16
+	defaultMinContrast = 4.5
+
+	// Swatches with less OKLCH chroma than this are grays and not used for
+	// the hue slots
+	minChroma = 0.04
+	// Swatches further than this many degrees from the canonical hue of a
+	// slot aren't matched to it
+	maxHueDistance = 60
+	// Hue slots keep the hue of their swatch within this many degrees of
+	// their canonical hue, so red never turns orange enough to read as yellow
+	maxHueShift = 30
+)
+
+// The colors the canonical hues of the chromatic ANSI colors are taken from:
+// red, green, yellow, blue, magenta and cyan
+var hueSlots = []string{"#ff0000", "#00ff00", "#ffff00", "#0000ff", "#ff00ff", "#00ffff"}
+
+// FromImage makes a scheme from the dominant colors of the image. See Scheme.
+func FromImage(img image.Image, opts Options) (*adapter.AbstractScheme, error) {
+	n := opts.Colors
+	if n == 0 {
+		n = defaultColors
+	}
+	palette, err := Extract(img, opts.Method, n)
+	if err != nil {
+		return nil, err
+	}
+	return Scheme(palette, opts)
+}
+
+// Scheme makes a scheme from a palette of dominant colors. The darkest and
+// lightest swatches become the background and foreground, the other way
+// around for a light scheme, and the six chromatic ANSI colors take the
+// swatches closest in hue to red, green, yellow, blue, magenta and cyan.
+// Lightness and chroma are then evened out across the slots and every text
+// color is brought to the minimum contrast against the background, so any
+// image gives a usable scheme. Colors left unset are filled by fallbacks when
+// the scheme is written.
+func Scheme(palette []Swatch, opts Options) (*adapter.AbstractScheme, error) {
+	if len(palette) == 0 {
+		return nil, fmt.Errorf("expected at least one color")
+	}
+	minContrast := opts.MinContrast
+	if minContrast == 0 {
+		minContrast = defaultMinContrast
+	}
+
+	byLightness := make([]color.OKLCH, len(palette))
+	for i, swatch := range palette {
+		byLightness[i] = swatch.Color.ToOKLCH()
+	}
+	sort.SliceStable(byLightness, func(i, j int) bool { return byLightness[i].L < byLightness[j].L })
+	darkest, lightest := byLightness[0], byLightness[len(byLightness)-1]
+
+	// bg and fg are the neutrals of the scheme, the hue of their swatch kept
+	// as a tint
+	var bg, fg color.OKLCH
+	if opts.Light {
+		bg = color.OKLCH{L: math.Max(lightest.L, 0.96), C: math.Min(lightest.C, 0.02), H: lightest.H}
+		fg = color.OKLCH{L: math.Min(darkest.L, 0.3), C: math.Min(darkest.C, 0.03), H: darkest.H}
+	} else {
+		bg = color.OKLCH{L: math.Min(darkest.L, 0.22), C: math.Min(darkest.C, 0.04), H: darkest.H}
+		fg = color.OKLCH{L: math.Max(lightest.L, 0.88), C: math.Min(lightest.C, 0.03), H: lightest.H}
+	}
+	// shade returns the neutral with its lightness moved by delta
+	shade := func(neutral color.OKLCH, delta float64) *color.Color {
+		neutral.L += delta
+		return makeColor(neutral)
+	}
+
+	s := &adapter.AbstractScheme{}
+	if opts.Name != "" {
+		name := opts.Name
+		s.Metadata.Name = &name
+	}
+	s.SpecialColors.Background = makeColor(bg)
+	s.SpecialColors.Foreground = makeColor(fg)
+	s.SpecialColors.Cursor = makeColor(fg)
+	s.SpecialColors.CursorText = makeColor(bg)
+	s.SpecialColors.SelectedText = makeColor(fg)
+
+	a := &s.AnsiColors
+	// Bright colors stand out further from the background
+	brighter := 0.08
+	var exempt []string // ANSI colors that are close to the background on purpose
+	if opts.Light {
+		brighter = -0.08
+		s.SpecialColors.Selection = shade(bg, -0.12)
+		a.Black, a.BrightBlack = shade(fg, 0), makeColor(color.OKLCH{L: 0.55, C: fg.C, H: fg.H})
+		a.White, a.BrightWhite = shade(bg, -0.1), shade(bg, 0)
+		exempt = []string{"White", "BrightWhite"}
+	} else {
+		s.SpecialColors.Selection = shade(bg, 0.15)
+		a.Black, a.BrightBlack = shade(bg, 0.1), makeColor(color.OKLCH{L: 0.55, C: bg.C, H: bg.H})
+		a.White, a.BrightWhite = shade(fg, -0.08), shade(fg, 0.08)
+		exempt = []string{"Black"}
+	}
+
+	targets := [][2]**color.Color{
+		{&a.Red, &a.BrightRed}, {&a.Green, &a.BrightGreen}, {&a.Yellow, &a.BrightYellow},
+		{&a.Blue, &a.BrightBlue}, {&a.Magenta, &a.BrightMagenta}, {&a.Cyan, &a.BrightCyan},
+	}
+	for i, slot := range assignHues(palette) {
+		if opts.Light {
+			slot.L = clamp(slot.L, 0.45, 0.6)
+		} else {
+			slot.L = clamp(slot.L, 0.6, 0.8)
+		}
+		slot.C = math.Max(slot.C, 0.08)
+
+		bright := slot
+		bright.L += brighter
+		*targets[i][0], *targets[i][1] = makeColor(slot), makeColor(bright)
+	}
+
+	pairs := []contrast.Pair{
+		{Foreground: "SpecialColors.Foreground", Background: "SpecialColors.Background"},
+		{Foreground: "SpecialColors.SelectedText", Background: "SpecialColors.Selection"},
+	}
+	for _, pair := range contrast.SchemePairs() {
+		name, ok := strings.CutPrefix(pair.Foreground, "AnsiColors.")
+		if ok && !slices.Contains(exempt, name) {
+			pairs = append(pairs, pair)
+		}
+	}
+	report, err := contrast.FixPairs(s, pairs, contrast.WCAG, minContrast)
+	if err != nil {
+		return nil, err
+	}
+	if len(report.Unfixable) > 0 {
+		p := report.Unfixable[0]
+		return nil, fmt.Errorf("cannot reach contrast %.4g for %s against %s", minContrast, p.Foreground, p.Background)
+	}
+	return s, nil
+}
+
+// assignHues returns the color of each hue slot, in order. Slots take the
+// chromatic swatches closest to their canonical hue, each swatch going to one
+// slot, and their hue is kept within maxHueShift of the canonical one. Slots
+// left without a swatch within maxHueDistance take their canonical hue at the
+// median lightness and chroma of the image's colors.
+func assignHues(palette []Swatch) []color.OKLCH {
+	var chromatic []color.OKLCH
+	for _, swatch := range palette {
+		if lch := swatch.Color.ToOKLCH(); lch.C >= minChroma {
+			chromatic = append(chromatic, lch)
+		}
+	}
+
+	canonical := make([]float64, len(hueSlots))
+	for i, hex := range hueSlots {
+		c, _ := color.FromHex(hex)
+		canonical[i] = c.ToOKLCH().H
+	}
+
+	// Match the closest slot and swatch first, then the next closest
+	type match struct {
+		slot, swatch int
+		distance     float64
+	}
+	var matches []match
+	for i := range hueSlots {
+		for j, lch := range chromatic {
+			if d := hueDistance(canonical[i], lch.H); d <= maxHueDistance {
+				matches = append(matches, match{i, j, d})
+			}
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].distance < matches[j].distance })
+
+	assigned := make([]int, len(hueSlots))
+	for i := range assigned {
+		assigned[i] = -1
+	}
+	used := make([]bool, len(chromatic))
+	for _, m := range matches {
+		if assigned[m.slot] < 0 && !used[m.swatch] {
+			assigned[m.slot], used[m.swatch] = m.swatch, true
+		}
+	}
+	missing := color.OKLCH{L: 0.7, C: 0.12}
+	if len(chromatic) > 0 {
+		missing.L = median(chromatic, func(lch color.OKLCH) float64 { return lch.L })
+		missing.C = median(chromatic, func(lch color.OKLCH) float64 { return lch.C })
+	}
+
+	slots := make([]color.OKLCH, len(hueSlots))
+	for i := range hueSlots {
+		if assigned[i] < 0 {
+			slots[i] = color.OKLCH{L: missing.L, C: missing.C, H: canonical[i]}
+			continue
+		}
+		lch := chromatic[assigned[i]]
+		if d := signedHueDistance(canonical[i], lch.H); math.Abs(d) > maxHueShift {
+			lch.H = canonical[i] + math.Copysign(maxHueShift, d)
+		}
+		slots[i] = lch
+	}
+	return slots
+}
+
+// makeColor returns the OKLCH color in sRGB, rounded to 8 bits
+func makeColor(lch color.OKLCH) *color.Color {
+	c := color.FromOKLCHInGamut(lch, 1).Quantized()
+	return &c
+}
+
+// signedHueDistance returns the shortest turn in degrees from hue a to hue b
+func signedHueDistance(a, b float64) float64 {
+	return math.Mod(b-a+540, 360) - 180
+}
+
+func hueDistance(a, b float64) float64 {
+	return math.Abs(signedHueDistance(a, b))
+}
+
+func median(colors []color.OKLCH, value func(color.OKLCH) float64) float64 {
+	values := make([]float64, len(colors))
+	for i, c := range colors {
+		values[i] = value(c)
+	}
+	sort.Float64s(values)
+	return values[len(values)/2]
+}
+
+func clamp(f, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, f))
+}
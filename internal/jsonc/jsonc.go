@@ -0,0 +1,73 @@
+// Package jsonc reads JSON with comments
+package jsonc
+
+import "strings"
+
+// Strip removes comments and trailing commas from JSON with comments, as
+// written by editors and terminals for their settings, leaving strings
+// untouched
+func Strip(input string) string {
+	var out strings.Builder
+	inString := false
+	for i := 0; i < len(input); i++ {
+		ch := input[i]
+		switch {
+		case inString:
+			out.WriteByte(ch)
+			if ch == '\\' && i+1 < len(input) {
+				i++
+				out.WriteByte(input[i])
+			} else if ch == '"' {
+				inString = false
+			}
+		case ch == '"':
+			inString = true
+			out.WriteByte(ch)
+		case strings.HasPrefix(input[i:], "//"):
+			for i < len(input) && input[i] != '\n' {
+				i++
+			}
+			if i < len(input) {
+				out.WriteByte('\n')
+			}
+		case strings.HasPrefix(input[i:], "/*"):
+			end := strings.Index(input[i+2:], "*/")
+			if end < 0 {
+				i = len(input)
+			} else {
+				i += end + 3
+			}
+		case ch == ',':
+			// Drop the comma if only whitespace and comments precede the closing bracket
+			if next := nextSignificant(input[i+1:]); next != '}' && next != ']' {
+				out.WriteByte(ch)
+			}
+		default:
+			out.WriteByte(ch)
+		}
+	}
+	return out.String()
+}
+
+// nextSignificant returns the first byte of input that isn't whitespace or
+// part of a comment, or 0 if there is none
+func nextSignificant(input string) byte {
+	for i := 0; i < len(input); i++ {
+		switch {
+		case strings.ContainsRune(" \t\r\n", rune(input[i])):
+		case strings.HasPrefix(input[i:], "//"):
+			for i < len(input) && input[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(input[i:], "/*"):
+			end := strings.Index(input[i+2:], "*/")
+			if end < 0 {
+				return 0
+			}
+			i += end + 3
+		default:
+			return input[i]
+		}
+	}
+	return 0
+}
@@ -0,0 +1,32 @@
+package jsonc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/jsonc"
+)
+
+func TestStrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"line comment", "{\"a\": 1 // one\n}", "{\"a\": 1 \n}"},
+		{"block comment", `{/* a */"a": 1}`, `{"a": 1}`},
+		{"trailing commas", `{"a": [1, 2,], "b": 3, /* c */ }`, `{"a": [1, 2], "b": 3  }`},
+		{"strings untouched", `{"a": "// not, a /* comment */", "b": "\"//\","}`, `{"a": "// not, a /* comment */", "b": "\"//\","}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := jsonc.Strip(tc.input)
+			if got != tc.want {
+				t.Errorf("Strip(%q) = %q, want %q", tc.input, got, tc.want)
+			}
+			if !json.Valid([]byte(got)) {
+				t.Errorf("expected valid JSON, got %q", got)
+			}
+		})
+	}
+}
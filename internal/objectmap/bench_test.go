@@ -0,0 +1,122 @@
+package objectmap
+
+import (
+	"reflect"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// Benchmarks, on types shaped like a scheme and a format adapter
+// -----------------------------------------------------------------------------
+
+type benchGroup struct {
+	A, B, C, D, E, F, G, H *float64
+}
+
+type benchScheme struct {
+	Name    *string
+	Normal  benchGroup
+	Bright  benchGroup
+	Special benchGroup
+	Scope   struct {
+		Basic, Advanced, Markup benchGroup
+	}
+}
+
+type benchFormat struct {
+	Name   *string `map:"Name"`
+	Colors struct {
+		Primary struct {
+			Background *float64 `map:"Special.A"`
+			Foreground *float64 `map:"Special.B"`
+			Bold       *float64 `map:"Special.C,Special.B"`
+		}
+		Normal benchGroup `map:"Normal"`
+		Bright struct {
+			A *float64 `map:"Bright.A"`
+			B *float64 `map:"Bright.B"`
+			C *float64 `map:"Bright.C"`
+			D *float64 `map:"Bright.D"`
+			E *float64 `map:"Bright.E"`
+			F *float64 `map:"Bright.F"`
+			G *float64 `map:"Bright.G"`
+			H *float64 `map:"Bright.H"`
+		}
+	}
+	Extra *float64
+}
+
+func newBenchScheme() *benchScheme {
+	name := "Bench"
+	s := &benchScheme{Name: &name}
+	for _, g := range []*benchGroup{&s.Normal, &s.Bright, &s.Special} {
+		for _, f := range []**float64{&g.A, &g.B, &g.C, &g.D, &g.E, &g.F, &g.G, &g.H} {
+			v := 0.5
+			*f = &v
+		}
+	}
+	return s
+}
+
+func BenchmarkMapFrom(b *testing.B) {
+	src := newBenchScheme()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var dst benchFormat
+		if err := MapFrom(src, &dst, nil, nil, "map"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMapInto(b *testing.B) {
+	var format benchFormat
+	if err := MapFrom(newBenchScheme(), &format, nil, nil, "map"); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var dst benchScheme
+		if err := MapInto(&format, &dst, nil, nil, "map"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// The baseline of BenchmarkMapFrom, compiling the plan on every run: the types
+// are walked and the tags parsed by reflection, as before plans were cached
+func BenchmarkMapFrom_Unplanned(b *testing.B) {
+	src := newBenchScheme()
+	key := planKey{reflect.TypeOf(benchScheme{}), reflect.TypeOf(benchFormat{}), "map", false}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var dst benchFormat
+		plan, err := compilePlan(key)
+		if err == nil {
+			err = plan.Map(src, &dst, nil, nil, nil)
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// The baseline of BenchmarkMapInto, compiling the plan on every run
+func BenchmarkMapInto_Unplanned(b *testing.B) {
+	var format benchFormat
+	if err := MapFrom(newBenchScheme(), &format, nil, nil, "map"); err != nil {
+		b.Fatal(err)
+	}
+	key := planKey{reflect.TypeOf(benchFormat{}), reflect.TypeOf(benchScheme{}), "map", true}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var dst benchScheme
+		plan, err := compilePlan(key)
+		if err == nil {
+			err = plan.Map(&format, &dst, nil, nil, nil)
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
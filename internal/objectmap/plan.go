@@ -0,0 +1,474 @@
+package objectmap
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// Plan is a mapping between two struct types compiled ahead of time: the
+// fields of both sides, the paths named by tags resolved to field indices,
+// and the transforms of their pipelines. Running a plan walks the values
+// without looking up fields by name, parsing tags or building paths, which is
+// what MapInto and MapFrom spend their time on otherwise. Plans are cached per
+// pair of types and tag, and safe for concurrent use.
+type Plan struct {
+	src, dst reflect.Type
+	into     bool    // Tags are on the source, as for MapInto, rather than the destination
+	walked   []*node // Fields of the tagged side, mapped in order
+	other    []*node // Fields of the other side
+	size     int     // Number of nodes on both sides
+}
+
+// node is a field of one side of a plan
+type node struct {
+	id       int
+	index    int // Of the field in its struct
+	path     []string
+	pathStr  string
+	field    reflect.StructField
+	parent   *node
+	canSet   bool    // The field and all of its parents are exported
+	children []*node // Fields of a struct or pointer to struct field
+
+	// Paths on the other side the field maps to or from, on the tagged side
+	links []*link
+}
+
+// link is a path of a tag resolved on the other side of a plan
+type link struct {
+	path       []string
+	valid      bool                  // The path names a field of the other side
+	fields     []reflect.StructField // Along the path
+	leaf       reflect.StructField
+	marks      []int // Ids of the nodes along the path, -1 past where the path leaves them
+	transforms *pipeline
+	canSet     bool // Every field along the path is exported
+	settable   bool // No parent along the path is a pointer to a pointer
+}
+
+type planKey struct {
+	src, dst reflect.Type
+	tag      string
+	into     bool
+}
+
+var (
+	plansMu sync.RWMutex
+	plans   = map[planKey]*Plan{}
+)
+
+// PlanInto returns the plan MapInto runs to map src onto dst, both struct
+// types, by the tags of src
+func PlanInto(src, dst reflect.Type, maptag string) (*Plan, error) {
+	return cachedPlan(planKey{src, dst, maptag, true})
+}
+
+// PlanFrom returns the plan MapFrom runs to fill dst from src, both struct
+// types, by the tags of dst
+func PlanFrom(src, dst reflect.Type, maptag string) (*Plan, error) {
+	return cachedPlan(planKey{src, dst, maptag, false})
+}
+
+func cachedPlan(key planKey) (*Plan, error) {
+	plansMu.RLock()
+	p, ok := plans[key]
+	plansMu.RUnlock()
+	if ok {
+		return p, nil
+	}
+
+	p, err := compilePlan(key)
+	if err != nil {
+		return nil, err
+	}
+	plansMu.Lock()
+	defer plansMu.Unlock()
+	plans[key] = p
+	return p, nil
+}
+
+func compilePlan(key planKey) (*Plan, error) {
+	if key.src.Kind() != reflect.Struct || key.dst.Kind() != reflect.Struct {
+		return nil, errors.New("both src and dst must point to structs")
+	}
+	p := &Plan{src: key.src, dst: key.dst, into: key.into}
+	walkedType, otherType := key.dst, key.src
+	if key.into {
+		walkedType, otherType = key.src, key.dst
+	}
+
+	var err error
+	if p.walked, err = buildNodes(walkedType, nil, []reflect.Type{walkedType}, &p.size); err != nil {
+		return nil, err
+	}
+	if p.other, err = buildNodes(otherType, nil, []reflect.Type{otherType}, &p.size); err != nil {
+		return nil, err
+	}
+
+	var linkErr error
+	eachNode(p.walked, func(n *node) {
+		if linkErr != nil {
+			return
+		}
+		sources := []source{{path: n.path}} // default: same name
+		if tagVal, ok := n.field.Tag.Lookup(key.tag); ok && tagVal != "" {
+			if sources, err = parseTag(tagVal); err != nil {
+				linkErr = fmt.Errorf("%s: %w", n.pathStr, err)
+				return
+			}
+		}
+		for _, s := range sources {
+			n.links = append(n.links, resolveLink(otherType, p.other, s))
+		}
+	})
+	if linkErr != nil {
+		return nil, linkErr
+	}
+	return p, nil
+}
+
+// buildNodes returns the fields of the struct type in the order
+// structutil.TraverseStructDFS visits them, numbering them from next
+func buildNodes(t reflect.Type, parent *node, ancestors []reflect.Type, next *int) ([]*node, error) {
+	nodes := make([]*node, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		n := &node{id: *next, index: i, field: f, parent: parent, canSet: f.IsExported()}
+		*next++
+		if parent != nil {
+			n.path = append(slices.Clip(parent.path), f.Name)
+			n.canSet = n.canSet && parent.canSet
+		} else {
+			n.path = []string{f.Name}
+		}
+		n.pathStr = joinPath(n.path)
+
+		// Traversal descends into structs and pointers to structs
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct {
+			if slices.Contains(ancestors, ft) {
+				return nil, fmt.Errorf("%s: recursive struct types are not supported", n.pathStr)
+			}
+			var err error
+			if n.children, err = buildNodes(ft, n, append(slices.Clip(ancestors), ft), next); err != nil {
+				return nil, err
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+func eachNode(nodes []*node, fn func(*node)) {
+	for _, n := range nodes {
+		fn(n)
+		eachNode(n.children, fn)
+	}
+}
+
+// resolveLink resolves the path of a tag on the struct type, the way
+// structutil.HasNestedFieldSlice looks it up
+func resolveLink(t reflect.Type, nodes []*node, s source) *link {
+	l := &link{path: s.path, transforms: s.transforms, canSet: true, settable: true}
+	if len(s.path) == 0 {
+		return l
+	}
+	curr := t
+	for i, part := range s.path {
+		f, ok := curr.FieldByName(part)
+		if !ok {
+			return l
+		}
+		l.fields = append(l.fields, f)
+		l.canSet = l.canSet && f.IsExported()
+
+		var id int
+		id, nodes = findNode(nodes, part)
+		l.marks = append(l.marks, id)
+
+		ft := f.Type
+		if i < len(s.path)-1 {
+			derefs := 0
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+				derefs++
+			}
+			if ft.Kind() != reflect.Struct {
+				return l
+			}
+			// structutil.SetNestedField only allocates a single pointer
+			l.settable = l.settable && derefs <= 1
+		}
+		curr = ft
+	}
+	l.leaf = l.fields[len(l.fields)-1]
+	l.valid = true
+	return l
+}
+
+// findNode returns the id and children of the node with the given name, or -1
+// and no children if there is none
+func findNode(nodes []*node, name string) (int, []*node) {
+	for _, n := range nodes {
+		if n.field.Name == name {
+			return n.id, n.children
+		}
+	}
+	return -1, nil
+}
+
+// lookup returns the value at the link's path, or false if a pointer along
+// the way is nil
+func (l *link) lookup(root reflect.Value) (reflect.Value, bool) {
+	v := root
+	last := len(l.fields) - 1
+	for i, f := range l.fields {
+		v = v.FieldByIndex(f.Index)
+		if i < last {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return reflect.Value{}, false
+				}
+				v = v.Elem()
+			}
+		}
+	}
+	return v, true
+}
+
+// Mapping states of the nodes during a run
+const (
+	unmapped  uint8 = iota
+	partial         // A child of the field was mapped
+	mappedAll       // The field itself was mapped
+)
+
+// run is a single mapping through a plan
+type run struct {
+	plan             *Plan
+	srcElem, dstElem reflect.Value
+	state            []uint8
+	err              error
+
+	onUnusedSrc func(fieldPath []string, srcVal reflect.Value)
+	onUnusedDst func(fieldPath []string, dstVal reflect.Value)
+	onMapped    func(dstPath []string, srcPath []string)
+}
+
+// Map runs the plan on src and dst, pointers to its source and destination
+// types, like MapInto or MapFrom would. The paths passed to the callbacks are
+// shared between runs and must not be modified.
+func (p *Plan) Map(
+	src any,
+	dst any,
+	onUnusedSrc func(fieldPath []string, srcVal reflect.Value),
+	onUnusedDst func(fieldPath []string, dstVal reflect.Value),
+	onMapped func(dstPath []string, srcPath []string),
+) error {
+	srcVal := reflect.ValueOf(src)
+	dstVal := reflect.ValueOf(dst)
+	if srcVal.Kind() != reflect.Ptr || dstVal.Kind() != reflect.Ptr {
+		return errors.New("both src and dst must be pointers")
+	}
+	if srcVal.Elem().Type() != p.src || dstVal.Elem().Type() != p.dst {
+		return fmt.Errorf("plan maps %s to %s, got %s and %s", p.src, p.dst, srcVal.Elem().Type(), dstVal.Elem().Type())
+	}
+
+	r := &run{
+		plan:        p,
+		srcElem:     srcVal.Elem(),
+		dstElem:     dstVal.Elem(),
+		state:       make([]uint8, p.size),
+		onUnusedSrc: onUnusedSrc,
+		onUnusedDst: onUnusedDst,
+		onMapped:    onMapped,
+	}
+	if r.onUnusedSrc == nil {
+		r.onUnusedSrc = func(_ []string, _ reflect.Value) {}
+	}
+	if r.onUnusedDst == nil {
+		r.onUnusedDst = func(_ []string, _ reflect.Value) {}
+	}
+	if r.onMapped == nil {
+		r.onMapped = func(_ []string, _ []string) {}
+	}
+
+	if p.into {
+		r.into(p.walked, r.srcElem)
+		if r.err != nil {
+			return r.err
+		}
+		r.report(p.other, r.dstElem, r.onUnusedDst)
+		return nil
+	}
+
+	r.from(p.walked, r.dstElem)
+	if r.err != nil {
+		return r.err
+	}
+	r.report(p.walked, r.dstElem, r.onUnusedDst)
+	r.report(p.other, r.srcElem, r.onUnusedSrc)
+	return nil
+}
+
+// into maps the source fields onto the destination paths their tags name
+func (r *run) into(nodes []*node, v reflect.Value) {
+	for _, n := range nodes {
+		if r.err != nil {
+			return
+		}
+		srcValue := v.Field(n.index)
+
+		mapped, recurse := false, false
+		for _, l := range n.links {
+			if !l.valid {
+				recurse = true
+				continue
+			}
+			dstFieldVal, ok := l.lookup(r.dstElem)
+			if !ok {
+				recurse = true
+				continue
+			}
+			// The tag says how the source was derived from the target, so
+			// undo it
+			value, err := l.transforms.apply(srcValue, true)
+			if err != nil {
+				r.err = fmt.Errorf("%s: %w", n.pathStr, err)
+				return
+			}
+			// Check type compatibility: must be exactly same type or assignable
+			if l.leaf.Type != value.Type() && !value.Type().AssignableTo(l.leaf.Type) {
+				recurse = true
+				continue
+			}
+			if !l.canSet {
+				recurse = true
+				continue
+			}
+			if !l.settable {
+				continue
+			}
+			dstFieldVal.Set(value)
+			r.mark(l.marks)
+			mapped = true
+		}
+
+		if !mapped {
+			r.onUnusedSrc(n.path, srcValue)
+			if recurse {
+				descend(n, srcValue, r.into)
+			}
+		}
+	}
+}
+
+// from fills the destination fields from the first source path their tags
+// name that has a value
+func (r *run) from(nodes []*node, v reflect.Value) {
+	for _, n := range nodes {
+		if r.err != nil {
+			return
+		}
+		dstValue := v.Field(n.index)
+
+		// Take the first source with a value, or failing that the first
+		// that exists at all
+		var winner *link
+		var winnerVal reflect.Value
+		for _, l := range n.links {
+			if !l.valid {
+				continue
+			}
+			srcFieldVal, ok := l.lookup(r.srcElem)
+			if !ok {
+				continue
+			}
+			value, err := l.transforms.apply(srcFieldVal, false)
+			if err != nil {
+				r.err = fmt.Errorf("%s: %w", n.pathStr, err)
+				return
+			}
+
+			// Normalize for assignment (handle *T → T, T → *T)
+			normalizedVal, ok := normalizeForAssignment(value, n.field.Type)
+			if !ok {
+				continue
+			}
+			if winner == nil || isNil(winnerVal) && !isNil(normalizedVal) {
+				winner, winnerVal = l, normalizedVal
+			}
+			if !isNil(winnerVal) {
+				break
+			}
+		}
+		if winner == nil || !n.canSet {
+			descend(n, dstValue, r.from)
+			continue
+		}
+
+		dstValue.Set(winnerVal)
+		for p := n; p != nil; p = p.parent {
+			r.setState(p.id, p == n)
+		}
+		r.mark(winner.marks)
+		r.onMapped(n.path, winner.path)
+	}
+}
+
+// mark records the nodes along a path as mapped: the last fully, the others
+// partially
+func (r *run) mark(ids []int) {
+	for i, id := range ids {
+		if id >= 0 {
+			r.setState(id, i == len(ids)-1)
+		}
+	}
+}
+
+func (r *run) setState(id int, full bool) {
+	if full {
+		r.state[id] = mappedAll
+	} else if r.state[id] == unmapped {
+		r.state[id] = partial
+	}
+}
+
+// report calls onUnused for every field that took no part in the mapping.
+// Parents of mapped fields are not reported themselves, but their unmapped
+// children are. Children of fully mapped fields are skipped.
+func (r *run) report(nodes []*node, v reflect.Value, onUnused func([]string, reflect.Value)) {
+	for _, n := range nodes {
+		value := v.Field(n.index)
+		switch r.state[n.id] {
+		case unmapped:
+			onUnused(n.path, value)
+			fallthrough
+		case partial:
+			descend(n, value, func(children []*node, v reflect.Value) {
+				r.report(children, v, onUnused)
+			})
+		}
+	}
+}
+
+// descend calls fn with the children of the node and the struct holding
+// them, unless it is behind a nil pointer
+func descend(n *node, value reflect.Value, fn func([]*node, reflect.Value)) {
+	if len(n.children) == 0 {
+		return
+	}
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	fn(n.children, value)
+}
@@ -0,0 +1,85 @@
+package objectmap_test
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/objectmap"
+)
+
+// -----------------------------------------------------------------------------
+// Plans
+// -----------------------------------------------------------------------------
+
+func TestPlan_Cached(t *testing.T) {
+	srcType, dstType := reflect.TypeOf(Src{}), reflect.TypeOf(Dst{})
+	first, err := objectmap.PlanFrom(srcType, dstType, "mapfrom")
+	if err != nil {
+		t.Fatalf("PlanFrom returned error: %v", err)
+	}
+	if again, _ := objectmap.PlanFrom(srcType, dstType, "mapfrom"); again != first {
+		t.Errorf("expected the plan to be cached")
+	}
+	if other, _ := objectmap.PlanFrom(srcType, dstType, "map"); other == first {
+		t.Errorf("expected a plan per tag")
+	}
+	if into, _ := objectmap.PlanInto(srcType, dstType, "mapfrom"); into == first {
+		t.Errorf("expected a plan per direction")
+	}
+}
+
+func TestPlan_Map(t *testing.T) {
+	plan, err := objectmap.PlanFrom(reflect.TypeOf(Src{}), reflect.TypeOf(Dst{}), "mapfrom")
+	if err != nil {
+		t.Fatalf("PlanFrom returned error: %v", err)
+	}
+
+	// Runs share the plan but nothing else
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			src := &Src{A: strings.Repeat("a", i), N: SrcNested{Y: "nested"}}
+			var dst Dst
+			var unused [][]string
+			err := plan.Map(src, &dst, nil, func(path []string, _ reflect.Value) {
+				unused = append(unused, path)
+			}, nil)
+			if err != nil {
+				t.Errorf("Map returned error: %v", err)
+				return
+			}
+			if dst.A != src.A || dst.Ren != src.A || dst.Nest.Y != "nested" {
+				t.Errorf("unexpected destination values: %+v", dst)
+			}
+			if !reflect.DeepEqual(unused, [][]string{{"Extra"}}) {
+				t.Errorf("expected only Extra unused, got %v", unused)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if err := plan.Map(&Dst{}, &Src{}, nil, nil, nil); err == nil {
+		t.Errorf("expected an error for values of other types")
+	}
+	if err := plan.Map(Src{}, &Dst{}, nil, nil, nil); err == nil {
+		t.Errorf("expected an error for values that aren't pointers")
+	}
+}
+
+type Recursive struct {
+	Name string
+	Next *Recursive
+}
+
+func TestPlan_Errors(t *testing.T) {
+	if _, err := objectmap.PlanInto(reflect.TypeOf(Recursive{}), reflect.TypeOf(Recursive{}), "map"); err == nil {
+		t.Errorf("expected an error for a recursive type")
+	}
+	if _, err := objectmap.PlanInto(reflect.TypeOf(""), reflect.TypeOf(Recursive{}), "map"); err == nil {
+		t.Errorf("expected an error for a type that isn't a struct")
+	}
+}
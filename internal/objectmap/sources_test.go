@@ -0,0 +1,111 @@
+package objectmap_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/objectmap"
+)
+
+// -----------------------------------------------------------------------------
+// Several paths in a tag
+// -----------------------------------------------------------------------------
+
+type Palette struct {
+	Caret      *string
+	Foreground *string
+	Accent     *string
+}
+
+type Editor struct {
+	Cursor *string `map:"Caret,Foreground,Accent"`
+	Text   *string `map:"Foreground,Caret"`
+	Link   *string `map:"Missing,Accent"`
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestMapFrom_FirstNonNil(t *testing.T) {
+	src := Palette{Foreground: strPtr("fg"), Accent: strPtr("accent")}
+	var dst Editor
+	won := make(map[string]string)
+	var unusedSrc [][]string
+	err := objectmap.MapFromWithSources(&src, &dst,
+		func(path []string, _ reflect.Value) {
+			unusedSrc = append(unusedSrc, path)
+		},
+		nil,
+		func(dstPath, srcPath []string) {
+			won[dstPath[0]] = srcPath[0]
+		},
+		"map",
+	)
+	if err != nil {
+		t.Fatalf("MapFromWithSources returned error: %v", err)
+	}
+
+	if dst.Cursor == nil || *dst.Cursor != "fg" {
+		t.Errorf("expected the cursor from the first set candidate, got %v", dst.Cursor)
+	}
+	if dst.Text == nil || *dst.Text != "fg" {
+		t.Errorf("expected the text from the first candidate, got %v", dst.Text)
+	}
+	if dst.Link == nil || *dst.Link != "accent" {
+		t.Errorf("expected candidates that don't exist to be skipped, got %v", dst.Link)
+	}
+	want := map[string]string{"Cursor": "Foreground", "Text": "Foreground", "Link": "Accent"}
+	if !reflect.DeepEqual(won, want) {
+		t.Errorf("expected winners %v, got %v", want, won)
+	}
+	// Caret lost to the others, being nil
+	if !reflect.DeepEqual(unusedSrc, [][]string{{"Caret"}}) {
+		t.Errorf("expected only Caret unused, got %v", unusedSrc)
+	}
+}
+
+func TestMapFrom_AllNil(t *testing.T) {
+	var src Palette
+	dst := Editor{Cursor: strPtr("old")}
+	won := make(map[string]string)
+	err := objectmap.MapFromWithSources(&src, &dst, nil, nil, func(dstPath, srcPath []string) {
+		won[dstPath[0]] = srcPath[0]
+	}, "map")
+	if err != nil {
+		t.Fatalf("MapFromWithSources returned error: %v", err)
+	}
+	if dst.Cursor != nil || won["Cursor"] != "Caret" {
+		t.Errorf("expected the first candidate mapped when none is set, got %v from %q", dst.Cursor, won["Cursor"])
+	}
+}
+
+type Theme struct {
+	Foreground *string `map:"Foreground,Caret"`
+	Accent     *string `map:"Missing,Accent"`
+}
+
+func TestMapInto_SeveralDestinations(t *testing.T) {
+	src := Theme{Foreground: strPtr("fg"), Accent: strPtr("accent")}
+	var dst Palette
+	var unusedSrc, unusedDst [][]string
+	err := objectmap.MapInto(&src, &dst,
+		func(path []string, _ reflect.Value) {
+			unusedSrc = append(unusedSrc, path)
+		},
+		func(path []string, _ reflect.Value) {
+			unusedDst = append(unusedDst, path)
+		},
+		"map",
+	)
+	if err != nil {
+		t.Fatalf("MapInto returned error: %v", err)
+	}
+	if dst.Foreground == nil || dst.Caret == nil || *dst.Foreground != "fg" || *dst.Caret != "fg" {
+		t.Errorf("expected both destinations filled, got %v and %v", dst.Foreground, dst.Caret)
+	}
+	if dst.Accent == nil || *dst.Accent != "accent" {
+		t.Errorf("expected destinations that don't exist to be skipped, got %v", dst.Accent)
+	}
+	if len(unusedSrc) != 0 || len(unusedDst) != 0 {
+		t.Errorf("expected nothing unused, got %v and %v", unusedSrc, unusedDst)
+	}
+}
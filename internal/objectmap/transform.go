@@ -0,0 +1,142 @@
+package objectmap
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+// TagPaths returns the paths a tag names, in order and without their
+// pipelines
+func TagPaths(tag string) []string {
+	var paths []string
+	for _, candidate := range strings.Split(tag, ",") {
+		path, _, _ := strings.Cut(candidate, "|")
+		paths = append(paths, strings.TrimSpace(path))
+	}
+	return paths
+}
+
+// TagSource is a path named in a tag along with its pipeline as written, e.g.
+// "lighten(0.1)|alpha(0.3)", which is empty if there is none
+type TagSource struct {
+	Path     []string
+	Pipeline string
+}
+
+// ParseTag returns the paths a tag names along with their pipelines, failing
+// on malformed pipelines and unknown transforms
+func ParseTag(tag string) ([]TagSource, error) {
+	if _, err := parseTag(tag); err != nil {
+		return nil, err
+	}
+	var sources []TagSource
+	for _, candidate := range strings.Split(tag, ",") {
+		path, pipeline, _ := strings.Cut(candidate, "|")
+		sources = append(sources, TagSource{splitPath(strings.TrimSpace(path)), strings.TrimSpace(pipeline)})
+	}
+	return sources, nil
+}
+
+// ApplyPipeline runs a pipeline as written in a tag on the color, forwards as
+// when the tagged field is filled, or undone. Nil is passed through as is.
+func ApplyPipeline(expr string, c *color.Color, inverse bool) (*color.Color, error) {
+	p, err := parsePipeline(expr)
+	if err != nil {
+		return nil, err
+	}
+	v, err := p.apply(reflect.ValueOf(c), inverse)
+	if err != nil {
+		return nil, err
+	}
+	return v.Interface().(*color.Color), nil
+}
+
+// pipeline is the transform following the path in a tag. A tag such as
+// `map:"Selection|alpha(0.3)"` says the tagged field holds Selection with
+// alpha(0.3) applied, in the syntax of color.ParseTransform: the transform is
+// applied when the tagged field is filled from the field the tag names, and
+// undone when that field is filled from the tagged one, so the same tag reads
+// and writes.
+type pipeline struct {
+	expr    string
+	forward color.Transform
+	inverse color.Transform
+}
+
+// source is a path named in a tag along with its pipeline, nil if it has none
+type source struct {
+	path       []string
+	transforms *pipeline
+}
+
+// parseTag splits a tag like "A.B|lighten(0.1),C" into the paths it names,
+// each with its pipeline
+func parseTag(tag string) ([]source, error) {
+	var sources []source
+	for _, candidate := range strings.Split(tag, ",") {
+		s, err := parseSource(candidate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag %q: %w", tag, err)
+		}
+		sources = append(sources, s)
+	}
+	return sources, nil
+}
+
+// parseSource parses a single path of a tag, like "A.B|lighten(0.1)|alpha(0.5)"
+func parseSource(candidate string) (source, error) {
+	path, expr, piped := strings.Cut(candidate, "|")
+	s := source{path: splitPath(strings.TrimSpace(path))}
+	if piped {
+		p, err := parsePipeline(expr)
+		if err != nil {
+			return source{}, err
+		}
+		s.transforms = p
+	}
+	return s, nil
+}
+
+func parsePipeline(expr string) (*pipeline, error) {
+	expr = strings.TrimSpace(expr)
+	forward, err := color.ParseTransform(expr)
+	if err != nil {
+		return nil, err
+	}
+	inverse, err := color.ParseInverseTransform(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &pipeline{expr, forward, inverse}, nil
+}
+
+// apply runs the pipeline on a color or color pointer, forwards or undone.
+// Nil values are passed through as is.
+func (p *pipeline) apply(v reflect.Value, inverse bool) (reflect.Value, error) {
+	if p == nil || isNil(v) {
+		return v, nil
+	}
+	fn := p.forward
+	if inverse {
+		fn = p.inverse
+	}
+	switch c := v.Interface().(type) {
+	case *color.Color:
+		result := fn(*c)
+		return reflect.ValueOf(&result), nil
+	case color.Color:
+		return reflect.ValueOf(fn(c)), nil
+	}
+	return reflect.Value{}, fmt.Errorf("pipeline %q on a %s rather than a color", p.expr, v.Type())
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	}
+	return !v.IsValid()
+}
@@ -0,0 +1,157 @@
+package objectmap_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/objectmap"
+)
+
+// -----------------------------------------------------------------------------
+// Tag pipelines
+// -----------------------------------------------------------------------------
+
+type Opaque struct {
+	Selection *color.Color
+	Other     *color.Color
+}
+
+type Translucent struct {
+	Selection *color.Color `map:"Selection|alpha(0.3)"`
+	Lighter   *color.Color `map:"Selection | lighten(0.1) | alpha(0.5)"`
+	Other     *color.Color `map:"Other"`
+}
+
+func colorPtr(c color.Color) *color.Color { return &c }
+
+var teal = color.NewColor(0.2, 0.4, 0.6, 1)
+
+func TestMapFrom_Pipeline(t *testing.T) {
+	src := Opaque{Selection: colorPtr(teal)}
+	var dst Translucent
+	if err := objectmap.MapFrom(&src, &dst, nil, nil, "map"); err != nil {
+		t.Fatalf("MapFrom returned error: %v", err)
+	}
+	if dst.Selection == nil || *dst.Selection != teal.WithAlpha(0.3) {
+		t.Errorf("expected the transform applied, got %v", dst.Selection)
+	}
+	if dst.Lighter == nil || *dst.Lighter != teal.Lighten(0.1).WithAlpha(0.5) {
+		t.Errorf("expected the steps applied in turn, got %v", dst.Lighter)
+	}
+	if dst.Other != nil {
+		t.Errorf("expected a nil source to stay nil, got %v", *dst.Other)
+	}
+	if *src.Selection != teal {
+		t.Errorf("expected the source left alone, got %v", *src.Selection)
+	}
+}
+
+type Lightened struct {
+	Selection *color.Color `map:"Selection|lighten(0.1)|alpha(0.3)"`
+}
+
+func TestMapInto_Pipeline(t *testing.T) {
+	src := Lightened{Selection: colorPtr(teal.Lighten(0.1).WithAlpha(0.3))}
+	var dst Opaque
+	if err := objectmap.MapInto(&src, &dst, nil, nil, "map"); err != nil {
+		t.Fatalf("MapInto returned error: %v", err)
+	}
+	if dst.Selection == nil || dst.Selection.HexAlpha() != teal.HexAlpha() {
+		t.Errorf("expected the transform undone, got %v", dst.Selection)
+	}
+}
+
+func TestPipeline_Errors(t *testing.T) {
+	src := Opaque{Selection: colorPtr(teal)}
+	tests := []struct {
+		dst  any
+		want string
+	}{
+		{&struct {
+			Selection *color.Color `map:"Selection|missing(1)"`
+		}{}, `unknown function "missing"`},
+		{&struct {
+			Selection *color.Color `map:"Selection|lighten"`
+		}{}, "expected name(argument)"},
+		{&struct {
+			Selection *color.Color `map:"Selection|lighten(one)"`
+		}{}, "invalid argument to lighten"},
+		{&struct {
+			Selection *string `map:"Selection|alpha(1)"`
+		}{}, ""},
+	}
+	for _, tc := range tests {
+		err := objectmap.MapFrom(&src, tc.dst, nil, nil, "map")
+		if tc.want == "" {
+			// The transformed value doesn't fit the field, which is left unmapped
+			if err != nil || !reflect.ValueOf(tc.dst).Elem().Field(0).IsNil() {
+				t.Errorf("expected the field left unmapped, got %v", err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("expected an error containing %q, got %v", tc.want, err)
+		}
+	}
+
+	// Only colors can be transformed
+	name := "Night"
+	reader := struct {
+		Selection *string `map:"Selection|alpha(0.5)"`
+	}{&name}
+	if err := objectmap.MapInto(&reader, &Opaque{}, nil, nil, "map"); err == nil || !strings.Contains(err.Error(), "rather than a color") {
+		t.Errorf("expected an error for a pipeline on a string, got %v", err)
+	}
+}
+
+func TestTagPaths(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want []string
+	}{
+		{"A.B", []string{"A.B"}},
+		{"A.B|alpha(0.3)", []string{"A.B"}},
+		{"A.B | lighten(0.1)|x(), C", []string{"A.B", "C"}},
+	}
+	for _, tc := range tests {
+		if got := objectmap.TagPaths(tc.tag); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("TagPaths(%q) = %q, want %q", tc.tag, got, tc.want)
+		}
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	got, err := objectmap.ParseTag("A.B | lighten(0.1)|alpha(0.5), C")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []objectmap.TagSource{
+		{Path: []string{"A", "B"}, Pipeline: "lighten(0.1)|alpha(0.5)"},
+		{Path: []string{"C"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseTag = %+v, want %+v", got, want)
+	}
+	if _, err := objectmap.ParseTag("A|missing(1)"); err == nil {
+		t.Error("expected an error for an unknown transform")
+	}
+}
+
+func TestApplyPipeline(t *testing.T) {
+	c, err := objectmap.ApplyPipeline("lighten(0.1)|alpha(0.5)", colorPtr(teal), false)
+	if err != nil || *c != teal.Lighten(0.1).WithAlpha(0.5) {
+		t.Errorf("expected the steps applied, got %v (%v)", c, err)
+	}
+	c, err = objectmap.ApplyPipeline("rotate(30)", colorPtr(teal.RotateHue(30)), true)
+	if err != nil || c.Hex() != teal.Hex() {
+		t.Errorf("expected the rotation undone, got %v (%v)", c, err)
+	}
+	if c, err = objectmap.ApplyPipeline("lighten(0.1)", nil, false); err != nil || c != nil {
+		t.Errorf("expected nil passed through, got %v (%v)", c, err)
+	}
+	if _, err := objectmap.ApplyPipeline("fade(0.1)", colorPtr(teal), false); err == nil {
+		t.Error("expected an error for an unknown transform")
+	}
+}
@@ -0,0 +1,247 @@
+// Package preview renders a color scheme to the terminal with ANSI escapes:
+// a swatch grid of its 16 ANSI colors and samples of the text it colors, each
+// on the scheme's own background.
+package preview
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/contrast"
+	"github.com/da-luce/paletteport/internal/quantize"
+)
+
+// Mode is the color support of the terminal rendered to
+type Mode int
+
+const (
+	TrueColor Mode = iota // 24-bit colors
+	Color256              // Nearest colors of the xterm 256-color palette
+)
+
+func (m Mode) String() string {
+	switch m {
+	case TrueColor:
+		return "truecolor"
+	case Color256:
+		return "256"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// ParseMode returns the mode with the given name, as printed by String
+func ParseMode(name string) (Mode, error) {
+	switch strings.ToLower(name) {
+	case "truecolor", "24bit":
+		return TrueColor, nil
+	case "256":
+		return Color256, nil
+	}
+	return 0, fmt.Errorf("unknown color mode %q, expected truecolor or 256", name)
+}
+
+// DetectMode guesses the mode of the terminal from its environment, read with
+// getenv. Terminals supporting 24-bit colors advertise it in COLORTERM.
+func DetectMode(getenv func(string) string) Mode {
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+	return Color256
+}
+
+// Width of the preview in columns
+const width = 64
+
+// Metric colors are matched to the 256-color palette with
+const metric = color.CIEDE2000
+
+// span is a run of text in a single color. A nil color is the scheme's
+// foreground.
+type span struct {
+	text string
+	fg   *color.Color
+	bold bool
+}
+
+// painter writes lines of spans as escape sequences
+type painter struct {
+	mode Mode
+	fg   *color.Color // Default text color
+	b    strings.Builder
+}
+
+func (p *painter) color(c *color.Color, layer int) string {
+	if c == nil {
+		return ""
+	}
+	if p.mode == Color256 {
+		return fmt.Sprintf("\x1b[%d;5;%dm", layer, c.NearestXterm256(metric))
+	}
+	r, g, b := c.ToRGB()
+	return fmt.Sprintf("\x1b[%d;2;%d;%d;%dm", layer, r, g, b)
+}
+
+// line writes the spans on the background, padded to the width of the preview
+func (p *painter) line(bg *color.Color, spans ...span) {
+	p.b.WriteString(p.color(bg, 48))
+	n := 0
+	for _, s := range spans {
+		fg := s.fg
+		if fg == nil {
+			fg = p.fg
+		}
+		p.b.WriteString(p.color(fg, 38))
+		if s.bold {
+			p.b.WriteString("\x1b[1m")
+		}
+		p.b.WriteString(s.text)
+		if s.bold {
+			p.b.WriteString("\x1b[22m")
+		}
+		n += utf8.RuneCountInString(s.text)
+	}
+	if n < width {
+		p.b.WriteString(strings.Repeat(" ", width-n))
+	}
+	p.b.WriteString("\x1b[0m\n")
+}
+
+// Render writes the preview of the scheme. Colors the scheme leaves unset are
+// left to the terminal, so fill in fallbacks first for a complete preview. In
+// 256-color mode the scheme is quantized to the palette first.
+func Render(w io.Writer, s *adapter.AbstractScheme, mode Mode) error {
+	if mode == Color256 {
+		s, _ = quantize.Scheme(s, quantize.Xterm256, metric)
+	}
+	bg := s.SpecialColors.Background
+	p := &painter{mode: mode, fg: s.SpecialColors.Foreground}
+	ansi := ansiColors(s)
+
+	name := "Untitled"
+	if s.Metadata.Name != nil {
+		name = *s.Metadata.Name
+	}
+	p.line(bg)
+	p.line(bg, span{text: "  " + name, bold: true})
+	p.line(bg)
+
+	// Swatches, labelled in whichever of the foreground and background reads best
+	for row := 0; row < 2; row++ {
+		p.b.WriteString(p.color(bg, 48) + "  ")
+		for i := row * 8; i < row*8+8; i++ {
+			p.b.WriteString(p.color(ansi[i], 48))
+			p.b.WriteString(p.color(label(ansi[i], s.SpecialColors.Foreground, bg), 38))
+			p.b.WriteString(fmt.Sprintf("  %2d   ", i))
+		}
+		p.b.WriteString(p.color(bg, 48) + strings.Repeat(" ", width-2-8*7) + "\x1b[0m\n")
+	}
+	p.line(bg)
+
+	// The colors as text
+	for row := 0; row < 2; row++ {
+		spans := []span{{text: "  "}}
+		for i := row * 8; i < row*8+8; i++ {
+			spans = append(spans, span{text: fmt.Sprintf("%-7s", ansiNames[i%8]), fg: ansi[i], bold: row == 1})
+		}
+		p.line(bg, spans...)
+	}
+	p.line(bg)
+
+	// Shell
+	green, blue, magenta, cyan, red := ansi[2], ansi[4], ansi[5], ansi[6], ansi[1]
+	p.line(bg,
+		span{text: "  "},
+		span{text: "user@host", fg: green, bold: true},
+		span{text: " "},
+		span{text: "~/src/paletteport", fg: blue},
+		span{text: " (main)", fg: magenta},
+		span{text: " $ ls -l"},
+	)
+	p.line(bg, span{text: "  drwxr-xr-x  "}, span{text: "docs/", fg: blue, bold: true})
+	p.line(bg, span{text: "  -rwxr-xr-x  "}, span{text: "build.sh", fg: green, bold: true})
+	p.line(bg, span{text: "  lrwxrwxrwx  "}, span{text: "latest", fg: cyan, bold: true}, span{text: " -> v1.2"})
+	p.line(bg, span{text: "  -rw-r--r--  "}, span{text: "themes.tar.gz", fg: red, bold: true})
+	p.line(bg, span{text: "  -rw-r--r--  README.md"})
+	p.line(bg)
+
+	// Diff
+	p.line(bg, span{text: "  diff --git a/main.go b/main.go", bold: true})
+	p.line(bg, span{text: "  @@ -1,4 +1,4 @@", fg: cyan})
+	p.line(bg, span{text: "   package main"})
+	p.line(bg, span{text: "  -import \"fmt\"", fg: red})
+	p.line(bg, span{text: "  +import \"log\"", fg: green})
+	p.line(bg)
+
+	writeCode(p, s)
+	p.line(bg)
+
+	_, err := io.WriteString(w, p.b.String())
+	return err
+}
+
+var ansiNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// ansiColors returns the 16 ANSI colors of the scheme in order
+func ansiColors(s *adapter.AbstractScheme) []*color.Color {
+	a := s.AnsiColors
+	return []*color.Color{
+		a.Black, a.Red, a.Green, a.Yellow, a.Blue, a.Magenta, a.Cyan, a.White,
+		a.BrightBlack, a.BrightRed, a.BrightGreen, a.BrightYellow, a.BrightBlue, a.BrightMagenta, a.BrightCyan, a.BrightWhite,
+	}
+}
+
+// label returns whichever of the candidates contrasts most with the swatch
+func label(swatch *color.Color, candidates ...*color.Color) *color.Color {
+	if swatch == nil {
+		return nil
+	}
+	var best *color.Color
+	bestRatio := 0.0
+	for _, c := range candidates {
+		if c == nil {
+			continue
+		}
+		if ratio := contrast.Ratio(*c, *swatch); ratio > bestRatio {
+			best, bestRatio = c, ratio
+		}
+	}
+	return best
+}
+
+// writeCode writes a syntax highlighted code block in the scheme's scope
+// colors, with line numbers and the cursor line highlighted
+func writeCode(p *painter, s *adapter.AbstractScheme) {
+	basic, advanced, editor := s.ScopeColors.Basic, s.ScopeColors.Advanced, s.ScopeColors.Editor
+	bg := s.ScopeColors.Miscellaneous.Background
+	if bg == nil {
+		bg = s.SpecialColors.Background
+	}
+	kw, fn, str, num := basic.Keyword, basic.Function, basic.String, basic.Number
+	v, op, typ, param := basic.Variable, basic.Operator, advanced.Type, advanced.Parameter
+
+	lines := [][]span{
+		{{text: "// Greet says hello to everyone", fg: basic.Comment}},
+		{{text: "func", fg: kw}, {text: " "}, {text: "Greet", fg: fn}, {text: "("}, {text: "names", fg: param}, {text: " "}, {text: "[]string", fg: typ}, {text: ") "}, {text: "int", fg: typ}, {text: " {"}},
+		{{text: "    "}, {text: "count", fg: v}, {text: " "}, {text: ":=", fg: op}, {text: " "}, {text: "0", fg: num}},
+		{{text: "    "}, {text: "for", fg: kw}, {text: " _, "}, {text: "name", fg: v}, {text: " "}, {text: ":=", fg: op}, {text: " "}, {text: "range", fg: kw}, {text: " "}, {text: "names", fg: v}, {text: " {"}},
+		{{text: "        "}, {text: "fmt", fg: advanced.Namespace}, {text: "."}, {text: "Printf", fg: fn}, {text: "("}, {text: `"Hello, %s!\n"`, fg: str}, {text: ", "}, {text: "name", fg: v}, {text: ")"}},
+		{{text: "        "}, {text: "count", fg: v}, {text: " "}, {text: "+=", fg: op}, {text: " "}, {text: "1", fg: num}},
+		{{text: "    }"}},
+		{{text: "    "}, {text: "return", fg: kw}, {text: " "}, {text: "count", fg: v}},
+		{{text: "}"}},
+	}
+	const cursorLine = 5
+	for i, spans := range lines {
+		lineBg := bg
+		if i == cursorLine && editor.CursorLine != nil {
+			lineBg = editor.CursorLine
+		}
+		numbered := append([]span{{text: fmt.Sprintf("  %2d  ", i+1), fg: editor.LineNumbers}}, spans...)
+		p.line(lineBg, numbered...)
+	}
+}
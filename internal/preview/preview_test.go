@@ -0,0 +1,105 @@
+package preview
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+)
+
+func testScheme(t *testing.T) *adapter.AbstractScheme {
+	t.Helper()
+	input := `{"name": "Campbell", "background": "#0C0C0C", "foreground": "#CCCCCC",
+		"black": "#0C0C0C", "red": "#C50F1F", "green": "#13A10E", "yellow": "#C19C00",
+		"blue": "#0037DA", "purple": "#881798", "cyan": "#3A96DD", "white": "#CCCCCC",
+		"brightBlack": "#767676", "brightRed": "#E74856", "brightGreen": "#16C60C", "brightYellow": "#F9F1A5",
+		"brightBlue": "#3B78FF", "brightPurple": "#B4009E", "brightCyan": "#61D6D6", "brightWhite": "#F2F2F2"}`
+	reader, err := adapter.GetAdapter("wt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheme, _, err := adapter.ParseAbstract(input, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adapter.DefaultFallbackRules().Apply(scheme)
+	return scheme
+}
+
+var escape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestRender_TrueColor(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, testScheme(t), TrueColor); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"Campbell",
+		"\x1b[48;2;12;12;12m",                  // Background
+		"\x1b[48;2;197;15;31m",                 // Red swatch
+		"\x1b[38;2;22;198;12m",                 // Bright green text
+		"\x1b[38;2;19;161;14m\x1b[1muser@host", // Prompt
+		"-import \"fmt\"",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected the preview to contain %q", want)
+		}
+	}
+
+	// Every line is padded to the same width, so the background is a block
+	for i, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if n := utf8.RuneCountInString(escape.ReplaceAllString(line, "")); n != width {
+			t.Errorf("line %d is %d columns wide, expected %d: %q", i, n, width, line)
+		}
+		if !strings.HasSuffix(line, "\x1b[0m") {
+			t.Errorf("line %d does not reset its colors", i)
+		}
+	}
+}
+
+func TestRender_256(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, testScheme(t), Color256); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if strings.Contains(buf.String(), ";2;") {
+		t.Errorf("expected no 24-bit escapes in 256-color mode")
+	}
+	if !strings.Contains(buf.String(), "\x1b[48;5;232m") {
+		t.Errorf("expected the background as the darkest gray of the palette")
+	}
+}
+
+func TestDetectMode(t *testing.T) {
+	tests := map[string]Mode{"truecolor": TrueColor, "24bit": TrueColor, "": Color256, "yes": Color256}
+	for value, want := range tests {
+		getenv := func(key string) string {
+			if key == "COLORTERM" {
+				return value
+			}
+			return ""
+		}
+		if got := DetectMode(getenv); got != want {
+			t.Errorf("COLORTERM=%q: expected %s, got %s", value, want, got)
+		}
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, mode := range []Mode{TrueColor, Color256} {
+		if got, err := ParseMode(mode.String()); err != nil || got != mode {
+			t.Errorf("ParseMode(%q) = %v, %v", mode, got, err)
+		}
+	}
+	if _, err := ParseMode("16"); err == nil {
+		t.Errorf("expected an error for an unknown mode")
+	}
+	if got := Mode(7).String(); got != "Mode(7)" {
+		t.Errorf("unexpected name for an unknown mode: %s", got)
+	}
+}
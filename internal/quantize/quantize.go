@@ -0,0 +1,133 @@
+// Package quantize maps the colors of a scheme onto the xterm 256-color or
+// 16-color palette, for terminals without truecolor and for formats that take
+// palette indices rather than colors, like vim's ctermfg.
+package quantize
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/structutil"
+)
+
+// Palette is a palette of indexed colors
+type Palette int
+
+const (
+	Xterm256 Palette = iota // The color cube and grays of the 256-color palette, 16 to 255
+	Xterm16                 // The 16 system colors, at xterm's defaults
+)
+
+func (p Palette) String() string {
+	switch p {
+	case Xterm256:
+		return "256"
+	case Xterm16:
+		return "16"
+	}
+	return fmt.Sprintf("Palette(%d)", int(p))
+}
+
+// MarshalText encodes the palette by name
+func (p Palette) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// ParsePalette returns the palette with the given name, as printed by String
+func ParsePalette(name string) (Palette, error) {
+	switch name {
+	case "256":
+		return Xterm256, nil
+	case "16":
+		return Xterm16, nil
+	}
+	return 0, fmt.Errorf("unknown palette %q, expected 256 or 16", name)
+}
+
+// Nearest returns the index of the palette color closest to c under the metric
+func (p Palette) Nearest(c color.Color, metric color.DistanceMetric) int {
+	if p == Xterm16 {
+		return c.NearestXterm16(metric)
+	}
+	return c.NearestXterm256(metric)
+}
+
+// Mapping records the palette color a field of the scheme was mapped to
+type Mapping struct {
+	Field    string  `json:"field"`
+	From     string  `json:"from"`     // Original color
+	Index    int     `json:"index"`    // Index of the palette color
+	To       string  `json:"to"`       // Palette color
+	Distance float64 `json:"distance"` // Distance between the two under the metric
+}
+
+// Table lists the mapping of every set color of a scheme, in field order
+type Table struct {
+	Palette  Palette   `json:"palette"`
+	Metric   string    `json:"metric"`
+	Mappings []Mapping `json:"mappings"`
+}
+
+// Index returns the palette index the field was mapped to
+func (t *Table) Index(field string) (int, bool) {
+	for _, m := range t.Mappings {
+		if m.Field == field {
+			return m.Index, true
+		}
+	}
+	return 0, false
+}
+
+// JSON returns the table as indented JSON
+func (t *Table) JSON() (string, error) {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// String returns the table as a human-readable table
+func (t *Table) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-34s %-9s %5s %-7s %8s\n", "Field", "Color", "Index", "Palette", "Distance")
+	for _, m := range t.Mappings {
+		fmt.Fprintf(&b, "%-34s %-9s %5d %-7s %8.2f\n", m.Field, m.From, m.Index, m.To, m.Distance)
+	}
+	return b.String()
+}
+
+// Scheme returns a copy of the scheme with every color replaced by the
+// nearest color of the palette under the metric, keeping its alpha, along
+// with the table of what each color was mapped to. The scheme itself is left
+// alone.
+func Scheme(s *adapter.AbstractScheme, palette Palette, metric color.DistanceMetric) (*adapter.AbstractScheme, *Table) {
+	quantized := *s
+	table := &Table{Palette: palette, Metric: metric.String(), Mappings: []Mapping{}}
+	structutil.TraverseStructDFS(&quantized, func(path []string, _ reflect.StructField, value reflect.Value) bool {
+		c, ok := value.Interface().(*color.Color)
+		if !ok {
+			return true
+		}
+		if c == nil {
+			return false
+		}
+
+		index := palette.Nearest(*c, metric)
+		to := color.Xterm256(index).WithAlpha(c.Alpha)
+		value.Set(reflect.ValueOf(&to))
+		table.Mappings = append(table.Mappings, Mapping{
+			Field:    strings.Join(path, "."),
+			From:     c.HexAlpha(),
+			Index:    index,
+			To:       to.HexAlpha(),
+			Distance: metric.Distance(*c, to),
+		})
+		return false
+	})
+	return &quantized, table
+}
@@ -0,0 +1,92 @@
+package quantize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+func mustHex(t *testing.T, hex string) *color.Color {
+	t.Helper()
+	c, err := color.FromHex(hex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &c
+}
+
+func TestScheme(t *testing.T) {
+	name := "Test"
+	s := &adapter.AbstractScheme{}
+	s.Metadata.Name = &name
+	s.SpecialColors.Background = mustHex(t, "#0c0c0c")
+	s.SpecialColors.Selection = mustHex(t, "#5f87af80")
+	s.AnsiColors.Red = mustHex(t, "#ff0505")
+	s.ScopeColors.Basic.Comment = mustHex(t, "#808080")
+
+	quantized, table := Scheme(s, Xterm256, color.CIEDE2000)
+
+	if s.AnsiColors.Red.HexAlpha() != "#ff0505" {
+		t.Errorf("expected the original scheme to be left alone")
+	}
+	if *quantized.Metadata.Name != name || quantized.AnsiColors.Blue != nil {
+		t.Errorf("expected fields other than set colors to be copied as is")
+	}
+	if quantized.AnsiColors.Red.HexAlpha() != "#ff0000" {
+		t.Errorf("expected red to become the palette red, got %s", quantized.AnsiColors.Red.HexAlpha())
+	}
+	if quantized.SpecialColors.Selection.HexAlpha() != "#5f87af80" {
+		t.Errorf("expected alpha to be kept, got %s", quantized.SpecialColors.Selection.HexAlpha())
+	}
+
+	if len(table.Mappings) != 4 {
+		t.Fatalf("expected a mapping per set color, got %v", table.Mappings)
+	}
+	want := map[string]int{
+		"AnsiColors.Red":            196,
+		"SpecialColors.Background":  232,
+		"SpecialColors.Selection":   67,
+		"ScopeColors.Basic.Comment": 244,
+	}
+	for field, index := range want {
+		if got, ok := table.Index(field); !ok || got != index {
+			t.Errorf("%s: expected index %d, got %d", field, index, got)
+		}
+	}
+	if _, ok := table.Index("AnsiColors.Blue"); ok {
+		t.Errorf("expected no mapping for an unset color")
+	}
+	if !strings.Contains(table.String(), "AnsiColors.Red") {
+		t.Errorf("expected the table to list the fields:\n%s", table)
+	}
+}
+
+func TestScheme_16(t *testing.T) {
+	s := &adapter.AbstractScheme{}
+	s.AnsiColors.Green = mustHex(t, "#13a10e")
+	s.AnsiColors.BrightBlue = mustHex(t, "#3b78ff")
+
+	quantized, table := Scheme(s, Xterm16, color.CIEDE2000)
+	if i, _ := table.Index("AnsiColors.Green"); i != 2 {
+		t.Errorf("expected green to map to 2, got %d", i)
+	}
+	if i, _ := table.Index("AnsiColors.BrightBlue"); i != 12 {
+		t.Errorf("expected bright blue to map to 12, got %d", i)
+	}
+	if quantized.AnsiColors.Green.Hex() != "#00cd00" {
+		t.Errorf("expected xterm's default green, got %s", quantized.AnsiColors.Green.Hex())
+	}
+}
+
+func TestParsePalette(t *testing.T) {
+	for _, p := range []Palette{Xterm256, Xterm16} {
+		if got, err := ParsePalette(p.String()); err != nil || got != p {
+			t.Errorf("ParsePalette(%q) = %v, %v", p, got, err)
+		}
+	}
+	if _, err := ParsePalette("88"); err == nil {
+		t.Errorf("expected an error for an unknown palette")
+	}
+}
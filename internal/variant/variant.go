@@ -0,0 +1,234 @@
+// Package variant derives the light variant of a dark scheme, or the dark
+// variant of a light one.
+package variant
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/contrast"
+	"github.com/da-luce/paletteport/internal/structutil"
+)
+
+// Polarity is whether a scheme draws light text on a dark background or the
+// other way around
+type Polarity int
+
+const (
+	Dark  Polarity = iota // Light text on a dark background
+	Light                 // Dark text on a light background
+)
+
+func (p Polarity) String() string {
+	switch p {
+	case Dark:
+		return "dark"
+	case Light:
+		return "light"
+	}
+	return fmt.Sprintf("Polarity(%d)", int(p))
+}
+
+// MarshalText encodes the polarity by name
+func (p Polarity) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// title returns the polarity as written in scheme names
+func (p Polarity) title() string {
+	return strings.ToUpper(p.String()[:1]) + p.String()[1:]
+}
+
+// Of returns the polarity of the scheme, from the OKLCH lightness of its
+// background
+func Of(s *adapter.AbstractScheme) (Polarity, error) {
+	bg := s.SpecialColors.Background
+	if bg == nil {
+		return 0, errors.New("scheme has no background")
+	}
+	if bg.ToOKLCH().L < 0.5 {
+		return Dark, nil
+	}
+	return Light, nil
+}
+
+// Report lists what Derive changed on top of mirroring lightness
+type Report struct {
+	From      Polarity              `json:"from"`
+	To        Polarity              `json:"to"`
+	Name      string                `json:"name,omitempty"`
+	Adjusted  []contrast.Adjustment `json:"adjusted"`  // Colors moved to keep their contrast
+	Unfixable []contrast.Pair       `json:"unfixable"` // Pairs that lost contrast all the same
+}
+
+// JSON returns the report as indented JSON
+func (r *Report) JSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// String returns the report as human-readable text
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Derived %s variant of a %s scheme", r.To, r.From)
+	if r.Name != "" {
+		fmt.Fprintf(&b, ": %s", r.Name)
+	}
+	fmt.Fprintln(&b)
+	if len(r.Adjusted) > 0 {
+		fmt.Fprintf(&b, "Adjusted to keep their contrast (%d)\n", len(r.Adjusted))
+		for _, a := range r.Adjusted {
+			fmt.Fprintf(&b, "  %s: %s -> %s (%.2f -> %.2f against %s)\n",
+				a.Foreground, a.From, a.To, a.Before, a.After, a.Background)
+		}
+	}
+	if len(r.Unfixable) > 0 {
+		fmt.Fprintf(&b, "Lost contrast (%d)\n", len(r.Unfixable))
+		for _, p := range r.Unfixable {
+			fmt.Fprintf(&b, "  %s against %s\n", p.Foreground, p.Background)
+		}
+	}
+	return b.String()
+}
+
+// Fields mirrored like the background and foreground whatever their chroma:
+// the surfaces text is drawn on and the neutral text drawn on them
+var neutralFields = map[string]bool{
+	"SpecialColors.Background":             true,
+	"SpecialColors.Foreground":             true,
+	"SpecialColors.ForegroundBright":       true,
+	"SpecialColors.CursorText":             true,
+	"SpecialColors.Selection":              true,
+	"SpecialColors.SelectedText":           true,
+	"AnsiColors.Black":                     true,
+	"AnsiColors.White":                     true,
+	"AnsiColors.BrightBlack":               true,
+	"AnsiColors.BrightWhite":               true,
+	"ScopeColors.Editor.CursorLine":        true,
+	"ScopeColors.Editor.LineNumbers":       true,
+	"ScopeColors.Editor.Highlight":         true,
+	"ScopeColors.Miscellaneous.Background": true,
+	"ScopeColors.Miscellaneous.Foreground": true,
+}
+
+// Colors with less OKLCH chroma than this are grays, mirrored as neutrals
+const neutralChroma = 0.03
+
+// Fields that trade places in the variant, so black stays the dark one
+var swapped = map[string]string{
+	"AnsiColors.Black":       "AnsiColors.White",
+	"AnsiColors.White":       "AnsiColors.Black",
+	"AnsiColors.BrightBlack": "AnsiColors.BrightWhite",
+	"AnsiColors.BrightWhite": "AnsiColors.BrightBlack",
+}
+
+// Derive returns a copy of the scheme with the opposite polarity, which must
+// be the one asked for. Neutral colors have their OKLCH lightness mirrored so
+// that the background and foreground trade lightness, keeping hue and chroma,
+// and ANSI Black and White swap places along with their bright counterparts,
+// so black stays the dark one. Accents keep their hue and chroma too, and move
+// to the lightness nearest their own that has the WCAG contrast against the
+// new background they had against the old one, up to AAA. Pairs audited by
+// contrast.SchemePairs that still lost contrast are then adjusted likewise
+// where lightness allows. The name gets the new polarity, e.g.
+// "Tokyo Night Light". The scheme itself is left alone.
+func Derive(s *adapter.AbstractScheme, to Polarity) (*adapter.AbstractScheme, *Report, error) {
+	from, err := Of(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	if from == to {
+		return nil, nil, fmt.Errorf("scheme is already %s", to)
+	}
+	if s.SpecialColors.Foreground == nil {
+		return nil, nil, errors.New("scheme has no foreground")
+	}
+
+	// Mirroring around the midpoint of the background and foreground swaps
+	// their lightness
+	bg := *s.SpecialColors.Background
+	pivot := bg.ToOKLCH().L + s.SpecialColors.Foreground.ToOKLCH().L
+	derived := *s
+	var pairs []contrast.Pair
+	var targets []float64
+	structutil.TraverseStructDFS(&derived, func(path []string, _ reflect.StructField, value reflect.Value) bool {
+		c, ok := value.Interface().(*color.Color)
+		if !ok {
+			return true
+		}
+		if c == nil {
+			return false
+		}
+		field := strings.Join(path, ".")
+		lch := c.ToOKLCH()
+		if !neutralFields[field] && lch.C >= neutralChroma {
+			pairs = append(pairs, contrast.Pair{Foreground: field, Background: "SpecialColors.Background"})
+			targets = append(targets, target(contrast.Ratio(*c, bg)))
+			return false
+		}
+		lch.L = pivot - lch.L
+		mirrored := color.FromOKLCHInGamut(lch, c.Alpha).Quantized()
+		value.Set(reflect.ValueOf(&mirrored))
+		return false
+	})
+
+	a := &derived.AnsiColors
+	a.Black, a.White = a.White, a.Black
+	a.BrightBlack, a.BrightWhite = a.BrightWhite, a.BrightBlack
+
+	report := &Report{From: from, To: to, Adjusted: []contrast.Adjustment{}, Unfixable: []contrast.Pair{}}
+	if name := s.Metadata.Name; name != nil {
+		renamed := rename(*name, from, to)
+		derived.Metadata.Name = &renamed
+		report.Name = renamed
+	}
+
+	original, err := contrast.Audit(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, result := range original.Results {
+		pair := result.Pair
+		if field, ok := swapped[pair.Foreground]; ok {
+			pair.Foreground = field
+		}
+		pairs = append(pairs, pair)
+		targets = append(targets, target(result.Ratio))
+	}
+	for i, pair := range pairs {
+		fixed, err := contrast.FixPairs(&derived, []contrast.Pair{pair}, contrast.WCAG, targets[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		report.Adjusted = append(report.Adjusted, fixed.Adjusted...)
+		report.Unfixable = append(report.Unfixable, fixed.Unfixable...)
+	}
+	return &derived, report, nil
+}
+
+// target returns the contrast a pair is kept at given its original one. Past
+// WCAG AAA it is enough to stay there, as the highest contrasts of a dark
+// scheme may be out of reach on a light background.
+func target(ratio float64) float64 {
+	return math.Min(ratio, contrast.Threshold(contrast.WCAG, contrast.AAA))
+}
+
+// rename gives the name the new polarity, replacing the old one if the name
+// ends in it
+func rename(name string, from, to Polarity) string {
+	for _, suffix := range []string{" " + from.title(), " " + from.String()} {
+		if base, ok := strings.CutSuffix(name, suffix); ok {
+			return base + " " + to.title()
+		}
+	}
+	return name + " " + to.title()
+}
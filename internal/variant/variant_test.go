@@ -0,0 +1,167 @@
+package variant
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/adapter"
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/da-luce/paletteport/internal/contrast"
+)
+
+func mustHex(t *testing.T, hex string) *color.Color {
+	t.Helper()
+	c, err := color.FromHex(hex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &c
+}
+
+// tokyoNight returns a dark scheme, with some of its colors
+func tokyoNight(t *testing.T) *adapter.AbstractScheme {
+	name := "Tokyo Night Dark"
+	s := &adapter.AbstractScheme{}
+	s.Metadata.Name = &name
+	s.SpecialColors.Background = mustHex(t, "#1a1b26")
+	s.SpecialColors.Foreground = mustHex(t, "#c0caf5")
+	s.SpecialColors.Selection = mustHex(t, "#33467c")
+	s.SpecialColors.SelectedText = mustHex(t, "#c0caf5")
+	s.AnsiColors.Black = mustHex(t, "#15161e")
+	s.AnsiColors.White = mustHex(t, "#a9b1d6")
+	s.AnsiColors.BrightBlack = mustHex(t, "#414868")
+	s.AnsiColors.BrightWhite = mustHex(t, "#c0caf5")
+	s.AnsiColors.Red = mustHex(t, "#f7768e")
+	s.AnsiColors.Green = mustHex(t, "#9ece6a")
+	s.AnsiColors.Yellow = mustHex(t, "#e0af68")
+	s.AnsiColors.Blue = mustHex(t, "#7aa2f7")
+	s.ScopeColors.Basic.Comment = mustHex(t, "#565f89")
+	return s
+}
+
+func TestOf(t *testing.T) {
+	s := tokyoNight(t)
+	if p, err := Of(s); err != nil || p != Dark {
+		t.Errorf("expected dark, got %v, %v", p, err)
+	}
+	s.SpecialColors.Background = mustHex(t, "#f0f0f0")
+	if p, err := Of(s); err != nil || p != Light {
+		t.Errorf("expected light, got %v, %v", p, err)
+	}
+	if _, err := Of(&adapter.AbstractScheme{}); err == nil {
+		t.Errorf("expected an error without a background")
+	}
+}
+
+func TestRename(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to Polarity
+		want     string
+	}{
+		{"Tokyo Night", Dark, Light, "Tokyo Night Light"},
+		{"Tokyo Night Dark", Dark, Light, "Tokyo Night Light"},
+		{"solarized light", Light, Dark, "solarized Dark"},
+		{"Darkside", Dark, Light, "Darkside Light"},
+	}
+	for _, tt := range tests {
+		if got := rename(tt.name, tt.from, tt.to); got != tt.want {
+			t.Errorf("rename(%q) = %q, expected %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDerive(t *testing.T) {
+	s := tokyoNight(t)
+	light, report, err := Derive(s, Light)
+	if err != nil {
+		t.Fatalf("Derive failed: %v", err)
+	}
+
+	if p, _ := Of(light); p != Light {
+		t.Errorf("expected a light scheme, got background %s", light.SpecialColors.Background.ToHex(true))
+	}
+	if *light.Metadata.Name != "Tokyo Night Light" || report.Name != "Tokyo Night Light" {
+		t.Errorf("expected the name to be Tokyo Night Light, got %q", *light.Metadata.Name)
+	}
+	// Background and foreground trade lightness
+	l := func(c *color.Color) float64 { return c.ToOKLCH().L }
+	if math.Abs(l(light.SpecialColors.Background)-l(s.SpecialColors.Foreground)) > 0.01 ||
+		math.Abs(l(light.SpecialColors.Foreground)-l(s.SpecialColors.Background)) > 0.01 {
+		t.Errorf("expected background and foreground to swap lightness, got %s on %s",
+			light.SpecialColors.Foreground.ToHex(true), light.SpecialColors.Background.ToHex(true))
+	}
+	// Black stays darker than white
+	a := light.AnsiColors
+	if l(a.Black) >= l(a.White) || l(a.BrightBlack) >= l(a.BrightWhite) {
+		t.Errorf("expected black darker than white, got black %s and white %s", a.Black.ToHex(true), a.White.ToHex(true))
+	}
+	// Accents keep their hue, and get dark enough to read on the light
+	// background, but no darker
+	if d := math.Abs(a.Red.ToOKLCH().H - s.AnsiColors.Red.ToOKLCH().H); d > 5 {
+		t.Errorf("expected red to keep its hue, got %s", a.Red.ToHex(true))
+	}
+	want := contrast.Ratio(*s.AnsiColors.Red, *s.SpecialColors.Background)
+	if got := contrast.Ratio(*a.Red, *light.SpecialColors.Background); got < math.Min(want, 7) || got > math.Min(want, 7)+0.2 {
+		t.Errorf("expected red to keep its contrast of %.2f, got %.2f", want, got)
+	}
+	if got := contrast.Ratio(*light.ScopeColors.Basic.Comment, *light.SpecialColors.Background); got < 1.5 {
+		t.Errorf("expected the comment color to stay readable, got %.2f", got)
+	}
+
+	// Every pair keeps its contrast, black and white trading places, or is
+	// reported
+	before, _ := contrast.Audit(s)
+	after, _ := contrast.Audit(light)
+	ratios := map[string]float64{}
+	for _, r := range before.Results {
+		field := r.Foreground
+		if other, ok := swapped[field]; ok {
+			field = other
+		}
+		ratios[field+" "+r.Background] = r.Ratio
+	}
+	for _, r := range after.Results {
+		want := ratios[r.Foreground+" "+r.Background]
+		lost := r.Ratio < math.Min(want, 7)-1e-9
+		reported := false
+		for _, p := range report.Unfixable {
+			reported = reported || p == r.Pair
+		}
+		if lost && !reported {
+			t.Errorf("%s: contrast dropped from %.2f to %.2f", r.Foreground, want, r.Ratio)
+		}
+	}
+	if !strings.Contains(report.String(), "Derived light variant of a dark scheme") {
+		t.Errorf("unexpected report:\n%s", report)
+	}
+
+	// The original is left alone
+	if s.SpecialColors.Background.ToHex(true) != "#1A1B26" || *s.Metadata.Name != "Tokyo Night Dark" {
+		t.Errorf("expected the original scheme to be unchanged")
+	}
+
+	// And back again
+	dark, _, err := Derive(light, Dark)
+	if err != nil {
+		t.Fatalf("Derive failed: %v", err)
+	}
+	if *dark.Metadata.Name != "Tokyo Night Dark" {
+		t.Errorf("expected the name back to Tokyo Night Dark, got %q", *dark.Metadata.Name)
+	}
+	if d := color.CIEDE2000.Distance(*dark.SpecialColors.Background, *s.SpecialColors.Background); d > 2 {
+		t.Errorf("expected the background back near %s, got %s", s.SpecialColors.Background.ToHex(true), dark.SpecialColors.Background.ToHex(true))
+	}
+}
+
+func TestDerive_Errors(t *testing.T) {
+	if _, _, err := Derive(tokyoNight(t), Dark); err == nil || !strings.Contains(err.Error(), "already dark") {
+		t.Errorf("expected an error deriving the polarity the scheme has, got %v", err)
+	}
+	s := tokyoNight(t)
+	s.SpecialColors.Foreground = nil
+	if _, _, err := Derive(s, Light); err == nil {
+		t.Errorf("expected an error without a foreground")
+	}
+}
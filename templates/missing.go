@@ -0,0 +1,150 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+// MissingPolicy is what rendering does with a color the template writes but
+// the scheme has no value for
+type MissingPolicy int
+
+const (
+	// Derive missing colors through the fallback rules before rendering, and
+	// leave out those no rule derives where the format allows, failing
+	// otherwise
+	MissingFallback MissingPolicy = iota
+	// Fail, listing every missing color
+	MissingFail
+	// Leave the key out where the format allows, failing otherwise
+	MissingOmit
+	// Write a placeholder color, so the gaps are easy to spot
+	MissingPlaceholder
+)
+
+var missingPolicyNames = []string{"fallback", "fail", "omit", "placeholder"}
+
+func (p MissingPolicy) String() string {
+	if int(p) >= 0 && int(p) < len(missingPolicyNames) {
+		return missingPolicyNames[p]
+	}
+	return fmt.Sprintf("MissingPolicy(%d)", int(p))
+}
+
+// ParseMissingPolicy returns the policy with the given name, as printed by
+// String
+func ParseMissingPolicy(name string) (MissingPolicy, error) {
+	for i, policyName := range missingPolicyNames {
+		if strings.ToLower(name) == policyName {
+			return MissingPolicy(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown missing color policy %q, expected %s", name, strings.Join(missingPolicyNames, ", "))
+}
+
+// DefaultPlaceholder is written for missing colors under MissingPlaceholder
+// unless another is given: magenta, which no sensible scheme is made of
+var DefaultPlaceholder = color.NewColor(1, 0, 1, 1)
+
+// Missing is the missing color policy a template is executed with
+type Missing struct {
+	Policy      MissingPolicy
+	Placeholder *color.Color // Written under MissingPlaceholder, DefaultPlaceholder if nil
+}
+
+// MissingError lists the colors a template wrote that the scheme has no value
+// for, by the key they were written under
+type MissingError struct {
+	Policy MissingPolicy
+	Keys   []string
+}
+
+func (e *MissingError) Error() string {
+	return fmt.Sprintf("missing colors (policy %s): %s", e.Policy, strings.Join(e.Keys, ", "))
+}
+
+// missingFuncs returns the functions templates resolve colors with under the
+// policy, calling record with the key of every missing color that can't be
+// left out. An error from record stops the execution:
+//
+//	color: the color of a key the format requires
+//	hex: color written as #rrggbb
+//	optional: the color of a key the format can do without, nil to leave it out
+//
+// Each takes the color followed by the key it is written under, e.g.
+//
+//	black = '{{ hex .Black "black" }}'
+//	{{ with optional .Cursor "cursor" }}cursor = '{{ .Hex }}'{{ end }}
+func missingFuncs(m Missing, record func(key string) error) template.FuncMap {
+	placeholder := DefaultPlaceholder
+	if m.Placeholder != nil {
+		placeholder = *m.Placeholder
+	}
+	required := func(v any, key string) (*color.Color, error) {
+		c, err := colorValue(v)
+		if c != nil || err != nil {
+			return c, err
+		}
+		if m.Policy == MissingPlaceholder {
+			return &placeholder, nil
+		}
+		// The output is discarded, so any color will do
+		return &color.Color{}, record(key)
+	}
+	return template.FuncMap{
+		"color": required,
+		"hex": func(v any, key string) (string, error) {
+			c, err := required(v, key)
+			if err != nil {
+				return "", err
+			}
+			return c.Hex(), nil
+		},
+		"optional": func(v any, key string) (*color.Color, error) {
+			c, err := colorValue(v)
+			if c != nil || err != nil {
+				return c, err
+			}
+			switch m.Policy {
+			case MissingPlaceholder:
+				return &placeholder, nil
+			case MissingFail:
+				return nil, record(key)
+			}
+			return nil, nil
+		},
+	}
+}
+
+// Execute executes the template with the data, resolving missing colors by
+// the policy. Under every policy but MissingPlaceholder, colors that are
+// missing and can't be left out fail the execution with a MissingError
+// listing all of them.
+func Execute(tmpl *template.Template, data any, m Missing) (string, error) {
+	tmpl, err := tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+	var keys []string
+	seen := make(map[string]bool)
+	tmpl.Funcs(missingFuncs(m, func(key string) error {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+		return nil
+	}))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	if len(keys) > 0 {
+		return "", &MissingError{Policy: m.Policy, Keys: keys}
+	}
+	return buf.String(), nil
+}
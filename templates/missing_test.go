@@ -0,0 +1,61 @@
+package templates
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"text/template"
+
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+func TestExecute_Missing(t *testing.T) {
+	tmpl := template.Must(template.New("test").Funcs(Funcs(FormatText)).Parse(
+		`bg={{ hex .Background "background" }} fg={{ hex .Foreground "foreground" }}` +
+			`{{ with optional .Cursor "cursor" }} cursor={{ .Hex }}{{ end }}`))
+	bg := color.NewColor(0, 0, 0, 1)
+	data := struct{ Background, Foreground, Cursor *color.Color }{Background: &bg}
+	green := color.NewColor(0, 1, 0, 1)
+
+	tests := []struct {
+		missing Missing
+		want    string
+		keys    []string
+	}{
+		{Missing{Policy: MissingFallback}, "", []string{"foreground"}},
+		{Missing{Policy: MissingOmit}, "", []string{"foreground"}},
+		{Missing{Policy: MissingFail}, "", []string{"foreground", "cursor"}},
+		{Missing{Policy: MissingPlaceholder}, "bg=#000000 fg=#ff00ff cursor=#ff00ff", nil},
+		{Missing{Policy: MissingPlaceholder, Placeholder: &green}, "bg=#000000 fg=#00ff00 cursor=#00ff00", nil},
+	}
+	for _, tc := range tests {
+		got, err := Execute(tmpl, data, tc.missing)
+		var missingErr *MissingError
+		if errors.As(err, &missingErr) {
+			if !reflect.DeepEqual(missingErr.Keys, tc.keys) {
+				t.Errorf("%s: expected missing %v, got %v", tc.missing.Policy, tc.keys, missingErr.Keys)
+			}
+			continue
+		}
+		if err != nil || tc.keys != nil || got != tc.want {
+			t.Errorf("%s: expected %q (missing %v), got %q (%v)", tc.missing.Policy, tc.want, tc.keys, got, err)
+		}
+	}
+
+	fg := color.NewColor(1, 1, 1, 1)
+	data.Foreground = &fg
+	if got, err := Execute(tmpl, data, Missing{Policy: MissingOmit}); err != nil || got != "bg=#000000 fg=#ffffff" {
+		t.Errorf("expected the optional cursor left out, got %q (%v)", got, err)
+	}
+}
+
+func TestParseMissingPolicy(t *testing.T) {
+	for _, p := range []MissingPolicy{MissingFallback, MissingFail, MissingOmit, MissingPlaceholder} {
+		if got, err := ParseMissingPolicy(p.String()); err != nil || got != p {
+			t.Errorf("ParseMissingPolicy(%q) = %v, %v", p, got, err)
+		}
+	}
+	if _, err := ParseMissingPolicy("red"); err == nil {
+		t.Errorf("expected an error for an unknown policy")
+	}
+}
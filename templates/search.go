@@ -0,0 +1,77 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+// SearchPath lists the directories searched for a template, in order, before
+// falling back to the embedded templates. A file in one of them named like an
+// embedded template, e.g. alacritty.toml.tmpl, replaces it.
+type SearchPath []string
+
+// UserDir returns the directory of the user's own templates,
+// $XDG_CONFIG_HOME/paletteport/templates, or "" if it can't be determined
+func UserDir() string {
+	config := os.Getenv("XDG_CONFIG_HOME")
+	if config == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		config = filepath.Join(home, ".config")
+	}
+	return filepath.Join(config, "paletteport", "templates")
+}
+
+// DefaultSearchPath searches the given directories, then the user's template
+// directory
+func DefaultSearchPath(dirs ...string) SearchPath {
+	if user := UserDir(); user != "" {
+		dirs = append(dirs, user)
+	}
+	return dirs
+}
+
+// ReadFile returns the first template of the given name on the path, along
+// with where it was found
+func (p SearchPath) ReadFile(name string) ([]byte, string, error) {
+	for _, dir := range p {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return data, path, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, "", fmt.Errorf("failed to read template: %w", err)
+		}
+	}
+
+	data, err := FS.ReadFile(name)
+	if err != nil {
+		return nil, "", fmt.Errorf("no template %q", name)
+	}
+	return data, "embedded " + name, nil
+}
+
+// Lookup finds and parses the named template
+func (p SearchPath) Lookup(name string) (*template.Template, error) {
+	data, origin, err := p.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(origin, string(data))
+}
+
+// ParseFile parses the template file at the given path
+func ParseFile(path string) (*template.Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	return Parse(path, string(data))
+}
@@ -0,0 +1,74 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTemplate(t *testing.T, dir, name, text string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUserDir(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	if got, want := UserDir(), filepath.Join("/xdg", "paletteport", "templates"); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "/home/me")
+	if got, want := UserDir(), filepath.Join("/home/me", ".config", "paletteport", "templates"); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestSearchPath_Order(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	flagDir := t.TempDir()
+
+	name := "alacritty.toml.tmpl"
+	writeTemplate(t, filepath.Join(xdg, "paletteport", "templates"), name, "{{/* format: toml */ -}}\n# user\n")
+	writeTemplate(t, flagDir, name, "{{/* format: toml */ -}}\n# flag\n")
+
+	tests := []struct {
+		path SearchPath
+		want string
+	}{
+		{DefaultSearchPath(flagDir), "# flag"},
+		{DefaultSearchPath(t.TempDir()), "# user"},
+		{SearchPath{t.TempDir()}, "[colors.primary]"},
+	}
+	for _, tc := range tests {
+		data, origin, err := tc.path.ReadFile(name)
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		if !strings.Contains(string(data), tc.want) {
+			t.Errorf("expected %q from %v, got %s:\n%s", tc.want, tc.path, origin, data)
+		}
+	}
+
+	if _, err := DefaultSearchPath().Lookup("missing.tmpl"); err == nil || !strings.Contains(err.Error(), `no template "missing.tmpl"`) {
+		t.Errorf("expected an error for a missing template, got %v", err)
+	}
+}
+
+// Overrides must declare their format like the embedded templates
+func TestSearchPath_LookupUndeclared(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "kitty.conf.tmpl", "foreground {{ .Foreground.Hex }}\n")
+
+	_, err := SearchPath{dir}.Lookup("kitty.conf.tmpl")
+	if err == nil || !strings.Contains(err.Error(), filepath.Join(dir, "kitty.conf.tmpl")) {
+		t.Errorf("expected an error naming the override, got %v", err)
+	}
+}
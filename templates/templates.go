@@ -1,14 +1,251 @@
 package templates
 
 import (
-	"html/template"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/da-luce/paletteport/internal/color"
+)
+
+// Format is the output format of a template, which decides how values are
+// escaped by the escape function
+type Format string
+
+const (
+	FormatText      Format = "text" // Written as is
+	FormatTOML      Format = "toml"
+	FormatJSON      Format = "json"
+	FormatYAML      Format = "yaml"
+	FormatXML       Format = "xml"
+	FormatKitty     Format = "kitty"     // kitty.conf, a setting or comment per line
+	FormatConfigObj Format = "configobj" // Terminator's INI-like config
 )
 
-func Indent(s template.HTML, prefix string) template.HTML {
-	lines := strings.Split(string(s), "\n")
+// Templates declare their format in a comment on their first line, e.g.
+//
+//	{{/* format: yaml */ -}}
+var formatDeclaration = regexp.MustCompile(`^\{\{-?\s*/\*\s*format:\s*(\w+)\s*\*/\s*-?\}\}`)
+
+// DeclaredFormat returns the format declared by the template text
+func DeclaredFormat(text string) (Format, error) {
+	match := formatDeclaration.FindStringSubmatch(text)
+	if match == nil {
+		return "", fmt.Errorf("template does not declare its format, e.g. {{/* format: yaml */ -}}")
+	}
+	format := Format(match[1])
+	if _, ok := escapers[format]; !ok {
+		return "", fmt.Errorf("unknown template format %q", format)
+	}
+	return format, nil
+}
+
+// Parse parses a template, which must declare its format, with the functions
+// for that format
+func Parse(name string, text string) (*template.Template, error) {
+	format, err := DeclaredFormat(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return template.New(name).Funcs(Funcs(format)).Parse(text)
+}
+
+// Funcs returns the functions available to templates of the given format:
+//
+//	indent: prefixes every line of a string
+//	toml, json, yaml: quote a value as a string of that format
+//	xml: escape a value for XML text or attributes
+//	kitty: keep a value on its line of a kitty.conf
+//	configobj: quote a value or section name for ConfigObj, if need be
+//	escape: whichever of the above the template's format calls for
+//	cterm, cterm16: the index of the nearest color of the xterm 256 or 16-color
+//	palette, for formats like vim's ctermfg
+//	color, hex, optional: resolve colors that may be missing, see Execute
+//
+// Values may be strings, string pointers or anything printable, and colors or
+// color pointers for cterm. Nil values are written as empty strings. Templates
+// executed other than by Execute fail on the first missing color.
+func Funcs(format Format) template.FuncMap {
+	funcs := template.FuncMap{
+		"indent":    Indent,
+		"toml":      TOMLString,
+		"json":      JSONString,
+		"yaml":      YAMLString,
+		"xml":       XMLText,
+		"kitty":     KittyText,
+		"configobj": ConfigObjString,
+		"escape":    escapers[format],
+		"cterm":     CtermIndex,
+		"cterm16":   Cterm16Index,
+	}
+	for name, f := range missingFuncs(Missing{}, func(key string) error {
+		return fmt.Errorf("missing color %s", key)
+	}) {
+		funcs[name] = f
+	}
+	return funcs
+}
+
+var escapers = map[Format]func(any) string{
+	FormatText:      func(v any) string { return stringValue(v) },
+	FormatTOML:      TOMLString,
+	FormatJSON:      JSONString,
+	FormatYAML:      YAMLString,
+	FormatXML:       XMLText,
+	FormatKitty:     KittyText,
+	FormatConfigObj: ConfigObjString,
+}
+
+func Indent(s string, prefix string) string {
+	lines := strings.Split(s, "\n")
 	for i, line := range lines {
 		lines[i] = prefix + line
 	}
-	return template.HTML(strings.Join(lines, "\n"))
+	return strings.Join(lines, "\n")
+}
+
+// JSONString returns the value as a quoted JSON string. Unlike json.Marshal,
+// characters special to HTML are left alone.
+func JSONString(v any) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	// Strings always encode
+	_ = encoder.Encode(stringValue(v))
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// YAMLString returns the value as a double-quoted YAML string, which accepts
+// the same escapes as JSON
+func YAMLString(v any) string {
+	return JSONString(v)
+}
+
+// TOMLString returns the value as a TOML basic string
+func TOMLString(v any) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range stringValue(v) {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// XMLText returns the value escaped for XML text and quoted attributes
+func XMLText(v any) string {
+	var buf bytes.Buffer
+	// Writes to a buffer don't fail
+	_ = xml.EscapeText(&buf, []byte(stringValue(v)))
+	return buf.String()
+}
+
+// KittyText returns the value for a line of a kitty.conf. The format has no
+// quoting or escapes, so line breaks, which would start a setting of their own,
+// and other control characters are written as spaces.
+func KittyText(v any) string {
+	return spaceControls(stringValue(v))
+}
+
+// ConfigObjString returns the value as a ConfigObj value or section name,
+// quoted if ConfigObj would read part of it as syntax: brackets, comments,
+// list separators, a leading quote or spaces at either end. ConfigObj has no
+// escapes either, so line breaks and other control characters are written as
+// spaces, and double quotes as single ones in quoted values holding both kinds.
+func ConfigObjString(v any) string {
+	s := spaceControls(stringValue(v))
+	if s != "" && strings.TrimSpace(s) == s && s[0] != '"' && s[0] != '\'' && !strings.ContainsAny(s, "[]#,") {
+		return s
+	}
+	switch {
+	case !strings.Contains(s, `"`):
+		return `"` + s + `"`
+	case !strings.Contains(s, `'`):
+		return `'` + s + `'`
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `'`) + `"`
+}
+
+func spaceControls(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// Metric colors are matched to palettes with in templates
+const ctermMetric = color.CIEDE2000
+
+// CtermIndex returns the index of the color of the xterm 256-color palette
+// nearest to the color value. See color.NearestXterm256.
+func CtermIndex(v any) (string, error) {
+	c, err := colorValue(v)
+	if c == nil || err != nil {
+		return "", err
+	}
+	return strconv.Itoa(c.NearestXterm256(ctermMetric)), nil
+}
+
+// Cterm16Index returns the index of the xterm system color nearest to the
+// color value. See color.NearestXterm16.
+func Cterm16Index(v any) (string, error) {
+	c, err := colorValue(v)
+	if c == nil || err != nil {
+		return "", err
+	}
+	return strconv.Itoa(c.NearestXterm16(ctermMetric)), nil
+}
+
+// colorValue returns the color a template value holds, or nil if it is nil
+func colorValue(v any) (*color.Color, error) {
+	switch c := v.(type) {
+	case nil:
+		return nil, nil
+	case *color.Color:
+		return c, nil
+	case color.Color:
+		return &c, nil
+	}
+	return nil, fmt.Errorf("expected a color, got %T", v)
+}
+
+// stringValue formats a template value, dereferencing pointers
+func stringValue(v any) string {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return ""
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return ""
+	}
+	if s, ok := rv.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprint(rv.Interface())
 }
@@ -0,0 +1,180 @@
+package templates
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/da-luce/paletteport/internal/color"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+var specialStrings = []string{
+	`plain`,
+	`O'Brien & Co`,
+	`"quoted" \ back`,
+	`Ünïcødé 🎨 <tag>`,
+	"line\nbreak\ttab",
+	"",
+}
+
+func TestEscape_RoundTrip(t *testing.T) {
+	for _, s := range specialStrings {
+		var fromJSON string
+		if err := json.Unmarshal([]byte(JSONString(s)), &fromJSON); err != nil || fromJSON != s {
+			t.Errorf("json: %q became %s (%v)", s, JSONString(s), err)
+		}
+
+		var fromYAML struct{ V string }
+		if err := yaml.Unmarshal([]byte("v: "+YAMLString(s)), &fromYAML); err != nil || fromYAML.V != s {
+			t.Errorf("yaml: %q became %s (%v)", s, YAMLString(s), err)
+		}
+
+		var fromTOML struct{ V string }
+		if err := toml.Unmarshal([]byte("V = "+TOMLString(s)), &fromTOML); err != nil || fromTOML.V != s {
+			t.Errorf("toml: %q became %s (%v)", s, TOMLString(s), err)
+		}
+
+		var fromXML struct {
+			V string `xml:",chardata"`
+		}
+		if err := xml.Unmarshal([]byte("<v>"+XMLText(s)+"</v>"), &fromXML); err != nil || fromXML.V != s {
+			t.Errorf("xml: %q became %s (%v)", s, XMLText(s), err)
+		}
+	}
+}
+
+func TestEscape_Values(t *testing.T) {
+	name := "O'Brien & Co"
+	var unset *string
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{name, `"O'Brien & Co"`},
+		{&name, `"O'Brien & Co"`},
+		{unset, `""`},
+		{nil, `""`},
+		{42, `"42"`},
+	}
+	for _, tc := range tests {
+		if got := JSONString(tc.value); got != tc.want {
+			t.Errorf("JSONString(%#v) = %s, want %s", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestKittyText(t *testing.T) {
+	if got, want := KittyText("Night\nbackground #ff0000\tdim"), "Night background #ff0000 dim"; got != want {
+		t.Errorf("KittyText kept a line break: got %q, want %q", got, want)
+	}
+	if got := KittyText(`"C#" [dark]`); got != `"C#" [dark]` {
+		t.Errorf("KittyText changed printable characters: %q", got)
+	}
+}
+
+func TestConfigObjString(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"Afterglow", "Afterglow"},
+		{"Solarized Light", "Solarized Light"},
+		{"#fdf6e3", `"#fdf6e3"`},
+		{"[dark]", `"[dark]"`},
+		{`O'Brien's "Night"`, `O'Brien's "Night"`},
+		{`"Night" [2]`, `'"Night" [2]'`},
+		{`"Night" O'Brien`, `"'Night' O'Brien"`},
+		{"line\nbreak", "line break"},
+		{" padded", `" padded"`},
+		{"", `""`},
+	}
+	for _, tc := range tests {
+		if got := ConfigObjString(tc.value); got != tc.want {
+			t.Errorf("ConfigObjString(%q) = %s, want %s", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestCterm(t *testing.T) {
+	red := color.NewColor(1, 0.02, 0.02, 1)
+	var unset *color.Color
+	tests := []struct {
+		value     any
+		want256   string
+		want16    string
+		wantError bool
+	}{
+		{&red, "196", "9", false},
+		{red, "196", "9", false},
+		{unset, "", "", false},
+		{nil, "", "", false},
+		{"#ff0000", "", "", true},
+	}
+	for _, tc := range tests {
+		got256, err := CtermIndex(tc.value)
+		if (err != nil) != tc.wantError || got256 != tc.want256 {
+			t.Errorf("CtermIndex(%#v) = %q, %v, want %q", tc.value, got256, err, tc.want256)
+		}
+		got16, err := Cterm16Index(tc.value)
+		if (err != nil) != tc.wantError || got16 != tc.want16 {
+			t.Errorf("Cterm16Index(%#v) = %q, %v, want %q", tc.value, got16, err, tc.want16)
+		}
+	}
+
+	tmpl, err := Parse("test", "{{/* format: text */ -}}\nhi Error ctermfg={{ cterm . }}")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, &red); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if buf.String() != "hi Error ctermfg=196" {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestParse(t *testing.T) {
+	tmpl, err := Parse("test", "{{/* format: xml */ -}}\n<name>{{ escape .Name }}</name> {{ json .Name }}")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string{"Name": "A & B"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if want := `<name>A &amp; B</name> "A & B"`; buf.String() != want {
+		t.Errorf("expected %s, got %s", want, buf.String())
+	}
+
+	for _, text := range []string{"name: {{ .Name }}", "{{/* format: ini */}}"} {
+		if _, err := Parse("test", text); err == nil {
+			t.Errorf("expected an error for %q", text)
+		}
+	}
+}
+
+// Every embedded template must declare a known format
+func TestEmbedded_DeclareFormat(t *testing.T) {
+	names, err := fs.Glob(FS, "*.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		data, err := FS.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Parse(name, string(data)); err != nil {
+			t.Errorf("%v", err)
+		}
+		if format, _ := DeclaredFormat(string(data)); strings.Contains(name, ".json.") && format != FormatJSON {
+			t.Errorf("%s: expected format json, got %s", name, format)
+		}
+	}
+}